@@ -0,0 +1,66 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"auth-service/tokens"
+	"shared/dbservicepb"
+	"shared/entitlements"
+)
+
+// entitlementTokenHandler gère POST /entitlements/token : signe un jeton
+// d'entitlements de courte durée pour l'utilisateur de la session, à partir
+// de son enregistrement et de son plan dans db-service
+func entitlementTokenHandler(issuer *tokens.EntitlementIssuer, db *dbservicepb.Client) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*tokens.Claims)
+		if !ok {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "claims_not_found"})
+		}
+
+		// db-service est appelé au nom de l'utilisateur, avec son propre jeton de session
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		ctx := dbservicepb.WithToken(c.UserContext(), token)
+
+		user, err := db.Users.GetUserByClerkID(ctx, &dbservicepb.GetUserByClerkIDRequest{ClerkUserId: claims.UserID})
+		if err != nil {
+			return dbError(c, err)
+		}
+		set, err := entitlements.Fetch(ctx, db.Plans, &dbservicepb.GetUserEntitlementsRequest{UserId: user.GetId()})
+		if err != nil {
+			return dbError(c, err)
+		}
+
+		signed, entClaims, err := issuer.Issue(user.GetUuid(), user.GetSubscriptionTier(), set)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "token_not_issued"})
+		}
+
+		return c.JSON(fiber.Map{
+			"token":        signed,
+			"expires_at":   entClaims.ExpiresAt(),
+			"user_uuid":    entClaims.Subject,
+			"tier":         entClaims.Tier,
+			"plan":         entClaims.Plan,
+			"entitlements": entClaims.Entitlements,
+		})
+	}
+}
+
+// dbError convertit une erreur gRPC de db-service en réponse HTTP
+func dbError(c *fiber.Ctx, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user_not_found"})
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+	default:
+		log.Printf("db-service error: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "db_service_unavailable"})
+	}
+}
@@ -4,29 +4,53 @@ import (
 	"log"
 	"os"
 	"strings"
-	"time"
 
-	"github.com/clerk/clerk-sdk-go/v2"
-	"github.com/clerk/clerk-sdk-go/v2/jwt"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"auth-service/oauth"
+	"auth-service/tokens"
+	"shared/dbservicepb"
 )
 
 func main() {
-	// 1) Création de l'application Fiber
-	app := fiber.New()
+	// 1) Sélection du vérificateur de jetons (Clerk par défaut, ou émetteur local hors-ligne)
+	verifier, issuer, err := tokens.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// 2) Chargement de la clé secrète Clerk depuis les variables d'environnement
-	secret := os.Getenv("CLERK_SECRET_KEY")
-	if secret == "" {
-		log.Fatal("CLERK_SECRET_KEY is not set")
+	// 2) Client OAuth (échange de code / refresh), seulement si le secret client est configuré
+	var oauthClient *oauth.Client
+	if cfg := oauth.ConfigFromEnv(); cfg.ClientSecret != "" {
+		if oauthClient, err = oauth.NewClient(cfg); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Println("OAUTH_CLIENT_SECRET is not set, /oauth endpoints are disabled")
+	}
+
+	// 3) Jetons d'entitlements : la clé de signature est publiée même sans db-service,
+	// pour que les services puissent vérifier les jetons déjà émis
+	entIssuer, err := tokens.EntitlementIssuerFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var db *dbservicepb.Client
+	if addr := os.Getenv("DB_SERVICE_GRPC_ADDR"); addr != "" {
+		if db, err = dbservicepb.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials())); err != nil {
+			log.Fatalf("failed connecting to db-service: %v", err)
+		}
+		defer db.Close()
+	} else {
+		log.Println("DB_SERVICE_GRPC_ADDR is not set, /entitlements/token is disabled")
 	}
-	clerk.SetKey(secret)
 
-	// 3) Déclaration des routes
-	app.Post("/verify", verifyHandler)
-	app.Get("/me", authMiddleware, meHandler)
+	// 4) Création de l'application Fiber et déclaration des routes
+	app := newApp(verifier, issuer, oauthClient, entIssuer, db)
 
-	// 4) Lancement du serveur
+	// 5) Lancement du serveur
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -35,70 +59,113 @@ func main() {
 	log.Fatal(app.Listen(":" + port))
 }
 
-// verifyHandler gère POST /verify, valide le token et renvoie les claims
-func verifyHandler(c *fiber.Ctx) error {
-	// Extraction du token depuis le header Authorization
-	auth := c.Get("Authorization")
-	token := strings.TrimPrefix(auth, "Bearer ")
-
-	// Vérification du jeton avec Clerk JWT (manuelle, sans caching automatique)
-	// Récupération de la clé publique JWK et vérification
-	// Ici, pour simplifier, on laisse Clerk middleware gérer le caching via JWTVerifyOptions
-	claimsProto, err := jwt.Verify(c.Context(), &jwt.VerifyParams{Token: token})
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_token"})
-	}
+// newApp déclare les routes. issuer n'est non-nil qu'en mode local : il expose
+// alors aussi un endpoint de création de jetons et sa JWKS. Les routes /oauth
+// ne sont déclarées que si oauthClient est non-nil, et /entitlements/token que
+// si db est non-nil.
+func newApp(verifier tokens.Verifier, issuer *tokens.LocalIssuer, oauthClient *oauth.Client, entIssuer *tokens.EntitlementIssuer, db *dbservicepb.Client) *fiber.App {
+	app := fiber.New()
 
-	// claimsProto est de type *clerk.SessionClaims
-	claims := claimsProto
+	app.Post("/verify", verifyHandler(verifier))
+	app.Get("/me", authMiddleware(verifier), meHandler)
 
-	// Préparation des timestamps
-	issuedAt := time.Unix(*claims.RegisteredClaims.IssuedAt, 0)
-	expiresAt := time.Unix(*claims.RegisteredClaims.Expiry, 0)
+	if oauthClient != nil {
+		app.Post("/oauth/token", oauthTokenHandler(oauthClient))
+		app.Post("/oauth/refresh", oauthRefreshHandler(oauthClient))
+	}
 
-	// Retourne les claims validés
-	return c.JSON(fiber.Map{
-		"session_id": claims.Claims.SessionID,
-		"user_id":    claims.RegisteredClaims.Subject,
-		"issued_at":  issuedAt,
-		"expires_at": expiresAt,
+	app.Get("/.well-known/entitlements-jwks.json", func(c *fiber.Ctx) error {
+		return c.JSON(entIssuer.JWKS())
 	})
-}
+	if db != nil {
+		app.Post("/entitlements/token", authMiddleware(verifier), entitlementTokenHandler(entIssuer, db))
+	}
 
-// authMiddleware protège les routes en validant le token
-func authMiddleware(c *fiber.Ctx) error {
-	// Extraction du token
-	auth := c.Get("Authorization")
-	token := strings.TrimPrefix(auth, "Bearer ")
+	if issuer != nil {
+		app.Post("/dev/token", devTokenHandler(issuer))
+		app.Get("/.well-known/jwks.json", func(c *fiber.Ctx) error {
+			return c.JSON(issuer.JWKS())
+		})
+	}
 
-	// Vérification JWT
-	claims, err := jwt.Verify(c.Context(), &jwt.VerifyParams{Token: token})
-	if err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+	return app
+}
+
+// verifyHandler gère POST /verify, valide le token et renvoie les claims
+func verifyHandler(verifier tokens.Verifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Extraction du token depuis le header Authorization
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+
+		claims, err := verifier.Verify(c.UserContext(), token)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_token"})
+		}
+
+		// Retourne les claims validés
+		return c.JSON(claimsView(claims))
 	}
+}
 
-	// Injection des claims de type *clerk.SessionClaims dans le contexte Fiber
-	c.Locals("claims", claims)
-	return c.Next()
+// authMiddleware protège les routes en validant le token
+func authMiddleware(verifier tokens.Verifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+
+		claims, err := verifier.Verify(c.UserContext(), token)
+		if err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+		}
+
+		// Injection des claims de type *tokens.Claims dans le contexte Fiber
+		c.Locals("claims", claims)
+		return c.Next()
+	}
 }
 
 // meHandler gère GET /me, renvoie les informations de l'utilisateur extraites des claims
 func meHandler(c *fiber.Ctx) error {
 	// Récupération des claims depuis le contexte
-	claims, ok := c.Locals("claims").(*clerk.SessionClaims)
+	claims, ok := c.Locals("claims").(*tokens.Claims)
 	if !ok {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "claims_not_found"})
 	}
 
-	// Préparation des timestamps
-	issuedAt := time.Unix(*claims.RegisteredClaims.IssuedAt, 0)
-	expiresAt := time.Unix(*claims.RegisteredClaims.Expiry, 0)
-
 	// Renvoi d'une vue minimale de l'utilisateur
-	return c.JSON(fiber.Map{
-		"session_id": claims.Claims.SessionID,
-		"user_id":    claims.RegisteredClaims.Subject,
-		"issued_at":  issuedAt,
-		"expires_at": expiresAt,
-	})
+	return c.JSON(claimsView(claims))
+}
+
+// devTokenHandler gère POST /dev/token (mode local uniquement) et crée un jeton
+func devTokenHandler(issuer *tokens.LocalIssuer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req struct {
+			UserID    string `json:"user_id"`
+			SessionID string `json:"session_id"`
+		}
+		if err := c.BodyParser(&req); err != nil || req.UserID == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "user_id is required"})
+		}
+		if req.SessionID == "" {
+			req.SessionID = "sess_local"
+		}
+
+		token, claims, err := issuer.Issue(req.UserID, req.SessionID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "token_not_issued"})
+		}
+
+		view := claimsView(claims)
+		view["token"] = token
+		return c.JSON(view)
+	}
+}
+
+// claimsView est la représentation JSON commune des claims
+func claimsView(claims *tokens.Claims) fiber.Map {
+	return fiber.Map{
+		"session_id": claims.SessionID,
+		"user_id":    claims.UserID,
+		"issued_at":  claims.IssuedAt,
+		"expires_at": claims.ExpiresAt,
+	}
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"auth-service/tokens"
+)
+
+// newLocalApp returns the app in local mode, its issuer minting tokens at
+// *now, and a second issuer with another key.
+func newLocalApp(t *testing.T, now *time.Time) (*fiber.App, *tokens.LocalIssuer, *tokens.LocalIssuer) {
+	t.Helper()
+	newIssuer := func() *tokens.LocalIssuer {
+		_, key, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		issuer, err := tokens.NewLocalIssuer(key, tokens.LocalIssuerOptions{Now: func() time.Time { return *now }})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issuer
+	}
+	issuer := newIssuer()
+	return newApp(issuer, issuer, nil, nil, nil), issuer, newIssuer()
+}
+
+func TestVerifyAndMe(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	app, issuer, other := newLocalApp(t, &now)
+
+	token, _, err := issuer.Issue("user_1", "sess_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, _, err := other.Issue("user_1", "sess_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuedAt := now
+	later := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name   string
+		token  string
+		now    time.Time
+		verify int // status of POST /verify
+		me     int // status of GET /me
+	}{
+		{name: "valid", token: token, now: issuedAt, verify: http.StatusOK, me: http.StatusOK},
+		{name: "missing", now: issuedAt, verify: http.StatusUnauthorized, me: http.StatusForbidden},
+		{name: "malformed", token: "not.a.token", now: issuedAt, verify: http.StatusUnauthorized, me: http.StatusForbidden},
+		{name: "other key", token: foreign, now: issuedAt, verify: http.StatusUnauthorized, me: http.StatusForbidden},
+		{name: "expired", token: token, now: later, verify: http.StatusUnauthorized, me: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = tt.now
+			for _, route := range []struct {
+				method, path string
+				want         int
+			}{
+				{http.MethodPost, "/verify", tt.verify},
+				{http.MethodGet, "/me", tt.me},
+			} {
+				req := httptest.NewRequest(route.method, route.path, nil)
+				if tt.token != "" {
+					req.Header.Set("Authorization", "Bearer "+tt.token)
+				}
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != route.want {
+					t.Errorf("%s: status = %d (%s), want %d", route.path, resp.StatusCode, body, route.want)
+					continue
+				}
+				if route.want != http.StatusOK {
+					continue
+				}
+
+				var view struct {
+					UserID    string    `json:"user_id"`
+					SessionID string    `json:"session_id"`
+					IssuedAt  time.Time `json:"issued_at"`
+					ExpiresAt time.Time `json:"expires_at"`
+				}
+				if err := json.Unmarshal(body, &view); err != nil {
+					t.Fatalf("%s: %v", route.path, err)
+				}
+				if view.UserID != "user_1" || view.SessionID != "sess_1" ||
+					!view.IssuedAt.Equal(issuedAt) || !view.ExpiresAt.Equal(issuedAt.Add(time.Hour)) {
+					t.Errorf("%s: claims = %+v", route.path, view)
+				}
+			}
+		})
+	}
+}
+
+func TestDevToken(t *testing.T) {
+	now := time.Now()
+	app, issuer, _ := newLocalApp(t, &now)
+
+	req := httptest.NewRequest(http.MethodPost, "/dev/token", nil)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("without user_id: status = %d, want 400", resp.StatusCode)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/dev/token", strings.NewReader(`{"user_id":"user_2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var view struct {
+		Token     string `json:"token"`
+		SessionID string `json:"session_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&view); err != nil {
+		t.Fatal(err)
+	}
+	claims, err := issuer.Verify(req.Context(), view.Token)
+	if err != nil {
+		t.Fatalf("minted token does not verify: %v", err)
+	}
+	if claims.UserID != "user_2" || claims.SessionID != "sess_local" || view.SessionID != "sess_local" {
+		t.Errorf("claims = %+v, want user_2 in sess_local", claims)
+	}
+}
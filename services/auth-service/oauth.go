@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"auth-service/oauth"
+)
+
+// oauthTokenHandler gère POST /oauth/token : échange d'un code d'autorisation (PKCE)
+func oauthTokenHandler(client *oauth.Client) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req struct {
+			Code         string `json:"code" form:"code"`
+			CodeVerifier string `json:"code_verifier" form:"code_verifier"`
+			RedirectURI  string `json:"redirect_uri" form:"redirect_uri"`
+		}
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_request"})
+		}
+
+		token, err := client.Exchange(c.UserContext(), req.Code, req.CodeVerifier, req.RedirectURI)
+		if err != nil {
+			return oauthError(c, err)
+		}
+		return c.JSON(token)
+	}
+}
+
+// oauthRefreshHandler gère POST /oauth/refresh : renouvellement des jetons
+func oauthRefreshHandler(client *oauth.Client) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req struct {
+			RefreshToken string `json:"refresh_token" form:"refresh_token"`
+		}
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_request"})
+		}
+
+		token, err := client.Refresh(c.UserContext(), req.RefreshToken)
+		if err != nil {
+			return oauthError(c, err)
+		}
+		return c.JSON(token)
+	}
+}
+
+// oauthError convertit les erreurs d'échange en réponses au format OAuth
+func oauthError(c *fiber.Ctx, err error) error {
+	var oauthErr *oauth.Error
+	switch {
+	case errors.Is(err, oauth.ErrInvalidRedirectURI), errors.Is(err, oauth.ErrInvalidCodeVerifier):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_request", "error_description": err.Error()})
+	case errors.As(err, &oauthErr):
+		// Les erreurs d'authentification client viennent de notre configuration, pas de l'appelant
+		status := fiber.StatusBadRequest
+		if oauthErr.Code == "invalid_client" || oauthErr.Status >= 500 {
+			status = fiber.StatusBadGateway
+		}
+		return c.Status(status).JSON(oauthErr)
+	default:
+		log.Printf("OAuth token endpoint error: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "upstream_unavailable"})
+	}
+}
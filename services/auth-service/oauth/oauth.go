@@ -0,0 +1,209 @@
+// Package oauth exchanges authorization codes and refresh tokens with the
+// OAuth provider on behalf of the extension, keeping the client secret server-side.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidRedirectURI is returned for redirect URIs outside the allowlist.
+	ErrInvalidRedirectURI = errors.New("redirect_uri is not allowed")
+	// ErrInvalidCodeVerifier is returned for PKCE verifiers not matching RFC 7636.
+	ErrInvalidCodeVerifier = errors.New("code_verifier is invalid")
+)
+
+// codeVerifierPattern is the RFC 7636 format of a PKCE code verifier.
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// Config configures a Client.
+type Config struct {
+	ClientID      string
+	ClientSecret  string
+	TokenEndpoint string
+	// AllowedRedirectURIs lists the exact chrome-extension:// redirect URIs accepted.
+	AllowedRedirectURIs []string
+	// HTTPClient calls the token endpoint. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Token is the normalized token response returned to the extension.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresIn    int64     `json:"expires_in"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	IDToken      string    `json:"id_token,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+}
+
+// Error is an OAuth error response returned by the provider.
+type Error struct {
+	Status      int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth: %s: %s", e.Code, e.Description)
+	}
+	return "oauth: " + e.Code
+}
+
+// Client talks to the provider's token endpoint.
+type Client struct {
+	cfg Config
+}
+
+// NewClient validates the configuration and returns a Client.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.TokenEndpoint == "" {
+		return nil, errors.New("oauth: client ID, client secret and token endpoint are required")
+	}
+	for _, uri := range cfg.AllowedRedirectURIs {
+		if !strings.HasPrefix(uri, "chrome-extension://") {
+			return nil, fmt.Errorf("oauth: allowed redirect URI %q is not a chrome-extension:// URI", uri)
+		}
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// ConfigFromEnv reads OAUTH_CLIENT_ID, OAUTH_CLIENT_SECRET, OAUTH_TOKEN_ENDPOINT
+// and OAUTH_ALLOWED_REDIRECT_URIS (comma-separated).
+func ConfigFromEnv() Config {
+	var uris []string
+	for _, uri := range strings.Split(os.Getenv("OAUTH_ALLOWED_REDIRECT_URIS"), ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+	return Config{
+		ClientID:            os.Getenv("OAUTH_CLIENT_ID"),
+		ClientSecret:        os.Getenv("OAUTH_CLIENT_SECRET"),
+		TokenEndpoint:       os.Getenv("OAUTH_TOKEN_ENDPOINT"),
+		AllowedRedirectURIs: uris,
+	}
+}
+
+// ValidateRedirectURI checks the URI is a chrome-extension:// URI of the allowlist.
+func (c *Client) ValidateRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "chrome-extension" || u.Host == "" {
+		return ErrInvalidRedirectURI
+	}
+	if !slices.Contains(c.cfg.AllowedRedirectURIs, uri) {
+		return ErrInvalidRedirectURI
+	}
+	return nil
+}
+
+// Exchange trades an authorization code and its PKCE verifier for tokens.
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier, redirectURI string) (*Token, error) {
+	if err := c.ValidateRedirectURI(redirectURI); err != nil {
+		return nil, err
+	}
+	if !codeVerifierPattern.MatchString(codeVerifier) {
+		return nil, ErrInvalidCodeVerifier
+	}
+	if code == "" {
+		return nil, &Error{Status: http.StatusBadRequest, Code: "invalid_request", Description: "code is required"}
+	}
+
+	return c.requestToken(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {codeVerifier},
+		"redirect_uri":  {redirectURI},
+	})
+}
+
+// Refresh trades a refresh token for new tokens.
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if refreshToken == "" {
+		return nil, &Error{Status: http.StatusBadRequest, Code: "invalid_request", Description: "refresh_token is required"}
+	}
+
+	return c.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func (c *Client) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("client_secret", c.cfg.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oauth: calling token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("oauth: reading token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		oauthErr := &Error{Status: resp.StatusCode}
+		if json.Unmarshal(body, oauthErr) != nil || oauthErr.Code == "" {
+			return nil, fmt.Errorf("oauth: token endpoint returned status %d", resp.StatusCode)
+		}
+		return nil, oauthErr
+	}
+
+	var raw struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+		IDToken      string `json:"id_token"`
+		Scope        string `json:"scope"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || raw.AccessToken == "" {
+		return nil, errors.New("oauth: malformed token response")
+	}
+
+	tokenType := raw.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	return &Token{
+		AccessToken:  raw.AccessToken,
+		TokenType:    tokenType,
+		ExpiresIn:    raw.ExpiresIn,
+		ExpiresAt:    c.cfg.Now().Add(time.Duration(raw.ExpiresIn) * time.Second).UTC(),
+		RefreshToken: raw.RefreshToken,
+		IDToken:      raw.IDToken,
+		Scope:        raw.Scope,
+	}, nil
+}
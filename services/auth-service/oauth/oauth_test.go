@@ -0,0 +1,133 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"auth-service/oauth"
+	"auth-service/oauth/oauthtest"
+)
+
+const redirectURI = "chrome-extension://abcdefghijklmnop/callback"
+
+var verifier = strings.Repeat("v", 43)
+
+func newClient(t *testing.T, provider *oauthtest.Server, secret string) *oauth.Client {
+	t.Helper()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client, err := oauth.NewClient(oauth.Config{
+		ClientID:            provider.ClientID,
+		ClientSecret:        secret,
+		TokenEndpoint:       provider.TokenEndpoint(),
+		AllowedRedirectURIs: []string{redirectURI},
+		Now:                 func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestExchange(t *testing.T) {
+	provider := oauthtest.NewServer("client", "secret")
+	defer provider.Close()
+	client := newClient(t, provider, "secret")
+
+	tests := []struct {
+		name        string
+		code        func() string
+		verifier    string
+		redirectURI string
+		err         error
+		oauthCode   string
+	}{
+		{name: "valid", verifier: verifier, redirectURI: redirectURI},
+		{name: "redirect URI not allowed", verifier: verifier, redirectURI: "chrome-extension://other/callback", err: oauth.ErrInvalidRedirectURI},
+		{name: "not an extension", verifier: verifier, redirectURI: "https://example.com/callback", err: oauth.ErrInvalidRedirectURI},
+		{name: "short verifier", verifier: "short", redirectURI: redirectURI, err: oauth.ErrInvalidCodeVerifier},
+		{name: "other verifier", verifier: strings.Repeat("w", 43), redirectURI: redirectURI, oauthCode: "invalid_grant"},
+		{name: "missing code", code: func() string { return "" }, verifier: verifier, redirectURI: redirectURI, oauthCode: "invalid_request"},
+		{name: "unknown code", code: func() string { return "unknown" }, verifier: verifier, redirectURI: redirectURI, oauthCode: "invalid_grant"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The provider binds the code to the redirect URI and verifier of the authorize step
+			code := provider.IssueCode("user_1", redirectURI, verifier)
+			if tt.code != nil {
+				code = tt.code()
+			}
+			token, err := client.Exchange(context.Background(), code, tt.verifier, tt.redirectURI)
+			checkError(t, err, tt.err, tt.oauthCode)
+			if err == nil && (!strings.HasPrefix(token.AccessToken, "at_user_1_") || token.RefreshToken == "") {
+				t.Errorf("token = %+v, want tokens of user_1", token)
+			}
+		})
+	}
+
+	t.Run("code reused", func(t *testing.T) {
+		code := provider.IssueCode("user_1", redirectURI, verifier)
+		if _, err := client.Exchange(context.Background(), code, verifier, redirectURI); err != nil {
+			t.Fatal(err)
+		}
+		_, err := client.Exchange(context.Background(), code, verifier, redirectURI)
+		checkError(t, err, nil, "invalid_grant")
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	provider := oauthtest.NewServer("client", "secret")
+	defer provider.Close()
+	client := newClient(t, provider, "secret")
+
+	first, err := client.Exchange(context.Background(), provider.IssueCode("user_1", redirectURI, verifier), verifier, redirectURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := client.Refresh(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if !strings.HasPrefix(second.AccessToken, "at_user_1_") || second.RefreshToken == first.RefreshToken {
+		t.Errorf("token = %+v, want a new access token and a rotated refresh token", second)
+	}
+	if want := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC); !second.ExpiresAt.Equal(want) {
+		t.Errorf("expires_at = %s, want %s", second.ExpiresAt, want)
+	}
+
+	// Rotated: the previous refresh token is spent
+	_, err = client.Refresh(context.Background(), first.RefreshToken)
+	checkError(t, err, nil, "invalid_grant")
+
+	_, err = client.Refresh(context.Background(), "")
+	checkError(t, err, nil, "invalid_request")
+
+	// A wrong client secret is a configuration error
+	_, err = newClient(t, provider, "wrong").Refresh(context.Background(), second.RefreshToken)
+	checkError(t, err, nil, "invalid_client")
+}
+
+// checkError checks err is want, or an *oauth.Error with the code oauthCode,
+// or nil when both are empty.
+func checkError(t *testing.T, err, want error, oauthCode string) {
+	t.Helper()
+	var oauthErr *oauth.Error
+	switch {
+	case want != nil:
+		if !errors.Is(err, want) {
+			t.Errorf("error = %v, want %v", err, want)
+		}
+	case oauthCode != "":
+		if !errors.As(err, &oauthErr) || oauthErr.Code != oauthCode {
+			t.Errorf("error = %v, want the OAuth error %s", err, oauthCode)
+		} else if oauthErr.Code == "invalid_client" && oauthErr.Status != http.StatusUnauthorized {
+			t.Errorf("status = %d, want 401", oauthErr.Status)
+		}
+	case err != nil:
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Package oauthtest provides a fake OAuth token endpoint implementing the
+// authorization-code (with PKCE S256) and refresh-token grants.
+package oauthtest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Server is a fake OAuth provider. Its token endpoint is Server.URL + "/oauth/token".
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu            sync.Mutex
+	codes         map[string]grant
+	refreshTokens map[string]string // refresh token -> subject
+}
+
+type grant struct {
+	redirectURI   string
+	codeChallenge string
+	subject       string
+}
+
+// NewServer starts a fake provider accepting the given client credentials.
+func NewServer(clientID, clientSecret string) *Server {
+	s := &Server{
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		codes:         map[string]grant{},
+		refreshTokens: map[string]string{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// TokenEndpoint returns the URL of the token endpoint.
+func (s *Server) TokenEndpoint() string {
+	return s.URL + "/oauth/token"
+}
+
+// IssueCode simulates the authorize step and returns a single-use code bound
+// to the redirect URI and the PKCE S256 challenge of the code verifier.
+func (s *Server) IssueCode(subject, redirectURI, codeVerifier string) string {
+	code := randomString()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codes[code] = grant{redirectURI: redirectURI, codeChallenge: Challenge(codeVerifier), subject: subject}
+	return code
+}
+
+// Challenge returns the PKCE S256 challenge of a code verifier.
+func Challenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	if r.PostForm.Get("client_id") != s.ClientID || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subject string
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		g, ok := s.codes[code]
+		// Codes are single-use, even when the exchange fails
+		delete(s.codes, code)
+		if !ok || g.redirectURI != r.PostForm.Get("redirect_uri") || g.codeChallenge != Challenge(r.PostForm.Get("code_verifier")) {
+			writeError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		subject = g.subject
+	case "refresh_token":
+		refreshToken := r.PostForm.Get("refresh_token")
+		sub, ok := s.refreshTokens[refreshToken]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		// Refresh tokens are rotated
+		delete(s.refreshTokens, refreshToken)
+		subject = sub
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	refreshToken := randomString()
+	s.refreshTokens[refreshToken] = subject
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  "at_" + subject + "_" + randomString(),
+		"token_type":    "bearer",
+		"expires_in":    3600,
+		"refresh_token": refreshToken,
+		"scope":         "profile email",
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"auth-service/oauth"
+	"auth-service/oauth/oauthtest"
+)
+
+func TestOAuthRoutes(t *testing.T) {
+	const redirectURI = "chrome-extension://abcdefghijklmnop/callback"
+	verifier := strings.Repeat("v", 43)
+	provider := oauthtest.NewServer("client", "secret")
+	defer provider.Close()
+
+	newOAuthApp := func(secret string) func(path string, form url.Values) (int, map[string]any) {
+		client, err := oauth.NewClient(oauth.Config{
+			ClientID:            "client",
+			ClientSecret:        secret,
+			TokenEndpoint:       provider.TokenEndpoint(),
+			AllowedRedirectURIs: []string{redirectURI},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		app := newApp(nil, nil, client, nil, nil)
+		return func(path string, form url.Values) (int, map[string]any) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var body map[string]any
+			_ = json.NewDecoder(resp.Body).Decode(&body)
+			return resp.StatusCode, body
+		}
+	}
+	post := newOAuthApp("secret")
+
+	code, body := post("/oauth/token", url.Values{
+		"code":          {provider.IssueCode("user_1", redirectURI, verifier)},
+		"code_verifier": {verifier},
+		"redirect_uri":  {redirectURI},
+	})
+	refreshToken, _ := body["refresh_token"].(string)
+	if code != http.StatusOK || body["token_type"] != "Bearer" || refreshToken == "" {
+		t.Fatalf("/oauth/token = %d %v, want tokens", code, body)
+	}
+
+	code, body = post("/oauth/refresh", url.Values{"refresh_token": {refreshToken}})
+	if code != http.StatusOK || body["refresh_token"] == refreshToken {
+		t.Errorf("/oauth/refresh = %d %v, want rotated tokens", code, body)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		path   string
+		form   url.Values
+		code   int
+		error  string
+	}{
+		{name: "redirect URI not allowed", secret: "secret", path: "/oauth/token", form: url.Values{"code": {"c"}, "code_verifier": {verifier}, "redirect_uri": {"chrome-extension://other/cb"}}, code: http.StatusBadRequest, error: "invalid_request"},
+		{name: "spent refresh token", secret: "secret", path: "/oauth/refresh", form: url.Values{"refresh_token": {refreshToken}}, code: http.StatusBadRequest, error: "invalid_grant"},
+		{name: "wrong client secret", secret: "wrong", path: "/oauth/refresh", form: url.Values{"refresh_token": {"r"}}, code: http.StatusBadGateway, error: "invalid_client"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := newOAuthApp(tt.secret)(tt.path, tt.form)
+			if code != tt.code || body["error"] != tt.error {
+				t.Errorf("%s = %d %v, want %d %s", tt.path, code, body, tt.code, tt.error)
+			}
+		})
+	}
+}
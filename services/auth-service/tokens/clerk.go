@@ -0,0 +1,37 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+)
+
+// ClerkVerifier verifies Clerk session tokens.
+type ClerkVerifier struct{}
+
+// NewClerkVerifier sets the Clerk secret key and returns a ClerkVerifier.
+func NewClerkVerifier(secretKey string) *ClerkVerifier {
+	clerk.SetKey(secretKey)
+	return &ClerkVerifier{}
+}
+
+// Verify validates the token with the Clerk SDK, which fetches the instance JWKS.
+func (ClerkVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims, err := jwt.Verify(ctx, &jwt.VerifyParams{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.RegisteredClaims.IssuedAt == nil || claims.RegisteredClaims.Expiry == nil {
+		return nil, fmt.Errorf("%w: missing iat or exp", ErrInvalidToken)
+	}
+
+	return &Claims{
+		SessionID: claims.Claims.SessionID,
+		UserID:    claims.RegisteredClaims.Subject,
+		IssuedAt:  time.Unix(*claims.RegisteredClaims.IssuedAt, 0),
+		ExpiresAt: time.Unix(*claims.RegisteredClaims.Expiry, 0),
+	}, nil
+}
@@ -0,0 +1,87 @@
+package tokens
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"shared/entitlements"
+)
+
+// EntitlementTokenType is the `typ` header of entitlement tokens, which
+// entitlements.TokenVerifier requires so they cannot be mistaken for session
+// tokens.
+const EntitlementTokenType = entitlements.TokenType
+
+// EntitlementIssuer signs short-lived entitlement tokens: the plan and
+// entitlements of a user, which the extension uses to gate features offline
+// and the services verify with shared/entitlements.TokenVerifier.
+type EntitlementIssuer struct {
+	key    *signingKey
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// EntitlementIssuerOptions configures an EntitlementIssuer. Zero values use
+// the defaults.
+type EntitlementIssuerOptions struct {
+	Issuer string           // `iss` claim, defaults to entitlements.DefaultTokenIssuer
+	TTL    time.Duration    // token lifetime, defaults to 1h
+	Now    func() time.Time // clock, defaults to time.Now
+}
+
+// NewEntitlementIssuer returns an issuer signing with an RSA (RS256) or
+// Ed25519 (EdDSA) key.
+func NewEntitlementIssuer(key crypto.Signer, opts EntitlementIssuerOptions) (*EntitlementIssuer, error) {
+	sk, err := newSigningKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Issuer == "" {
+		opts.Issuer = entitlements.DefaultTokenIssuer
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = time.Hour
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	return &EntitlementIssuer{key: sk, issuer: opts.Issuer, ttl: opts.TTL, now: opts.Now}, nil
+}
+
+// Issue signs a token granting set to the user with the given public UUID
+// and subscription tier.
+func (i *EntitlementIssuer) Issue(userUUID, tier string, set *entitlements.Set) (string, *entitlements.Claims, error) {
+	now := i.now().Truncate(time.Second)
+	claims := &entitlements.Claims{
+		Issuer:       i.issuer,
+		Subject:      userUUID,
+		IssuedAt:     now.Unix(),
+		NotBefore:    now.Unix(),
+		Expiry:       now.Add(i.ttl).Unix(),
+		UserID:       set.UserID,
+		Tier:         tier,
+		Plan:         set.Plan,
+		Entitlements: set.Entitlements,
+	}
+	if claims.Entitlements == nil {
+		claims.Entitlements = map[string]entitlements.Entitlement{}
+	}
+
+	token, err := i.key.sign(EntitlementTokenType, claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+// JWKS returns the public key set verifying entitlement tokens. It is
+// distinct from the session JWKS, so that no session token is accepted as an
+// entitlement token and conversely.
+func (i *EntitlementIssuer) JWKS() jose.JSONWebKeySet {
+	return i.key.jwks()
+}
@@ -0,0 +1,66 @@
+package tokens
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"shared/entitlements"
+	"shared/jwtauth"
+)
+
+func TestEntitlementTokenType(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := NewEntitlementIssuer(key, EntitlementIssuerOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A session issuer wrongly sharing the key and issuer: only the type
+	// tells its tokens apart
+	sessions, err := NewLocalIssuer(key, LocalIssuerOptions{Issuer: entitlements.DefaultTokenIssuer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(issuer.JWKS())
+	}))
+	defer jwks.Close()
+	verifier, err := entitlements.NewTokenVerifier(context.Background(), jwtauth.Config{JWKSURL: jwks.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer verifier.Close()
+
+	limit := int64(3)
+	token, _, err := issuer.Issue("3f2b1c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "premium", &entitlements.Set{
+		UserID:       1,
+		Plan:         "premium",
+		Entitlements: map[string]entitlements.Entitlement{"max_cloud_backups": {Enabled: true, Limit: &limit}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := verifier.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Verify entitlement token: %v", err)
+	}
+	if claims.UserID != 1 || claims.Tier != "premium" || *claims.Entitlements["max_cloud_backups"].Limit != 3 {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	session, _, err := sessions.Issue("user_1", "sess_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifier.Verify(context.Background(), session); !errors.Is(err, jwtauth.ErrWrongType) {
+		t.Errorf("Verify session token = %v, want ErrWrongType", err)
+	}
+}
@@ -0,0 +1,127 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// FromEnv builds the Verifier selected by AUTH_VERIFIER:
+//   - "clerk" (default): Clerk tokens, CLERK_SECRET_KEY is required.
+//   - "local": tokens minted by a LocalIssuer, which is also returned.
+func FromEnv() (Verifier, *LocalIssuer, error) {
+	switch mode := os.Getenv("AUTH_VERIFIER"); mode {
+	case "", "clerk":
+		secret := os.Getenv("CLERK_SECRET_KEY")
+		if secret == "" {
+			return nil, nil, fmt.Errorf("CLERK_SECRET_KEY is not set")
+		}
+		return NewClerkVerifier(secret), nil, nil
+	case "local":
+		issuer, err := LocalIssuerFromEnv()
+		if err != nil {
+			return nil, nil, err
+		}
+		return issuer, issuer, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown AUTH_VERIFIER %q", mode)
+	}
+}
+
+// LocalIssuerFromEnv configures a LocalIssuer from:
+//   - LOCAL_ISSUER_KEY_FILE: PEM PKCS#8 RSA or Ed25519 private key. When empty,
+//     an ephemeral key is generated and tokens do not survive a restart.
+//   - LOCAL_ISSUER_ALG: RS256 (default) or EdDSA, for the ephemeral key.
+//   - LOCAL_ISSUER_NAME: `iss` claim, defaults to "leakr-local".
+//   - LOCAL_ISSUER_TTL: token lifetime (Go duration), defaults to 1h.
+func LocalIssuerFromEnv() (*LocalIssuer, error) {
+	var key crypto.Signer
+	var err error
+	if path := os.Getenv("LOCAL_ISSUER_KEY_FILE"); path != "" {
+		key, err = loadPrivateKey(path)
+	} else {
+		log.Println("LOCAL_ISSUER_KEY_FILE is not set, generating an ephemeral signing key")
+		key, err = generateKey("LOCAL_ISSUER_ALG")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := LocalIssuerOptions{Issuer: os.Getenv("LOCAL_ISSUER_NAME")}
+	if ttl := os.Getenv("LOCAL_ISSUER_TTL"); ttl != "" {
+		if opts.TTL, err = time.ParseDuration(ttl); err != nil {
+			return nil, fmt.Errorf("invalid LOCAL_ISSUER_TTL: %w", err)
+		}
+	}
+	return NewLocalIssuer(key, opts)
+}
+
+// EntitlementIssuerFromEnv configures an EntitlementIssuer from:
+//   - ENTITLEMENT_KEY_FILE: PEM PKCS#8 RSA or Ed25519 private key. When empty,
+//     an ephemeral key is generated and the tokens issued before a restart
+//     (or by another instance) no longer verify.
+//   - ENTITLEMENT_KEY_ALG: RS256 (default) or EdDSA, for the ephemeral key.
+//   - ENTITLEMENT_ISSUER: `iss` claim, defaults to "leakr-auth".
+//   - ENTITLEMENT_TOKEN_TTL: token lifetime (Go duration), defaults to 1h.
+func EntitlementIssuerFromEnv() (*EntitlementIssuer, error) {
+	var key crypto.Signer
+	var err error
+	if path := os.Getenv("ENTITLEMENT_KEY_FILE"); path != "" {
+		key, err = loadPrivateKey(path)
+	} else {
+		log.Println("ENTITLEMENT_KEY_FILE is not set, generating an ephemeral entitlement signing key")
+		key, err = generateKey("ENTITLEMENT_KEY_ALG")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := EntitlementIssuerOptions{Issuer: os.Getenv("ENTITLEMENT_ISSUER")}
+	if ttl := os.Getenv("ENTITLEMENT_TOKEN_TTL"); ttl != "" {
+		if opts.TTL, err = time.ParseDuration(ttl); err != nil {
+			return nil, fmt.Errorf("invalid ENTITLEMENT_TOKEN_TTL: %w", err)
+		}
+	}
+	return NewEntitlementIssuer(key, opts)
+}
+
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key %s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+	return signer, nil
+}
+
+// generateKey generates an ephemeral key for the algorithm named by the
+// algEnv variable.
+func generateKey(algEnv string) (crypto.Signer, error) {
+	switch alg := os.Getenv(algEnv); alg {
+	case "", "RS256":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "EdDSA":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported %s %q", algEnv, alg)
+	}
+}
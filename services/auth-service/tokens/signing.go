@@ -0,0 +1,73 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+)
+
+// signingKey is a private key published in a JWKS under its thumbprint.
+type signingKey struct {
+	alg jose.SignatureAlgorithm
+	key crypto.Signer
+	kid string
+}
+
+// newSigningKey accepts RSA (RS256) and Ed25519 (EdDSA) keys.
+func newSigningKey(key crypto.Signer) (*signingKey, error) {
+	var alg jose.SignatureAlgorithm
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case ed25519.PrivateKey:
+		alg = jose.EdDSA
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("computing key ID: %w", err)
+	}
+
+	return &signingKey{alg: alg, key: key, kid: base64.RawURLEncoding.EncodeToString(thumbprint)}, nil
+}
+
+// sign serializes claims into a compact JWT of the given type (`typ` header).
+func (k *signingKey) sign(typ jose.ContentType, claims any) (string, error) {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: k.alg, Key: k.key},
+		(&jose.SignerOptions{}).WithType(typ).WithHeader(jose.HeaderKey("kid"), k.kid),
+	)
+	if err != nil {
+		return "", err
+	}
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
+
+// parse checks the signature of token and decodes its claims into dest.
+func (k *signingKey) parse(token string, dest any) error {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return err
+	}
+	if len(parsed.Headers) != 1 || parsed.Headers[0].Algorithm != string(k.alg) || parsed.Headers[0].KeyID != k.kid {
+		return fmt.Errorf("unexpected signing key")
+	}
+	return parsed.Claims(k.key.Public(), dest)
+}
+
+// jwks returns the public key set.
+func (k *signingKey) jwks() jose.JSONWebKeySet {
+	return jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       k.key.Public(),
+		KeyID:     k.kid,
+		Algorithm: string(k.alg),
+		Use:       "sig",
+	}}}
+}
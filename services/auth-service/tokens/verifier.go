@@ -0,0 +1,25 @@
+// Package tokens verifies (and, for local development, mints) session tokens,
+// and signs the entitlement tokens derived from them.
+package tokens
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidToken is returned when a token cannot be verified.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims is the part of a session token the services rely on.
+type Claims struct {
+	SessionID string    // sid
+	UserID    string    // sub
+	IssuedAt  time.Time // iat
+	ExpiresAt time.Time // exp
+}
+
+// Verifier validates a session token and returns its claims.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
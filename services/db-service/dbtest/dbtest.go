@@ -0,0 +1,55 @@
+// Package dbtest opens ent clients on throwaway SQLite databases for the
+// tests of db-service.
+//
+// The Postgres functions used for advisory locks (hashtext and
+// pg_try_advisory_xact_lock / pg_advisory_xact_lock) are stubbed: each test
+// database has a single user, so the locks are always granted.
+package dbtest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"modernc.org/sqlite"
+
+	"db-service/ent"
+	"db-service/ent/enttest"
+)
+
+var registerOnce sync.Once
+
+func register() {
+	// ent opens SQLite databases with the driver name of mattn/go-sqlite3.
+	// The functions below are registered on the driver of modernc.org/sqlite.
+	db, err := sql.Open("sqlite", "")
+	if err != nil {
+		panic(err)
+	}
+	sql.Register(dialect.SQLite, db.Driver())
+	db.Close()
+
+	sqlite.MustRegisterDeterministicScalarFunction("hashtext", 1, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return int64(0), nil
+	})
+	granted := func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return true, nil
+	}
+	sqlite.MustRegisterScalarFunction("pg_try_advisory_xact_lock", 1, granted)
+	sqlite.MustRegisterScalarFunction("pg_advisory_xact_lock", 1, granted)
+}
+
+// Open returns a client on a new database in t.TempDir(), with the schema
+// created. It is closed at the end of the test.
+func Open(t *testing.T, opts ...ent.Option) *ent.Client {
+	t.Helper()
+	registerOnce.Do(register)
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "db.sqlite") + "?_pragma=foreign_keys(1)"
+	client := enttest.Open(t, dialect.SQLite, dsn, enttest.WithOptions(opts...))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
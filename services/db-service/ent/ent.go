@@ -4,7 +4,11 @@ package ent
 
 import (
 	"context"
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"db-service/ent/processedevent"
 	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
 	"db-service/ent/user"
 	"errors"
 	"fmt"
@@ -74,8 +78,12 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			subscription.Table: subscription.ValidColumn,
-			user.Table:         user.ValidColumn,
+			entitlement.Table:       entitlement.ValidColumn,
+			plan.Table:              plan.ValidColumn,
+			processedevent.Table:    processedevent.ValidColumn,
+			subscription.Table:      subscription.ValidColumn,
+			subscriptionevent.Table: subscriptionevent.ValidColumn,
+			user.Table:              user.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
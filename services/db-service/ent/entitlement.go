@@ -0,0 +1,169 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Entitlement is the model entity for the Entitlement schema.
+type Entitlement struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Nom du droit, voir shared/entitlements
+	Key string `json:"key,omitempty"`
+	// Faux pour refuser explicitement le droit
+	Enabled bool `json:"enabled,omitempty"`
+	// Limite du droit ; NULL = illimité ou sans objet
+	Limit *int64 `json:"limit,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the EntitlementQuery when eager-loading is set.
+	Edges             EntitlementEdges `json:"edges"`
+	plan_entitlements *int
+	selectValues      sql.SelectValues
+}
+
+// EntitlementEdges holds the relations/edges for other nodes in the graph.
+type EntitlementEdges struct {
+	// Plan holds the value of the plan edge.
+	Plan *Plan `json:"plan,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// PlanOrErr returns the Plan value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e EntitlementEdges) PlanOrErr() (*Plan, error) {
+	if e.Plan != nil {
+		return e.Plan, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: plan.Label}
+	}
+	return nil, &NotLoadedError{edge: "plan"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Entitlement) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case entitlement.FieldEnabled:
+			values[i] = new(sql.NullBool)
+		case entitlement.FieldID, entitlement.FieldLimit:
+			values[i] = new(sql.NullInt64)
+		case entitlement.FieldKey:
+			values[i] = new(sql.NullString)
+		case entitlement.ForeignKeys[0]: // plan_entitlements
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Entitlement fields.
+func (e *Entitlement) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case entitlement.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			e.ID = int(value.Int64)
+		case entitlement.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				e.Key = value.String
+			}
+		case entitlement.FieldEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field enabled", values[i])
+			} else if value.Valid {
+				e.Enabled = value.Bool
+			}
+		case entitlement.FieldLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field limit", values[i])
+			} else if value.Valid {
+				e.Limit = new(int64)
+				*e.Limit = value.Int64
+			}
+		case entitlement.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field plan_entitlements", value)
+			} else if value.Valid {
+				e.plan_entitlements = new(int)
+				*e.plan_entitlements = int(value.Int64)
+			}
+		default:
+			e.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Entitlement.
+// This includes values selected through modifiers, order, etc.
+func (e *Entitlement) Value(name string) (ent.Value, error) {
+	return e.selectValues.Get(name)
+}
+
+// QueryPlan queries the "plan" edge of the Entitlement entity.
+func (e *Entitlement) QueryPlan() *PlanQuery {
+	return NewEntitlementClient(e.config).QueryPlan(e)
+}
+
+// Update returns a builder for updating this Entitlement.
+// Note that you need to call Entitlement.Unwrap() before calling this method if this Entitlement
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (e *Entitlement) Update() *EntitlementUpdateOne {
+	return NewEntitlementClient(e.config).UpdateOne(e)
+}
+
+// Unwrap unwraps the Entitlement entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (e *Entitlement) Unwrap() *Entitlement {
+	_tx, ok := e.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Entitlement is not a transactional entity")
+	}
+	e.config.driver = _tx.drv
+	return e
+}
+
+// String implements the fmt.Stringer.
+func (e *Entitlement) String() string {
+	var builder strings.Builder
+	builder.WriteString("Entitlement(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", e.ID))
+	builder.WriteString("key=")
+	builder.WriteString(e.Key)
+	builder.WriteString(", ")
+	builder.WriteString("enabled=")
+	builder.WriteString(fmt.Sprintf("%v", e.Enabled))
+	builder.WriteString(", ")
+	if v := e.Limit; v != nil {
+		builder.WriteString("limit=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Entitlements is a parsable slice of Entitlement.
+type Entitlements []*Entitlement
@@ -0,0 +1,233 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entitlement
+
+import (
+	"db-service/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldLTE(FieldID, id))
+}
+
+// Key applies equality check predicate on the "key" field. It's identical to KeyEQ.
+func Key(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldKey, v))
+}
+
+// Enabled applies equality check predicate on the "enabled" field. It's identical to EnabledEQ.
+func Enabled(v bool) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldEnabled, v))
+}
+
+// Limit applies equality check predicate on the "limit" field. It's identical to LimitEQ.
+func Limit(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldLimit, v))
+}
+
+// KeyEQ applies the EQ predicate on the "key" field.
+func KeyEQ(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldKey, v))
+}
+
+// KeyNEQ applies the NEQ predicate on the "key" field.
+func KeyNEQ(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNEQ(FieldKey, v))
+}
+
+// KeyIn applies the In predicate on the "key" field.
+func KeyIn(vs ...string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldIn(FieldKey, vs...))
+}
+
+// KeyNotIn applies the NotIn predicate on the "key" field.
+func KeyNotIn(vs ...string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNotIn(FieldKey, vs...))
+}
+
+// KeyGT applies the GT predicate on the "key" field.
+func KeyGT(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldGT(FieldKey, v))
+}
+
+// KeyGTE applies the GTE predicate on the "key" field.
+func KeyGTE(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldGTE(FieldKey, v))
+}
+
+// KeyLT applies the LT predicate on the "key" field.
+func KeyLT(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldLT(FieldKey, v))
+}
+
+// KeyLTE applies the LTE predicate on the "key" field.
+func KeyLTE(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldLTE(FieldKey, v))
+}
+
+// KeyContains applies the Contains predicate on the "key" field.
+func KeyContains(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldContains(FieldKey, v))
+}
+
+// KeyHasPrefix applies the HasPrefix predicate on the "key" field.
+func KeyHasPrefix(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldHasPrefix(FieldKey, v))
+}
+
+// KeyHasSuffix applies the HasSuffix predicate on the "key" field.
+func KeyHasSuffix(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldHasSuffix(FieldKey, v))
+}
+
+// KeyEqualFold applies the EqualFold predicate on the "key" field.
+func KeyEqualFold(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEqualFold(FieldKey, v))
+}
+
+// KeyContainsFold applies the ContainsFold predicate on the "key" field.
+func KeyContainsFold(v string) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldContainsFold(FieldKey, v))
+}
+
+// EnabledEQ applies the EQ predicate on the "enabled" field.
+func EnabledEQ(v bool) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldEnabled, v))
+}
+
+// EnabledNEQ applies the NEQ predicate on the "enabled" field.
+func EnabledNEQ(v bool) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNEQ(FieldEnabled, v))
+}
+
+// LimitEQ applies the EQ predicate on the "limit" field.
+func LimitEQ(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldEQ(FieldLimit, v))
+}
+
+// LimitNEQ applies the NEQ predicate on the "limit" field.
+func LimitNEQ(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNEQ(FieldLimit, v))
+}
+
+// LimitIn applies the In predicate on the "limit" field.
+func LimitIn(vs ...int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldIn(FieldLimit, vs...))
+}
+
+// LimitNotIn applies the NotIn predicate on the "limit" field.
+func LimitNotIn(vs ...int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNotIn(FieldLimit, vs...))
+}
+
+// LimitGT applies the GT predicate on the "limit" field.
+func LimitGT(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldGT(FieldLimit, v))
+}
+
+// LimitGTE applies the GTE predicate on the "limit" field.
+func LimitGTE(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldGTE(FieldLimit, v))
+}
+
+// LimitLT applies the LT predicate on the "limit" field.
+func LimitLT(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldLT(FieldLimit, v))
+}
+
+// LimitLTE applies the LTE predicate on the "limit" field.
+func LimitLTE(v int64) predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldLTE(FieldLimit, v))
+}
+
+// LimitIsNil applies the IsNil predicate on the "limit" field.
+func LimitIsNil() predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldIsNull(FieldLimit))
+}
+
+// LimitNotNil applies the NotNil predicate on the "limit" field.
+func LimitNotNil() predicate.Entitlement {
+	return predicate.Entitlement(sql.FieldNotNull(FieldLimit))
+}
+
+// HasPlan applies the HasEdge predicate on the "plan" edge.
+func HasPlan() predicate.Entitlement {
+	return predicate.Entitlement(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, PlanTable, PlanColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPlanWith applies the HasEdge predicate on the "plan" edge with a given conditions (other predicates).
+func HasPlanWith(preds ...predicate.Plan) predicate.Entitlement {
+	return predicate.Entitlement(func(s *sql.Selector) {
+		step := newPlanStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Entitlement) predicate.Entitlement {
+	return predicate.Entitlement(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Entitlement) predicate.Entitlement {
+	return predicate.Entitlement(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Entitlement) predicate.Entitlement {
+	return predicate.Entitlement(sql.NotPredicates(p))
+}
@@ -0,0 +1,665 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// EntitlementCreate is the builder for creating a Entitlement entity.
+type EntitlementCreate struct {
+	config
+	mutation *EntitlementMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetKey sets the "key" field.
+func (ec *EntitlementCreate) SetKey(s string) *EntitlementCreate {
+	ec.mutation.SetKey(s)
+	return ec
+}
+
+// SetEnabled sets the "enabled" field.
+func (ec *EntitlementCreate) SetEnabled(b bool) *EntitlementCreate {
+	ec.mutation.SetEnabled(b)
+	return ec
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (ec *EntitlementCreate) SetNillableEnabled(b *bool) *EntitlementCreate {
+	if b != nil {
+		ec.SetEnabled(*b)
+	}
+	return ec
+}
+
+// SetLimit sets the "limit" field.
+func (ec *EntitlementCreate) SetLimit(i int64) *EntitlementCreate {
+	ec.mutation.SetLimit(i)
+	return ec
+}
+
+// SetNillableLimit sets the "limit" field if the given value is not nil.
+func (ec *EntitlementCreate) SetNillableLimit(i *int64) *EntitlementCreate {
+	if i != nil {
+		ec.SetLimit(*i)
+	}
+	return ec
+}
+
+// SetPlanID sets the "plan" edge to the Plan entity by ID.
+func (ec *EntitlementCreate) SetPlanID(id int) *EntitlementCreate {
+	ec.mutation.SetPlanID(id)
+	return ec
+}
+
+// SetPlan sets the "plan" edge to the Plan entity.
+func (ec *EntitlementCreate) SetPlan(p *Plan) *EntitlementCreate {
+	return ec.SetPlanID(p.ID)
+}
+
+// Mutation returns the EntitlementMutation object of the builder.
+func (ec *EntitlementCreate) Mutation() *EntitlementMutation {
+	return ec.mutation
+}
+
+// Save creates the Entitlement in the database.
+func (ec *EntitlementCreate) Save(ctx context.Context) (*Entitlement, error) {
+	ec.defaults()
+	return withHooks(ctx, ec.sqlSave, ec.mutation, ec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ec *EntitlementCreate) SaveX(ctx context.Context) *Entitlement {
+	v, err := ec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ec *EntitlementCreate) Exec(ctx context.Context) error {
+	_, err := ec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ec *EntitlementCreate) ExecX(ctx context.Context) {
+	if err := ec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ec *EntitlementCreate) defaults() {
+	if _, ok := ec.mutation.Enabled(); !ok {
+		v := entitlement.DefaultEnabled
+		ec.mutation.SetEnabled(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ec *EntitlementCreate) check() error {
+	if _, ok := ec.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "Entitlement.key"`)}
+	}
+	if v, ok := ec.mutation.Key(); ok {
+		if err := entitlement.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Entitlement.key": %w`, err)}
+		}
+	}
+	if _, ok := ec.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "Entitlement.enabled"`)}
+	}
+	if v, ok := ec.mutation.Limit(); ok {
+		if err := entitlement.LimitValidator(v); err != nil {
+			return &ValidationError{Name: "limit", err: fmt.Errorf(`ent: validator failed for field "Entitlement.limit": %w`, err)}
+		}
+	}
+	if len(ec.mutation.PlanIDs()) == 0 {
+		return &ValidationError{Name: "plan", err: errors.New(`ent: missing required edge "Entitlement.plan"`)}
+	}
+	return nil
+}
+
+func (ec *EntitlementCreate) sqlSave(ctx context.Context) (*Entitlement, error) {
+	if err := ec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ec.mutation.id = &_node.ID
+	ec.mutation.done = true
+	return _node, nil
+}
+
+func (ec *EntitlementCreate) createSpec() (*Entitlement, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Entitlement{config: ec.config}
+		_spec = sqlgraph.NewCreateSpec(entitlement.Table, sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = ec.conflict
+	if value, ok := ec.mutation.Key(); ok {
+		_spec.SetField(entitlement.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := ec.mutation.Enabled(); ok {
+		_spec.SetField(entitlement.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := ec.mutation.Limit(); ok {
+		_spec.SetField(entitlement.FieldLimit, field.TypeInt64, value)
+		_node.Limit = &value
+	}
+	if nodes := ec.mutation.PlanIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   entitlement.PlanTable,
+			Columns: []string{entitlement.PlanColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(plan.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.plan_entitlements = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Entitlement.Create().
+//		SetKey(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.EntitlementUpsert) {
+//			SetKey(v+v).
+//		}).
+//		Exec(ctx)
+func (ec *EntitlementCreate) OnConflict(opts ...sql.ConflictOption) *EntitlementUpsertOne {
+	ec.conflict = opts
+	return &EntitlementUpsertOne{
+		create: ec,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Entitlement.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ec *EntitlementCreate) OnConflictColumns(columns ...string) *EntitlementUpsertOne {
+	ec.conflict = append(ec.conflict, sql.ConflictColumns(columns...))
+	return &EntitlementUpsertOne{
+		create: ec,
+	}
+}
+
+type (
+	// EntitlementUpsertOne is the builder for "upsert"-ing
+	//  one Entitlement node.
+	EntitlementUpsertOne struct {
+		create *EntitlementCreate
+	}
+
+	// EntitlementUpsert is the "OnConflict" setter.
+	EntitlementUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetKey sets the "key" field.
+func (u *EntitlementUpsert) SetKey(v string) *EntitlementUpsert {
+	u.Set(entitlement.FieldKey, v)
+	return u
+}
+
+// UpdateKey sets the "key" field to the value that was provided on create.
+func (u *EntitlementUpsert) UpdateKey() *EntitlementUpsert {
+	u.SetExcluded(entitlement.FieldKey)
+	return u
+}
+
+// SetEnabled sets the "enabled" field.
+func (u *EntitlementUpsert) SetEnabled(v bool) *EntitlementUpsert {
+	u.Set(entitlement.FieldEnabled, v)
+	return u
+}
+
+// UpdateEnabled sets the "enabled" field to the value that was provided on create.
+func (u *EntitlementUpsert) UpdateEnabled() *EntitlementUpsert {
+	u.SetExcluded(entitlement.FieldEnabled)
+	return u
+}
+
+// SetLimit sets the "limit" field.
+func (u *EntitlementUpsert) SetLimit(v int64) *EntitlementUpsert {
+	u.Set(entitlement.FieldLimit, v)
+	return u
+}
+
+// UpdateLimit sets the "limit" field to the value that was provided on create.
+func (u *EntitlementUpsert) UpdateLimit() *EntitlementUpsert {
+	u.SetExcluded(entitlement.FieldLimit)
+	return u
+}
+
+// AddLimit adds v to the "limit" field.
+func (u *EntitlementUpsert) AddLimit(v int64) *EntitlementUpsert {
+	u.Add(entitlement.FieldLimit, v)
+	return u
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (u *EntitlementUpsert) ClearLimit() *EntitlementUpsert {
+	u.SetNull(entitlement.FieldLimit)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.Entitlement.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *EntitlementUpsertOne) UpdateNewValues() *EntitlementUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Entitlement.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *EntitlementUpsertOne) Ignore() *EntitlementUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *EntitlementUpsertOne) DoNothing() *EntitlementUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the EntitlementCreate.OnConflict
+// documentation for more info.
+func (u *EntitlementUpsertOne) Update(set func(*EntitlementUpsert)) *EntitlementUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&EntitlementUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetKey sets the "key" field.
+func (u *EntitlementUpsertOne) SetKey(v string) *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.SetKey(v)
+	})
+}
+
+// UpdateKey sets the "key" field to the value that was provided on create.
+func (u *EntitlementUpsertOne) UpdateKey() *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.UpdateKey()
+	})
+}
+
+// SetEnabled sets the "enabled" field.
+func (u *EntitlementUpsertOne) SetEnabled(v bool) *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.SetEnabled(v)
+	})
+}
+
+// UpdateEnabled sets the "enabled" field to the value that was provided on create.
+func (u *EntitlementUpsertOne) UpdateEnabled() *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.UpdateEnabled()
+	})
+}
+
+// SetLimit sets the "limit" field.
+func (u *EntitlementUpsertOne) SetLimit(v int64) *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.SetLimit(v)
+	})
+}
+
+// AddLimit adds v to the "limit" field.
+func (u *EntitlementUpsertOne) AddLimit(v int64) *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.AddLimit(v)
+	})
+}
+
+// UpdateLimit sets the "limit" field to the value that was provided on create.
+func (u *EntitlementUpsertOne) UpdateLimit() *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.UpdateLimit()
+	})
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (u *EntitlementUpsertOne) ClearLimit() *EntitlementUpsertOne {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.ClearLimit()
+	})
+}
+
+// Exec executes the query.
+func (u *EntitlementUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for EntitlementCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *EntitlementUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *EntitlementUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *EntitlementUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// EntitlementCreateBulk is the builder for creating many Entitlement entities in bulk.
+type EntitlementCreateBulk struct {
+	config
+	err      error
+	builders []*EntitlementCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the Entitlement entities in the database.
+func (ecb *EntitlementCreateBulk) Save(ctx context.Context) ([]*Entitlement, error) {
+	if ecb.err != nil {
+		return nil, ecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ecb.builders))
+	nodes := make([]*Entitlement, len(ecb.builders))
+	mutators := make([]Mutator, len(ecb.builders))
+	for i := range ecb.builders {
+		func(i int, root context.Context) {
+			builder := ecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*EntitlementMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = ecb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ecb *EntitlementCreateBulk) SaveX(ctx context.Context) []*Entitlement {
+	v, err := ecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ecb *EntitlementCreateBulk) Exec(ctx context.Context) error {
+	_, err := ecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ecb *EntitlementCreateBulk) ExecX(ctx context.Context) {
+	if err := ecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Entitlement.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.EntitlementUpsert) {
+//			SetKey(v+v).
+//		}).
+//		Exec(ctx)
+func (ecb *EntitlementCreateBulk) OnConflict(opts ...sql.ConflictOption) *EntitlementUpsertBulk {
+	ecb.conflict = opts
+	return &EntitlementUpsertBulk{
+		create: ecb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Entitlement.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ecb *EntitlementCreateBulk) OnConflictColumns(columns ...string) *EntitlementUpsertBulk {
+	ecb.conflict = append(ecb.conflict, sql.ConflictColumns(columns...))
+	return &EntitlementUpsertBulk{
+		create: ecb,
+	}
+}
+
+// EntitlementUpsertBulk is the builder for "upsert"-ing
+// a bulk of Entitlement nodes.
+type EntitlementUpsertBulk struct {
+	create *EntitlementCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Entitlement.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *EntitlementUpsertBulk) UpdateNewValues() *EntitlementUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Entitlement.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *EntitlementUpsertBulk) Ignore() *EntitlementUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *EntitlementUpsertBulk) DoNothing() *EntitlementUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the EntitlementCreateBulk.OnConflict
+// documentation for more info.
+func (u *EntitlementUpsertBulk) Update(set func(*EntitlementUpsert)) *EntitlementUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&EntitlementUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetKey sets the "key" field.
+func (u *EntitlementUpsertBulk) SetKey(v string) *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.SetKey(v)
+	})
+}
+
+// UpdateKey sets the "key" field to the value that was provided on create.
+func (u *EntitlementUpsertBulk) UpdateKey() *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.UpdateKey()
+	})
+}
+
+// SetEnabled sets the "enabled" field.
+func (u *EntitlementUpsertBulk) SetEnabled(v bool) *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.SetEnabled(v)
+	})
+}
+
+// UpdateEnabled sets the "enabled" field to the value that was provided on create.
+func (u *EntitlementUpsertBulk) UpdateEnabled() *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.UpdateEnabled()
+	})
+}
+
+// SetLimit sets the "limit" field.
+func (u *EntitlementUpsertBulk) SetLimit(v int64) *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.SetLimit(v)
+	})
+}
+
+// AddLimit adds v to the "limit" field.
+func (u *EntitlementUpsertBulk) AddLimit(v int64) *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.AddLimit(v)
+	})
+}
+
+// UpdateLimit sets the "limit" field to the value that was provided on create.
+func (u *EntitlementUpsertBulk) UpdateLimit() *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.UpdateLimit()
+	})
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (u *EntitlementUpsertBulk) ClearLimit() *EntitlementUpsertBulk {
+	return u.Update(func(s *EntitlementUpsert) {
+		s.ClearLimit()
+	})
+}
+
+// Exec executes the query.
+func (u *EntitlementUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the EntitlementCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for EntitlementCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *EntitlementUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,357 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/entitlement"
+	"db-service/ent/predicate"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// EntitlementUpdate is the builder for updating Entitlement entities.
+type EntitlementUpdate struct {
+	config
+	hooks    []Hook
+	mutation *EntitlementMutation
+}
+
+// Where appends a list predicates to the EntitlementUpdate builder.
+func (eu *EntitlementUpdate) Where(ps ...predicate.Entitlement) *EntitlementUpdate {
+	eu.mutation.Where(ps...)
+	return eu
+}
+
+// SetKey sets the "key" field.
+func (eu *EntitlementUpdate) SetKey(s string) *EntitlementUpdate {
+	eu.mutation.SetKey(s)
+	return eu
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (eu *EntitlementUpdate) SetNillableKey(s *string) *EntitlementUpdate {
+	if s != nil {
+		eu.SetKey(*s)
+	}
+	return eu
+}
+
+// SetEnabled sets the "enabled" field.
+func (eu *EntitlementUpdate) SetEnabled(b bool) *EntitlementUpdate {
+	eu.mutation.SetEnabled(b)
+	return eu
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (eu *EntitlementUpdate) SetNillableEnabled(b *bool) *EntitlementUpdate {
+	if b != nil {
+		eu.SetEnabled(*b)
+	}
+	return eu
+}
+
+// SetLimit sets the "limit" field.
+func (eu *EntitlementUpdate) SetLimit(i int64) *EntitlementUpdate {
+	eu.mutation.ResetLimit()
+	eu.mutation.SetLimit(i)
+	return eu
+}
+
+// SetNillableLimit sets the "limit" field if the given value is not nil.
+func (eu *EntitlementUpdate) SetNillableLimit(i *int64) *EntitlementUpdate {
+	if i != nil {
+		eu.SetLimit(*i)
+	}
+	return eu
+}
+
+// AddLimit adds i to the "limit" field.
+func (eu *EntitlementUpdate) AddLimit(i int64) *EntitlementUpdate {
+	eu.mutation.AddLimit(i)
+	return eu
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (eu *EntitlementUpdate) ClearLimit() *EntitlementUpdate {
+	eu.mutation.ClearLimit()
+	return eu
+}
+
+// Mutation returns the EntitlementMutation object of the builder.
+func (eu *EntitlementUpdate) Mutation() *EntitlementMutation {
+	return eu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (eu *EntitlementUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, eu.sqlSave, eu.mutation, eu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (eu *EntitlementUpdate) SaveX(ctx context.Context) int {
+	affected, err := eu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (eu *EntitlementUpdate) Exec(ctx context.Context) error {
+	_, err := eu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eu *EntitlementUpdate) ExecX(ctx context.Context) {
+	if err := eu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (eu *EntitlementUpdate) check() error {
+	if v, ok := eu.mutation.Key(); ok {
+		if err := entitlement.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Entitlement.key": %w`, err)}
+		}
+	}
+	if v, ok := eu.mutation.Limit(); ok {
+		if err := entitlement.LimitValidator(v); err != nil {
+			return &ValidationError{Name: "limit", err: fmt.Errorf(`ent: validator failed for field "Entitlement.limit": %w`, err)}
+		}
+	}
+	if eu.mutation.PlanCleared() && len(eu.mutation.PlanIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Entitlement.plan"`)
+	}
+	return nil
+}
+
+func (eu *EntitlementUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := eu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(entitlement.Table, entitlement.Columns, sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt))
+	if ps := eu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := eu.mutation.Key(); ok {
+		_spec.SetField(entitlement.FieldKey, field.TypeString, value)
+	}
+	if value, ok := eu.mutation.Enabled(); ok {
+		_spec.SetField(entitlement.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := eu.mutation.Limit(); ok {
+		_spec.SetField(entitlement.FieldLimit, field.TypeInt64, value)
+	}
+	if value, ok := eu.mutation.AddedLimit(); ok {
+		_spec.AddField(entitlement.FieldLimit, field.TypeInt64, value)
+	}
+	if eu.mutation.LimitCleared() {
+		_spec.ClearField(entitlement.FieldLimit, field.TypeInt64)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, eu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{entitlement.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	eu.mutation.done = true
+	return n, nil
+}
+
+// EntitlementUpdateOne is the builder for updating a single Entitlement entity.
+type EntitlementUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *EntitlementMutation
+}
+
+// SetKey sets the "key" field.
+func (euo *EntitlementUpdateOne) SetKey(s string) *EntitlementUpdateOne {
+	euo.mutation.SetKey(s)
+	return euo
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (euo *EntitlementUpdateOne) SetNillableKey(s *string) *EntitlementUpdateOne {
+	if s != nil {
+		euo.SetKey(*s)
+	}
+	return euo
+}
+
+// SetEnabled sets the "enabled" field.
+func (euo *EntitlementUpdateOne) SetEnabled(b bool) *EntitlementUpdateOne {
+	euo.mutation.SetEnabled(b)
+	return euo
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (euo *EntitlementUpdateOne) SetNillableEnabled(b *bool) *EntitlementUpdateOne {
+	if b != nil {
+		euo.SetEnabled(*b)
+	}
+	return euo
+}
+
+// SetLimit sets the "limit" field.
+func (euo *EntitlementUpdateOne) SetLimit(i int64) *EntitlementUpdateOne {
+	euo.mutation.ResetLimit()
+	euo.mutation.SetLimit(i)
+	return euo
+}
+
+// SetNillableLimit sets the "limit" field if the given value is not nil.
+func (euo *EntitlementUpdateOne) SetNillableLimit(i *int64) *EntitlementUpdateOne {
+	if i != nil {
+		euo.SetLimit(*i)
+	}
+	return euo
+}
+
+// AddLimit adds i to the "limit" field.
+func (euo *EntitlementUpdateOne) AddLimit(i int64) *EntitlementUpdateOne {
+	euo.mutation.AddLimit(i)
+	return euo
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (euo *EntitlementUpdateOne) ClearLimit() *EntitlementUpdateOne {
+	euo.mutation.ClearLimit()
+	return euo
+}
+
+// Mutation returns the EntitlementMutation object of the builder.
+func (euo *EntitlementUpdateOne) Mutation() *EntitlementMutation {
+	return euo.mutation
+}
+
+// Where appends a list predicates to the EntitlementUpdate builder.
+func (euo *EntitlementUpdateOne) Where(ps ...predicate.Entitlement) *EntitlementUpdateOne {
+	euo.mutation.Where(ps...)
+	return euo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (euo *EntitlementUpdateOne) Select(field string, fields ...string) *EntitlementUpdateOne {
+	euo.fields = append([]string{field}, fields...)
+	return euo
+}
+
+// Save executes the query and returns the updated Entitlement entity.
+func (euo *EntitlementUpdateOne) Save(ctx context.Context) (*Entitlement, error) {
+	return withHooks(ctx, euo.sqlSave, euo.mutation, euo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (euo *EntitlementUpdateOne) SaveX(ctx context.Context) *Entitlement {
+	node, err := euo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (euo *EntitlementUpdateOne) Exec(ctx context.Context) error {
+	_, err := euo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (euo *EntitlementUpdateOne) ExecX(ctx context.Context) {
+	if err := euo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (euo *EntitlementUpdateOne) check() error {
+	if v, ok := euo.mutation.Key(); ok {
+		if err := entitlement.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Entitlement.key": %w`, err)}
+		}
+	}
+	if v, ok := euo.mutation.Limit(); ok {
+		if err := entitlement.LimitValidator(v); err != nil {
+			return &ValidationError{Name: "limit", err: fmt.Errorf(`ent: validator failed for field "Entitlement.limit": %w`, err)}
+		}
+	}
+	if euo.mutation.PlanCleared() && len(euo.mutation.PlanIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Entitlement.plan"`)
+	}
+	return nil
+}
+
+func (euo *EntitlementUpdateOne) sqlSave(ctx context.Context) (_node *Entitlement, err error) {
+	if err := euo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(entitlement.Table, entitlement.Columns, sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt))
+	id, ok := euo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Entitlement.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := euo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, entitlement.FieldID)
+		for _, f := range fields {
+			if !entitlement.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != entitlement.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := euo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := euo.mutation.Key(); ok {
+		_spec.SetField(entitlement.FieldKey, field.TypeString, value)
+	}
+	if value, ok := euo.mutation.Enabled(); ok {
+		_spec.SetField(entitlement.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := euo.mutation.Limit(); ok {
+		_spec.SetField(entitlement.FieldLimit, field.TypeInt64, value)
+	}
+	if value, ok := euo.mutation.AddedLimit(); ok {
+		_spec.AddField(entitlement.FieldLimit, field.TypeInt64, value)
+	}
+	if euo.mutation.LimitCleared() {
+		_spec.ClearField(entitlement.FieldLimit, field.TypeInt64)
+	}
+	_node = &Entitlement{config: euo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, euo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{entitlement.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	euo.mutation.done = true
+	return _node, nil
+}
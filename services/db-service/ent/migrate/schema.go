@@ -8,12 +8,90 @@ import (
 )
 
 var (
+	// EntitlementsColumns holds the columns for the "entitlements" table.
+	EntitlementsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key", Type: field.TypeString},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
+		{Name: "limit", Type: field.TypeInt64, Nullable: true},
+		{Name: "plan_entitlements", Type: field.TypeInt},
+	}
+	// EntitlementsTable holds the schema information for the "entitlements" table.
+	EntitlementsTable = &schema.Table{
+		Name:       "entitlements",
+		Columns:    EntitlementsColumns,
+		PrimaryKey: []*schema.Column{EntitlementsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "entitlements_plans_entitlements",
+				Columns:    []*schema.Column{EntitlementsColumns[4]},
+				RefColumns: []*schema.Column{PlansColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "entitlement_key_plan_entitlements",
+				Unique:  true,
+				Columns: []*schema.Column{EntitlementsColumns[1], EntitlementsColumns[4]},
+			},
+		},
+	}
+	// PlansColumns holds the columns for the "plans" table.
+	PlansColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key", Type: field.TypeString, Unique: true},
+		{Name: "name", Type: field.TypeString, Nullable: true},
+		{Name: "stripe_price_ids", Type: field.TypeJSON, Nullable: true},
+		{Name: "rank", Type: field.TypeInt, Default: 0},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// PlansTable holds the schema information for the "plans" table.
+	PlansTable = &schema.Table{
+		Name:       "plans",
+		Columns:    PlansColumns,
+		PrimaryKey: []*schema.Column{PlansColumns[0]},
+	}
+	// ProcessedEventsColumns holds the columns for the "processed_events" table.
+	ProcessedEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "event_id", Type: field.TypeString},
+		{Name: "event_type", Type: field.TypeString, Nullable: true},
+		{Name: "resource_id", Type: field.TypeString, Nullable: true},
+		{Name: "event_created_at", Type: field.TypeTime, Nullable: true},
+		{Name: "received_at", Type: field.TypeTime},
+		{Name: "processed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "outcome", Type: field.TypeEnum, Enums: []string{"processed", "stale", "failed"}},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "attempts", Type: field.TypeInt, Default: 1},
+	}
+	// ProcessedEventsTable holds the schema information for the "processed_events" table.
+	ProcessedEventsTable = &schema.Table{
+		Name:       "processed_events",
+		Columns:    ProcessedEventsColumns,
+		PrimaryKey: []*schema.Column{ProcessedEventsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "processedevent_provider_event_id",
+				Unique:  true,
+				Columns: []*schema.Column{ProcessedEventsColumns[1], ProcessedEventsColumns[2]},
+			},
+			{
+				Name:    "processedevent_provider_resource_id_event_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{ProcessedEventsColumns[1], ProcessedEventsColumns[4], ProcessedEventsColumns[5]},
+			},
+		},
+	}
 	// SubscriptionsColumns holds the columns for the "subscriptions" table.
 	SubscriptionsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
-		{Name: "stripe_customer_id", Type: field.TypeString, Unique: true},
+		{Name: "stripe_customer_id", Type: field.TypeString},
 		{Name: "stripe_subscription_id", Type: field.TypeString, Unique: true},
-		{Name: "status", Type: field.TypeString, Default: "inactive"},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"trialing", "active", "past_due", "canceled", "expired", "paused"}},
+		{Name: "tier", Type: field.TypeString},
 		{Name: "current_period_end", Type: field.TypeTime, Nullable: true},
 		{Name: "user_subscription", Type: field.TypeInt},
 	}
@@ -25,16 +103,55 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "subscriptions_users_subscription",
-				Columns:    []*schema.Column{SubscriptionsColumns[5]},
+				Columns:    []*schema.Column{SubscriptionsColumns[6]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "subscription_stripe_customer_id",
+				Unique:  false,
+				Columns: []*schema.Column{SubscriptionsColumns[1]},
+			},
+		},
+	}
+	// SubscriptionEventsColumns holds the columns for the "subscription_events" table.
+	SubscriptionEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "previous_status", Type: field.TypeEnum, Nullable: true, Enums: []string{"trialing", "active", "past_due", "canceled", "expired", "paused"}},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"trialing", "active", "past_due", "canceled", "expired", "paused"}},
+		{Name: "source", Type: field.TypeEnum, Enums: []string{"webhook", "admin", "scheduler"}},
+		{Name: "reason", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "subscription_events", Type: field.TypeInt},
+	}
+	// SubscriptionEventsTable holds the schema information for the "subscription_events" table.
+	SubscriptionEventsTable = &schema.Table{
+		Name:       "subscription_events",
+		Columns:    SubscriptionEventsColumns,
+		PrimaryKey: []*schema.Column{SubscriptionEventsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "subscription_events_subscriptions_events",
+				Columns:    []*schema.Column{SubscriptionEventsColumns[6]},
+				RefColumns: []*schema.Column{SubscriptionsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "subscriptionevent_subscription_events",
+				Unique:  false,
+				Columns: []*schema.Column{SubscriptionEventsColumns[6]},
+			},
+		},
 	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "clerk_user_id", Type: field.TypeString, Unique: true},
+		{Name: "uuid", Type: field.TypeUUID, Unique: true, Default: schema.Expr("gen_random_uuid()")},
 		{Name: "role", Type: field.TypeString, Default: "user"},
 		{Name: "is_subscribed", Type: field.TypeBool, Default: false},
 		{Name: "subscription_tier", Type: field.TypeString, Default: "free"},
@@ -49,11 +166,17 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		EntitlementsTable,
+		PlansTable,
+		ProcessedEventsTable,
 		SubscriptionsTable,
+		SubscriptionEventsTable,
 		UsersTable,
 	}
 )
 
 func init() {
+	EntitlementsTable.ForeignKeys[0].RefTable = PlansTable
 	SubscriptionsTable.ForeignKeys[0].RefTable = UsersTable
+	SubscriptionEventsTable.ForeignKeys[0].RefTable = SubscriptionsTable
 }
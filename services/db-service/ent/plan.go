@@ -0,0 +1,192 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"db-service/ent/plan"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Plan is the model entity for the Plan schema.
+type Plan struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Identifiant du plan, égal au tier des abonnements (subscription.tier)
+	Key string `json:"key,omitempty"`
+	// Nom affiché
+	Name string `json:"name,omitempty"`
+	// Prix Stripe qui vendent ce plan (mensuel, annuel...)
+	StripePriceIds []string `json:"stripe_price_ids,omitempty"`
+	// Rang du plan : quand un utilisateur a plusieurs abonnements, le tier du plan de plus haut rang l'emporte
+	Rank int `json:"rank,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PlanQuery when eager-loading is set.
+	Edges        PlanEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// PlanEdges holds the relations/edges for other nodes in the graph.
+type PlanEdges struct {
+	// Entitlements holds the value of the entitlements edge.
+	Entitlements []*Entitlement `json:"entitlements,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// EntitlementsOrErr returns the Entitlements value or an error if the edge
+// was not loaded in eager-loading.
+func (e PlanEdges) EntitlementsOrErr() ([]*Entitlement, error) {
+	if e.loadedTypes[0] {
+		return e.Entitlements, nil
+	}
+	return nil, &NotLoadedError{edge: "entitlements"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Plan) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case plan.FieldStripePriceIds:
+			values[i] = new([]byte)
+		case plan.FieldID, plan.FieldRank:
+			values[i] = new(sql.NullInt64)
+		case plan.FieldKey, plan.FieldName:
+			values[i] = new(sql.NullString)
+		case plan.FieldCreatedAt, plan.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Plan fields.
+func (pl *Plan) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case plan.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pl.ID = int(value.Int64)
+		case plan.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				pl.Key = value.String
+			}
+		case plan.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				pl.Name = value.String
+			}
+		case plan.FieldStripePriceIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field stripe_price_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pl.StripePriceIds); err != nil {
+					return fmt.Errorf("unmarshal field stripe_price_ids: %w", err)
+				}
+			}
+		case plan.FieldRank:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rank", values[i])
+			} else if value.Valid {
+				pl.Rank = int(value.Int64)
+			}
+		case plan.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pl.CreatedAt = value.Time
+			}
+		case plan.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				pl.UpdatedAt = value.Time
+			}
+		default:
+			pl.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Plan.
+// This includes values selected through modifiers, order, etc.
+func (pl *Plan) Value(name string) (ent.Value, error) {
+	return pl.selectValues.Get(name)
+}
+
+// QueryEntitlements queries the "entitlements" edge of the Plan entity.
+func (pl *Plan) QueryEntitlements() *EntitlementQuery {
+	return NewPlanClient(pl.config).QueryEntitlements(pl)
+}
+
+// Update returns a builder for updating this Plan.
+// Note that you need to call Plan.Unwrap() before calling this method if this Plan
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pl *Plan) Update() *PlanUpdateOne {
+	return NewPlanClient(pl.config).UpdateOne(pl)
+}
+
+// Unwrap unwraps the Plan entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pl *Plan) Unwrap() *Plan {
+	_tx, ok := pl.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Plan is not a transactional entity")
+	}
+	pl.config.driver = _tx.drv
+	return pl
+}
+
+// String implements the fmt.Stringer.
+func (pl *Plan) String() string {
+	var builder strings.Builder
+	builder.WriteString("Plan(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pl.ID))
+	builder.WriteString("key=")
+	builder.WriteString(pl.Key)
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(pl.Name)
+	builder.WriteString(", ")
+	builder.WriteString("stripe_price_ids=")
+	builder.WriteString(fmt.Sprintf("%v", pl.StripePriceIds))
+	builder.WriteString(", ")
+	builder.WriteString("rank=")
+	builder.WriteString(fmt.Sprintf("%v", pl.Rank))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(pl.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(pl.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Plans is a parsable slice of Plan.
+type Plans []*Plan
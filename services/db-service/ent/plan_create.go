@@ -0,0 +1,799 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PlanCreate is the builder for creating a Plan entity.
+type PlanCreate struct {
+	config
+	mutation *PlanMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetKey sets the "key" field.
+func (pc *PlanCreate) SetKey(s string) *PlanCreate {
+	pc.mutation.SetKey(s)
+	return pc
+}
+
+// SetName sets the "name" field.
+func (pc *PlanCreate) SetName(s string) *PlanCreate {
+	pc.mutation.SetName(s)
+	return pc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (pc *PlanCreate) SetNillableName(s *string) *PlanCreate {
+	if s != nil {
+		pc.SetName(*s)
+	}
+	return pc
+}
+
+// SetStripePriceIds sets the "stripe_price_ids" field.
+func (pc *PlanCreate) SetStripePriceIds(s []string) *PlanCreate {
+	pc.mutation.SetStripePriceIds(s)
+	return pc
+}
+
+// SetRank sets the "rank" field.
+func (pc *PlanCreate) SetRank(i int) *PlanCreate {
+	pc.mutation.SetRank(i)
+	return pc
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (pc *PlanCreate) SetNillableRank(i *int) *PlanCreate {
+	if i != nil {
+		pc.SetRank(*i)
+	}
+	return pc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (pc *PlanCreate) SetCreatedAt(t time.Time) *PlanCreate {
+	pc.mutation.SetCreatedAt(t)
+	return pc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (pc *PlanCreate) SetNillableCreatedAt(t *time.Time) *PlanCreate {
+	if t != nil {
+		pc.SetCreatedAt(*t)
+	}
+	return pc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (pc *PlanCreate) SetUpdatedAt(t time.Time) *PlanCreate {
+	pc.mutation.SetUpdatedAt(t)
+	return pc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (pc *PlanCreate) SetNillableUpdatedAt(t *time.Time) *PlanCreate {
+	if t != nil {
+		pc.SetUpdatedAt(*t)
+	}
+	return pc
+}
+
+// AddEntitlementIDs adds the "entitlements" edge to the Entitlement entity by IDs.
+func (pc *PlanCreate) AddEntitlementIDs(ids ...int) *PlanCreate {
+	pc.mutation.AddEntitlementIDs(ids...)
+	return pc
+}
+
+// AddEntitlements adds the "entitlements" edges to the Entitlement entity.
+func (pc *PlanCreate) AddEntitlements(e ...*Entitlement) *PlanCreate {
+	ids := make([]int, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return pc.AddEntitlementIDs(ids...)
+}
+
+// Mutation returns the PlanMutation object of the builder.
+func (pc *PlanCreate) Mutation() *PlanMutation {
+	return pc.mutation
+}
+
+// Save creates the Plan in the database.
+func (pc *PlanCreate) Save(ctx context.Context) (*Plan, error) {
+	pc.defaults()
+	return withHooks(ctx, pc.sqlSave, pc.mutation, pc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pc *PlanCreate) SaveX(ctx context.Context) *Plan {
+	v, err := pc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pc *PlanCreate) Exec(ctx context.Context) error {
+	_, err := pc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pc *PlanCreate) ExecX(ctx context.Context) {
+	if err := pc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pc *PlanCreate) defaults() {
+	if _, ok := pc.mutation.Rank(); !ok {
+		v := plan.DefaultRank
+		pc.mutation.SetRank(v)
+	}
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		v := plan.DefaultCreatedAt()
+		pc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		v := plan.DefaultUpdatedAt()
+		pc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pc *PlanCreate) check() error {
+	if _, ok := pc.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "Plan.key"`)}
+	}
+	if v, ok := pc.mutation.Key(); ok {
+		if err := plan.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "Plan.key": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Rank(); !ok {
+		return &ValidationError{Name: "rank", err: errors.New(`ent: missing required field "Plan.rank"`)}
+	}
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Plan.created_at"`)}
+	}
+	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Plan.updated_at"`)}
+	}
+	return nil
+}
+
+func (pc *PlanCreate) sqlSave(ctx context.Context) (*Plan, error) {
+	if err := pc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	pc.mutation.id = &_node.ID
+	pc.mutation.done = true
+	return _node, nil
+}
+
+func (pc *PlanCreate) createSpec() (*Plan, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Plan{config: pc.config}
+		_spec = sqlgraph.NewCreateSpec(plan.Table, sqlgraph.NewFieldSpec(plan.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = pc.conflict
+	if value, ok := pc.mutation.Key(); ok {
+		_spec.SetField(plan.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := pc.mutation.Name(); ok {
+		_spec.SetField(plan.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := pc.mutation.StripePriceIds(); ok {
+		_spec.SetField(plan.FieldStripePriceIds, field.TypeJSON, value)
+		_node.StripePriceIds = value
+	}
+	if value, ok := pc.mutation.Rank(); ok {
+		_spec.SetField(plan.FieldRank, field.TypeInt, value)
+		_node.Rank = value
+	}
+	if value, ok := pc.mutation.CreatedAt(); ok {
+		_spec.SetField(plan.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := pc.mutation.UpdatedAt(); ok {
+		_spec.SetField(plan.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := pc.mutation.EntitlementsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Plan.Create().
+//		SetKey(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PlanUpsert) {
+//			SetKey(v+v).
+//		}).
+//		Exec(ctx)
+func (pc *PlanCreate) OnConflict(opts ...sql.ConflictOption) *PlanUpsertOne {
+	pc.conflict = opts
+	return &PlanUpsertOne{
+		create: pc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Plan.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (pc *PlanCreate) OnConflictColumns(columns ...string) *PlanUpsertOne {
+	pc.conflict = append(pc.conflict, sql.ConflictColumns(columns...))
+	return &PlanUpsertOne{
+		create: pc,
+	}
+}
+
+type (
+	// PlanUpsertOne is the builder for "upsert"-ing
+	//  one Plan node.
+	PlanUpsertOne struct {
+		create *PlanCreate
+	}
+
+	// PlanUpsert is the "OnConflict" setter.
+	PlanUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetName sets the "name" field.
+func (u *PlanUpsert) SetName(v string) *PlanUpsert {
+	u.Set(plan.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *PlanUpsert) UpdateName() *PlanUpsert {
+	u.SetExcluded(plan.FieldName)
+	return u
+}
+
+// ClearName clears the value of the "name" field.
+func (u *PlanUpsert) ClearName() *PlanUpsert {
+	u.SetNull(plan.FieldName)
+	return u
+}
+
+// SetStripePriceIds sets the "stripe_price_ids" field.
+func (u *PlanUpsert) SetStripePriceIds(v []string) *PlanUpsert {
+	u.Set(plan.FieldStripePriceIds, v)
+	return u
+}
+
+// UpdateStripePriceIds sets the "stripe_price_ids" field to the value that was provided on create.
+func (u *PlanUpsert) UpdateStripePriceIds() *PlanUpsert {
+	u.SetExcluded(plan.FieldStripePriceIds)
+	return u
+}
+
+// ClearStripePriceIds clears the value of the "stripe_price_ids" field.
+func (u *PlanUpsert) ClearStripePriceIds() *PlanUpsert {
+	u.SetNull(plan.FieldStripePriceIds)
+	return u
+}
+
+// SetRank sets the "rank" field.
+func (u *PlanUpsert) SetRank(v int) *PlanUpsert {
+	u.Set(plan.FieldRank, v)
+	return u
+}
+
+// UpdateRank sets the "rank" field to the value that was provided on create.
+func (u *PlanUpsert) UpdateRank() *PlanUpsert {
+	u.SetExcluded(plan.FieldRank)
+	return u
+}
+
+// AddRank adds v to the "rank" field.
+func (u *PlanUpsert) AddRank(v int) *PlanUpsert {
+	u.Add(plan.FieldRank, v)
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *PlanUpsert) SetUpdatedAt(v time.Time) *PlanUpsert {
+	u.Set(plan.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *PlanUpsert) UpdateUpdatedAt() *PlanUpsert {
+	u.SetExcluded(plan.FieldUpdatedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.Plan.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *PlanUpsertOne) UpdateNewValues() *PlanUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.Key(); exists {
+			s.SetIgnore(plan.FieldKey)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(plan.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Plan.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *PlanUpsertOne) Ignore() *PlanUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PlanUpsertOne) DoNothing() *PlanUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PlanCreate.OnConflict
+// documentation for more info.
+func (u *PlanUpsertOne) Update(set func(*PlanUpsert)) *PlanUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PlanUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *PlanUpsertOne) SetName(v string) *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *PlanUpsertOne) UpdateName() *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateName()
+	})
+}
+
+// ClearName clears the value of the "name" field.
+func (u *PlanUpsertOne) ClearName() *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.ClearName()
+	})
+}
+
+// SetStripePriceIds sets the "stripe_price_ids" field.
+func (u *PlanUpsertOne) SetStripePriceIds(v []string) *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetStripePriceIds(v)
+	})
+}
+
+// UpdateStripePriceIds sets the "stripe_price_ids" field to the value that was provided on create.
+func (u *PlanUpsertOne) UpdateStripePriceIds() *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateStripePriceIds()
+	})
+}
+
+// ClearStripePriceIds clears the value of the "stripe_price_ids" field.
+func (u *PlanUpsertOne) ClearStripePriceIds() *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.ClearStripePriceIds()
+	})
+}
+
+// SetRank sets the "rank" field.
+func (u *PlanUpsertOne) SetRank(v int) *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetRank(v)
+	})
+}
+
+// AddRank adds v to the "rank" field.
+func (u *PlanUpsertOne) AddRank(v int) *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.AddRank(v)
+	})
+}
+
+// UpdateRank sets the "rank" field to the value that was provided on create.
+func (u *PlanUpsertOne) UpdateRank() *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateRank()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *PlanUpsertOne) SetUpdatedAt(v time.Time) *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *PlanUpsertOne) UpdateUpdatedAt() *PlanUpsertOne {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *PlanUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PlanCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PlanUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *PlanUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *PlanUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// PlanCreateBulk is the builder for creating many Plan entities in bulk.
+type PlanCreateBulk struct {
+	config
+	err      error
+	builders []*PlanCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the Plan entities in the database.
+func (pcb *PlanCreateBulk) Save(ctx context.Context) ([]*Plan, error) {
+	if pcb.err != nil {
+		return nil, pcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pcb.builders))
+	nodes := make([]*Plan, len(pcb.builders))
+	mutators := make([]Mutator, len(pcb.builders))
+	for i := range pcb.builders {
+		func(i int, root context.Context) {
+			builder := pcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PlanMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = pcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pcb *PlanCreateBulk) SaveX(ctx context.Context) []*Plan {
+	v, err := pcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pcb *PlanCreateBulk) Exec(ctx context.Context) error {
+	_, err := pcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcb *PlanCreateBulk) ExecX(ctx context.Context) {
+	if err := pcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Plan.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PlanUpsert) {
+//			SetKey(v+v).
+//		}).
+//		Exec(ctx)
+func (pcb *PlanCreateBulk) OnConflict(opts ...sql.ConflictOption) *PlanUpsertBulk {
+	pcb.conflict = opts
+	return &PlanUpsertBulk{
+		create: pcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Plan.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (pcb *PlanCreateBulk) OnConflictColumns(columns ...string) *PlanUpsertBulk {
+	pcb.conflict = append(pcb.conflict, sql.ConflictColumns(columns...))
+	return &PlanUpsertBulk{
+		create: pcb,
+	}
+}
+
+// PlanUpsertBulk is the builder for "upsert"-ing
+// a bulk of Plan nodes.
+type PlanUpsertBulk struct {
+	create *PlanCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Plan.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *PlanUpsertBulk) UpdateNewValues() *PlanUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.Key(); exists {
+				s.SetIgnore(plan.FieldKey)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(plan.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Plan.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *PlanUpsertBulk) Ignore() *PlanUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PlanUpsertBulk) DoNothing() *PlanUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PlanCreateBulk.OnConflict
+// documentation for more info.
+func (u *PlanUpsertBulk) Update(set func(*PlanUpsert)) *PlanUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PlanUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *PlanUpsertBulk) SetName(v string) *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *PlanUpsertBulk) UpdateName() *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateName()
+	})
+}
+
+// ClearName clears the value of the "name" field.
+func (u *PlanUpsertBulk) ClearName() *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.ClearName()
+	})
+}
+
+// SetStripePriceIds sets the "stripe_price_ids" field.
+func (u *PlanUpsertBulk) SetStripePriceIds(v []string) *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetStripePriceIds(v)
+	})
+}
+
+// UpdateStripePriceIds sets the "stripe_price_ids" field to the value that was provided on create.
+func (u *PlanUpsertBulk) UpdateStripePriceIds() *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateStripePriceIds()
+	})
+}
+
+// ClearStripePriceIds clears the value of the "stripe_price_ids" field.
+func (u *PlanUpsertBulk) ClearStripePriceIds() *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.ClearStripePriceIds()
+	})
+}
+
+// SetRank sets the "rank" field.
+func (u *PlanUpsertBulk) SetRank(v int) *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetRank(v)
+	})
+}
+
+// AddRank adds v to the "rank" field.
+func (u *PlanUpsertBulk) AddRank(v int) *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.AddRank(v)
+	})
+}
+
+// UpdateRank sets the "rank" field to the value that was provided on create.
+func (u *PlanUpsertBulk) UpdateRank() *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateRank()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *PlanUpsertBulk) SetUpdatedAt(v time.Time) *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *PlanUpsertBulk) UpdateUpdatedAt() *PlanUpsertBulk {
+	return u.Update(func(s *PlanUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *PlanUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the PlanCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PlanCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PlanUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,540 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"db-service/ent/predicate"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// PlanUpdate is the builder for updating Plan entities.
+type PlanUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PlanMutation
+}
+
+// Where appends a list predicates to the PlanUpdate builder.
+func (pu *PlanUpdate) Where(ps ...predicate.Plan) *PlanUpdate {
+	pu.mutation.Where(ps...)
+	return pu
+}
+
+// SetName sets the "name" field.
+func (pu *PlanUpdate) SetName(s string) *PlanUpdate {
+	pu.mutation.SetName(s)
+	return pu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (pu *PlanUpdate) SetNillableName(s *string) *PlanUpdate {
+	if s != nil {
+		pu.SetName(*s)
+	}
+	return pu
+}
+
+// ClearName clears the value of the "name" field.
+func (pu *PlanUpdate) ClearName() *PlanUpdate {
+	pu.mutation.ClearName()
+	return pu
+}
+
+// SetStripePriceIds sets the "stripe_price_ids" field.
+func (pu *PlanUpdate) SetStripePriceIds(s []string) *PlanUpdate {
+	pu.mutation.SetStripePriceIds(s)
+	return pu
+}
+
+// AppendStripePriceIds appends s to the "stripe_price_ids" field.
+func (pu *PlanUpdate) AppendStripePriceIds(s []string) *PlanUpdate {
+	pu.mutation.AppendStripePriceIds(s)
+	return pu
+}
+
+// ClearStripePriceIds clears the value of the "stripe_price_ids" field.
+func (pu *PlanUpdate) ClearStripePriceIds() *PlanUpdate {
+	pu.mutation.ClearStripePriceIds()
+	return pu
+}
+
+// SetRank sets the "rank" field.
+func (pu *PlanUpdate) SetRank(i int) *PlanUpdate {
+	pu.mutation.ResetRank()
+	pu.mutation.SetRank(i)
+	return pu
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (pu *PlanUpdate) SetNillableRank(i *int) *PlanUpdate {
+	if i != nil {
+		pu.SetRank(*i)
+	}
+	return pu
+}
+
+// AddRank adds i to the "rank" field.
+func (pu *PlanUpdate) AddRank(i int) *PlanUpdate {
+	pu.mutation.AddRank(i)
+	return pu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (pu *PlanUpdate) SetUpdatedAt(t time.Time) *PlanUpdate {
+	pu.mutation.SetUpdatedAt(t)
+	return pu
+}
+
+// AddEntitlementIDs adds the "entitlements" edge to the Entitlement entity by IDs.
+func (pu *PlanUpdate) AddEntitlementIDs(ids ...int) *PlanUpdate {
+	pu.mutation.AddEntitlementIDs(ids...)
+	return pu
+}
+
+// AddEntitlements adds the "entitlements" edges to the Entitlement entity.
+func (pu *PlanUpdate) AddEntitlements(e ...*Entitlement) *PlanUpdate {
+	ids := make([]int, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return pu.AddEntitlementIDs(ids...)
+}
+
+// Mutation returns the PlanMutation object of the builder.
+func (pu *PlanUpdate) Mutation() *PlanMutation {
+	return pu.mutation
+}
+
+// ClearEntitlements clears all "entitlements" edges to the Entitlement entity.
+func (pu *PlanUpdate) ClearEntitlements() *PlanUpdate {
+	pu.mutation.ClearEntitlements()
+	return pu
+}
+
+// RemoveEntitlementIDs removes the "entitlements" edge to Entitlement entities by IDs.
+func (pu *PlanUpdate) RemoveEntitlementIDs(ids ...int) *PlanUpdate {
+	pu.mutation.RemoveEntitlementIDs(ids...)
+	return pu
+}
+
+// RemoveEntitlements removes "entitlements" edges to Entitlement entities.
+func (pu *PlanUpdate) RemoveEntitlements(e ...*Entitlement) *PlanUpdate {
+	ids := make([]int, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return pu.RemoveEntitlementIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pu *PlanUpdate) Save(ctx context.Context) (int, error) {
+	pu.defaults()
+	return withHooks(ctx, pu.sqlSave, pu.mutation, pu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pu *PlanUpdate) SaveX(ctx context.Context) int {
+	affected, err := pu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pu *PlanUpdate) Exec(ctx context.Context) error {
+	_, err := pu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pu *PlanUpdate) ExecX(ctx context.Context) {
+	if err := pu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pu *PlanUpdate) defaults() {
+	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		v := plan.UpdateDefaultUpdatedAt()
+		pu.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (pu *PlanUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(plan.Table, plan.Columns, sqlgraph.NewFieldSpec(plan.FieldID, field.TypeInt))
+	if ps := pu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := pu.mutation.Name(); ok {
+		_spec.SetField(plan.FieldName, field.TypeString, value)
+	}
+	if pu.mutation.NameCleared() {
+		_spec.ClearField(plan.FieldName, field.TypeString)
+	}
+	if value, ok := pu.mutation.StripePriceIds(); ok {
+		_spec.SetField(plan.FieldStripePriceIds, field.TypeJSON, value)
+	}
+	if value, ok := pu.mutation.AppendedStripePriceIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, plan.FieldStripePriceIds, value)
+		})
+	}
+	if pu.mutation.StripePriceIdsCleared() {
+		_spec.ClearField(plan.FieldStripePriceIds, field.TypeJSON)
+	}
+	if value, ok := pu.mutation.Rank(); ok {
+		_spec.SetField(plan.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedRank(); ok {
+		_spec.AddField(plan.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.UpdatedAt(); ok {
+		_spec.SetField(plan.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if pu.mutation.EntitlementsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedEntitlementsIDs(); len(nodes) > 0 && !pu.mutation.EntitlementsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.EntitlementsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{plan.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pu.mutation.done = true
+	return n, nil
+}
+
+// PlanUpdateOne is the builder for updating a single Plan entity.
+type PlanUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PlanMutation
+}
+
+// SetName sets the "name" field.
+func (puo *PlanUpdateOne) SetName(s string) *PlanUpdateOne {
+	puo.mutation.SetName(s)
+	return puo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (puo *PlanUpdateOne) SetNillableName(s *string) *PlanUpdateOne {
+	if s != nil {
+		puo.SetName(*s)
+	}
+	return puo
+}
+
+// ClearName clears the value of the "name" field.
+func (puo *PlanUpdateOne) ClearName() *PlanUpdateOne {
+	puo.mutation.ClearName()
+	return puo
+}
+
+// SetStripePriceIds sets the "stripe_price_ids" field.
+func (puo *PlanUpdateOne) SetStripePriceIds(s []string) *PlanUpdateOne {
+	puo.mutation.SetStripePriceIds(s)
+	return puo
+}
+
+// AppendStripePriceIds appends s to the "stripe_price_ids" field.
+func (puo *PlanUpdateOne) AppendStripePriceIds(s []string) *PlanUpdateOne {
+	puo.mutation.AppendStripePriceIds(s)
+	return puo
+}
+
+// ClearStripePriceIds clears the value of the "stripe_price_ids" field.
+func (puo *PlanUpdateOne) ClearStripePriceIds() *PlanUpdateOne {
+	puo.mutation.ClearStripePriceIds()
+	return puo
+}
+
+// SetRank sets the "rank" field.
+func (puo *PlanUpdateOne) SetRank(i int) *PlanUpdateOne {
+	puo.mutation.ResetRank()
+	puo.mutation.SetRank(i)
+	return puo
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (puo *PlanUpdateOne) SetNillableRank(i *int) *PlanUpdateOne {
+	if i != nil {
+		puo.SetRank(*i)
+	}
+	return puo
+}
+
+// AddRank adds i to the "rank" field.
+func (puo *PlanUpdateOne) AddRank(i int) *PlanUpdateOne {
+	puo.mutation.AddRank(i)
+	return puo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (puo *PlanUpdateOne) SetUpdatedAt(t time.Time) *PlanUpdateOne {
+	puo.mutation.SetUpdatedAt(t)
+	return puo
+}
+
+// AddEntitlementIDs adds the "entitlements" edge to the Entitlement entity by IDs.
+func (puo *PlanUpdateOne) AddEntitlementIDs(ids ...int) *PlanUpdateOne {
+	puo.mutation.AddEntitlementIDs(ids...)
+	return puo
+}
+
+// AddEntitlements adds the "entitlements" edges to the Entitlement entity.
+func (puo *PlanUpdateOne) AddEntitlements(e ...*Entitlement) *PlanUpdateOne {
+	ids := make([]int, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return puo.AddEntitlementIDs(ids...)
+}
+
+// Mutation returns the PlanMutation object of the builder.
+func (puo *PlanUpdateOne) Mutation() *PlanMutation {
+	return puo.mutation
+}
+
+// ClearEntitlements clears all "entitlements" edges to the Entitlement entity.
+func (puo *PlanUpdateOne) ClearEntitlements() *PlanUpdateOne {
+	puo.mutation.ClearEntitlements()
+	return puo
+}
+
+// RemoveEntitlementIDs removes the "entitlements" edge to Entitlement entities by IDs.
+func (puo *PlanUpdateOne) RemoveEntitlementIDs(ids ...int) *PlanUpdateOne {
+	puo.mutation.RemoveEntitlementIDs(ids...)
+	return puo
+}
+
+// RemoveEntitlements removes "entitlements" edges to Entitlement entities.
+func (puo *PlanUpdateOne) RemoveEntitlements(e ...*Entitlement) *PlanUpdateOne {
+	ids := make([]int, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return puo.RemoveEntitlementIDs(ids...)
+}
+
+// Where appends a list predicates to the PlanUpdate builder.
+func (puo *PlanUpdateOne) Where(ps ...predicate.Plan) *PlanUpdateOne {
+	puo.mutation.Where(ps...)
+	return puo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (puo *PlanUpdateOne) Select(field string, fields ...string) *PlanUpdateOne {
+	puo.fields = append([]string{field}, fields...)
+	return puo
+}
+
+// Save executes the query and returns the updated Plan entity.
+func (puo *PlanUpdateOne) Save(ctx context.Context) (*Plan, error) {
+	puo.defaults()
+	return withHooks(ctx, puo.sqlSave, puo.mutation, puo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (puo *PlanUpdateOne) SaveX(ctx context.Context) *Plan {
+	node, err := puo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (puo *PlanUpdateOne) Exec(ctx context.Context) error {
+	_, err := puo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (puo *PlanUpdateOne) ExecX(ctx context.Context) {
+	if err := puo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (puo *PlanUpdateOne) defaults() {
+	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		v := plan.UpdateDefaultUpdatedAt()
+		puo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (puo *PlanUpdateOne) sqlSave(ctx context.Context) (_node *Plan, err error) {
+	_spec := sqlgraph.NewUpdateSpec(plan.Table, plan.Columns, sqlgraph.NewFieldSpec(plan.FieldID, field.TypeInt))
+	id, ok := puo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Plan.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := puo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, plan.FieldID)
+		for _, f := range fields {
+			if !plan.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != plan.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := puo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := puo.mutation.Name(); ok {
+		_spec.SetField(plan.FieldName, field.TypeString, value)
+	}
+	if puo.mutation.NameCleared() {
+		_spec.ClearField(plan.FieldName, field.TypeString)
+	}
+	if value, ok := puo.mutation.StripePriceIds(); ok {
+		_spec.SetField(plan.FieldStripePriceIds, field.TypeJSON, value)
+	}
+	if value, ok := puo.mutation.AppendedStripePriceIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, plan.FieldStripePriceIds, value)
+		})
+	}
+	if puo.mutation.StripePriceIdsCleared() {
+		_spec.ClearField(plan.FieldStripePriceIds, field.TypeJSON)
+	}
+	if value, ok := puo.mutation.Rank(); ok {
+		_spec.SetField(plan.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedRank(); ok {
+		_spec.AddField(plan.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.UpdatedAt(); ok {
+		_spec.SetField(plan.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if puo.mutation.EntitlementsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedEntitlementsIDs(); len(nodes) > 0 && !puo.mutation.EntitlementsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.EntitlementsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   plan.EntitlementsTable,
+			Columns: []string{plan.EntitlementsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(entitlement.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Plan{config: puo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, puo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{plan.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	puo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,211 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"db-service/ent/processedevent"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ProcessedEvent is the model entity for the ProcessedEvent schema.
+type ProcessedEvent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Émetteur du webhook : stripe, clerk, mailerlite
+	Provider string `json:"provider,omitempty"`
+	// ID de l'événement chez l'émetteur
+	EventID string `json:"event_id,omitempty"`
+	// EventType holds the value of the "event_type" field.
+	EventType string `json:"event_type,omitempty"`
+	// Objet concerné (abonnement Stripe, utilisateur Clerk…), pour l'ordre des événements
+	ResourceID string `json:"resource_id,omitempty"`
+	// Date de création de l'événement chez l'émetteur
+	EventCreatedAt *time.Time `json:"event_created_at,omitempty"`
+	// Dernière réception
+	ReceivedAt time.Time `json:"received_at,omitempty"`
+	// ProcessedAt holds the value of the "processed_at" field.
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	// processed : appliqué ; stale : plus ancien qu'un événement déjà appliqué ; failed : à retenter
+	Outcome processedevent.Outcome `json:"outcome,omitempty"`
+	// Erreur du dernier essai en échec
+	Error string `json:"error,omitempty"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts     int `json:"attempts,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ProcessedEvent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case processedevent.FieldID, processedevent.FieldAttempts:
+			values[i] = new(sql.NullInt64)
+		case processedevent.FieldProvider, processedevent.FieldEventID, processedevent.FieldEventType, processedevent.FieldResourceID, processedevent.FieldOutcome, processedevent.FieldError:
+			values[i] = new(sql.NullString)
+		case processedevent.FieldEventCreatedAt, processedevent.FieldReceivedAt, processedevent.FieldProcessedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ProcessedEvent fields.
+func (pe *ProcessedEvent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case processedevent.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pe.ID = int(value.Int64)
+		case processedevent.FieldProvider:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field provider", values[i])
+			} else if value.Valid {
+				pe.Provider = value.String
+			}
+		case processedevent.FieldEventID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field event_id", values[i])
+			} else if value.Valid {
+				pe.EventID = value.String
+			}
+		case processedevent.FieldEventType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field event_type", values[i])
+			} else if value.Valid {
+				pe.EventType = value.String
+			}
+		case processedevent.FieldResourceID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resource_id", values[i])
+			} else if value.Valid {
+				pe.ResourceID = value.String
+			}
+		case processedevent.FieldEventCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field event_created_at", values[i])
+			} else if value.Valid {
+				pe.EventCreatedAt = new(time.Time)
+				*pe.EventCreatedAt = value.Time
+			}
+		case processedevent.FieldReceivedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field received_at", values[i])
+			} else if value.Valid {
+				pe.ReceivedAt = value.Time
+			}
+		case processedevent.FieldProcessedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field processed_at", values[i])
+			} else if value.Valid {
+				pe.ProcessedAt = new(time.Time)
+				*pe.ProcessedAt = value.Time
+			}
+		case processedevent.FieldOutcome:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field outcome", values[i])
+			} else if value.Valid {
+				pe.Outcome = processedevent.Outcome(value.String)
+			}
+		case processedevent.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				pe.Error = value.String
+			}
+		case processedevent.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				pe.Attempts = int(value.Int64)
+			}
+		default:
+			pe.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ProcessedEvent.
+// This includes values selected through modifiers, order, etc.
+func (pe *ProcessedEvent) Value(name string) (ent.Value, error) {
+	return pe.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ProcessedEvent.
+// Note that you need to call ProcessedEvent.Unwrap() before calling this method if this ProcessedEvent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pe *ProcessedEvent) Update() *ProcessedEventUpdateOne {
+	return NewProcessedEventClient(pe.config).UpdateOne(pe)
+}
+
+// Unwrap unwraps the ProcessedEvent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pe *ProcessedEvent) Unwrap() *ProcessedEvent {
+	_tx, ok := pe.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ProcessedEvent is not a transactional entity")
+	}
+	pe.config.driver = _tx.drv
+	return pe
+}
+
+// String implements the fmt.Stringer.
+func (pe *ProcessedEvent) String() string {
+	var builder strings.Builder
+	builder.WriteString("ProcessedEvent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pe.ID))
+	builder.WriteString("provider=")
+	builder.WriteString(pe.Provider)
+	builder.WriteString(", ")
+	builder.WriteString("event_id=")
+	builder.WriteString(pe.EventID)
+	builder.WriteString(", ")
+	builder.WriteString("event_type=")
+	builder.WriteString(pe.EventType)
+	builder.WriteString(", ")
+	builder.WriteString("resource_id=")
+	builder.WriteString(pe.ResourceID)
+	builder.WriteString(", ")
+	if v := pe.EventCreatedAt; v != nil {
+		builder.WriteString("event_created_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("received_at=")
+	builder.WriteString(pe.ReceivedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := pe.ProcessedAt; v != nil {
+		builder.WriteString("processed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("outcome=")
+	builder.WriteString(fmt.Sprintf("%v", pe.Outcome))
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(pe.Error)
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", pe.Attempts))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ProcessedEvents is a parsable slice of ProcessedEvent.
+type ProcessedEvents []*ProcessedEvent
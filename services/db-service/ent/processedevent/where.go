@@ -0,0 +1,670 @@
+// Code generated by ent, DO NOT EDIT.
+
+package processedevent
+
+import (
+	"db-service/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldID, id))
+}
+
+// Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
+func Provider(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldProvider, v))
+}
+
+// EventID applies equality check predicate on the "event_id" field. It's identical to EventIDEQ.
+func EventID(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEventID, v))
+}
+
+// EventType applies equality check predicate on the "event_type" field. It's identical to EventTypeEQ.
+func EventType(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEventType, v))
+}
+
+// ResourceID applies equality check predicate on the "resource_id" field. It's identical to ResourceIDEQ.
+func ResourceID(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldResourceID, v))
+}
+
+// EventCreatedAt applies equality check predicate on the "event_created_at" field. It's identical to EventCreatedAtEQ.
+func EventCreatedAt(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEventCreatedAt, v))
+}
+
+// ReceivedAt applies equality check predicate on the "received_at" field. It's identical to ReceivedAtEQ.
+func ReceivedAt(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldReceivedAt, v))
+}
+
+// ProcessedAt applies equality check predicate on the "processed_at" field. It's identical to ProcessedAtEQ.
+func ProcessedAt(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldError, v))
+}
+
+// Attempts applies equality check predicate on the "attempts" field. It's identical to AttemptsEQ.
+func Attempts(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldAttempts, v))
+}
+
+// ProviderEQ applies the EQ predicate on the "provider" field.
+func ProviderEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldProvider, v))
+}
+
+// ProviderNEQ applies the NEQ predicate on the "provider" field.
+func ProviderNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldProvider, v))
+}
+
+// ProviderIn applies the In predicate on the "provider" field.
+func ProviderIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldProvider, vs...))
+}
+
+// ProviderNotIn applies the NotIn predicate on the "provider" field.
+func ProviderNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldProvider, vs...))
+}
+
+// ProviderGT applies the GT predicate on the "provider" field.
+func ProviderGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldProvider, v))
+}
+
+// ProviderGTE applies the GTE predicate on the "provider" field.
+func ProviderGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldProvider, v))
+}
+
+// ProviderLT applies the LT predicate on the "provider" field.
+func ProviderLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldProvider, v))
+}
+
+// ProviderLTE applies the LTE predicate on the "provider" field.
+func ProviderLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldProvider, v))
+}
+
+// ProviderContains applies the Contains predicate on the "provider" field.
+func ProviderContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldProvider, v))
+}
+
+// ProviderHasPrefix applies the HasPrefix predicate on the "provider" field.
+func ProviderHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldProvider, v))
+}
+
+// ProviderHasSuffix applies the HasSuffix predicate on the "provider" field.
+func ProviderHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldProvider, v))
+}
+
+// ProviderEqualFold applies the EqualFold predicate on the "provider" field.
+func ProviderEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldProvider, v))
+}
+
+// ProviderContainsFold applies the ContainsFold predicate on the "provider" field.
+func ProviderContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldProvider, v))
+}
+
+// EventIDEQ applies the EQ predicate on the "event_id" field.
+func EventIDEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEventID, v))
+}
+
+// EventIDNEQ applies the NEQ predicate on the "event_id" field.
+func EventIDNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldEventID, v))
+}
+
+// EventIDIn applies the In predicate on the "event_id" field.
+func EventIDIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldEventID, vs...))
+}
+
+// EventIDNotIn applies the NotIn predicate on the "event_id" field.
+func EventIDNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldEventID, vs...))
+}
+
+// EventIDGT applies the GT predicate on the "event_id" field.
+func EventIDGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldEventID, v))
+}
+
+// EventIDGTE applies the GTE predicate on the "event_id" field.
+func EventIDGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldEventID, v))
+}
+
+// EventIDLT applies the LT predicate on the "event_id" field.
+func EventIDLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldEventID, v))
+}
+
+// EventIDLTE applies the LTE predicate on the "event_id" field.
+func EventIDLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldEventID, v))
+}
+
+// EventIDContains applies the Contains predicate on the "event_id" field.
+func EventIDContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldEventID, v))
+}
+
+// EventIDHasPrefix applies the HasPrefix predicate on the "event_id" field.
+func EventIDHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldEventID, v))
+}
+
+// EventIDHasSuffix applies the HasSuffix predicate on the "event_id" field.
+func EventIDHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldEventID, v))
+}
+
+// EventIDEqualFold applies the EqualFold predicate on the "event_id" field.
+func EventIDEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldEventID, v))
+}
+
+// EventIDContainsFold applies the ContainsFold predicate on the "event_id" field.
+func EventIDContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldEventID, v))
+}
+
+// EventTypeEQ applies the EQ predicate on the "event_type" field.
+func EventTypeEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEventType, v))
+}
+
+// EventTypeNEQ applies the NEQ predicate on the "event_type" field.
+func EventTypeNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldEventType, v))
+}
+
+// EventTypeIn applies the In predicate on the "event_type" field.
+func EventTypeIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldEventType, vs...))
+}
+
+// EventTypeNotIn applies the NotIn predicate on the "event_type" field.
+func EventTypeNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldEventType, vs...))
+}
+
+// EventTypeGT applies the GT predicate on the "event_type" field.
+func EventTypeGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldEventType, v))
+}
+
+// EventTypeGTE applies the GTE predicate on the "event_type" field.
+func EventTypeGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldEventType, v))
+}
+
+// EventTypeLT applies the LT predicate on the "event_type" field.
+func EventTypeLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldEventType, v))
+}
+
+// EventTypeLTE applies the LTE predicate on the "event_type" field.
+func EventTypeLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldEventType, v))
+}
+
+// EventTypeContains applies the Contains predicate on the "event_type" field.
+func EventTypeContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldEventType, v))
+}
+
+// EventTypeHasPrefix applies the HasPrefix predicate on the "event_type" field.
+func EventTypeHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldEventType, v))
+}
+
+// EventTypeHasSuffix applies the HasSuffix predicate on the "event_type" field.
+func EventTypeHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldEventType, v))
+}
+
+// EventTypeIsNil applies the IsNil predicate on the "event_type" field.
+func EventTypeIsNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIsNull(FieldEventType))
+}
+
+// EventTypeNotNil applies the NotNil predicate on the "event_type" field.
+func EventTypeNotNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotNull(FieldEventType))
+}
+
+// EventTypeEqualFold applies the EqualFold predicate on the "event_type" field.
+func EventTypeEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldEventType, v))
+}
+
+// EventTypeContainsFold applies the ContainsFold predicate on the "event_type" field.
+func EventTypeContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldEventType, v))
+}
+
+// ResourceIDEQ applies the EQ predicate on the "resource_id" field.
+func ResourceIDEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldResourceID, v))
+}
+
+// ResourceIDNEQ applies the NEQ predicate on the "resource_id" field.
+func ResourceIDNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldResourceID, v))
+}
+
+// ResourceIDIn applies the In predicate on the "resource_id" field.
+func ResourceIDIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldResourceID, vs...))
+}
+
+// ResourceIDNotIn applies the NotIn predicate on the "resource_id" field.
+func ResourceIDNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldResourceID, vs...))
+}
+
+// ResourceIDGT applies the GT predicate on the "resource_id" field.
+func ResourceIDGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldResourceID, v))
+}
+
+// ResourceIDGTE applies the GTE predicate on the "resource_id" field.
+func ResourceIDGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldResourceID, v))
+}
+
+// ResourceIDLT applies the LT predicate on the "resource_id" field.
+func ResourceIDLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldResourceID, v))
+}
+
+// ResourceIDLTE applies the LTE predicate on the "resource_id" field.
+func ResourceIDLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldResourceID, v))
+}
+
+// ResourceIDContains applies the Contains predicate on the "resource_id" field.
+func ResourceIDContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldResourceID, v))
+}
+
+// ResourceIDHasPrefix applies the HasPrefix predicate on the "resource_id" field.
+func ResourceIDHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldResourceID, v))
+}
+
+// ResourceIDHasSuffix applies the HasSuffix predicate on the "resource_id" field.
+func ResourceIDHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldResourceID, v))
+}
+
+// ResourceIDIsNil applies the IsNil predicate on the "resource_id" field.
+func ResourceIDIsNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIsNull(FieldResourceID))
+}
+
+// ResourceIDNotNil applies the NotNil predicate on the "resource_id" field.
+func ResourceIDNotNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotNull(FieldResourceID))
+}
+
+// ResourceIDEqualFold applies the EqualFold predicate on the "resource_id" field.
+func ResourceIDEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldResourceID, v))
+}
+
+// ResourceIDContainsFold applies the ContainsFold predicate on the "resource_id" field.
+func ResourceIDContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldResourceID, v))
+}
+
+// EventCreatedAtEQ applies the EQ predicate on the "event_created_at" field.
+func EventCreatedAtEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEventCreatedAt, v))
+}
+
+// EventCreatedAtNEQ applies the NEQ predicate on the "event_created_at" field.
+func EventCreatedAtNEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldEventCreatedAt, v))
+}
+
+// EventCreatedAtIn applies the In predicate on the "event_created_at" field.
+func EventCreatedAtIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldEventCreatedAt, vs...))
+}
+
+// EventCreatedAtNotIn applies the NotIn predicate on the "event_created_at" field.
+func EventCreatedAtNotIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldEventCreatedAt, vs...))
+}
+
+// EventCreatedAtGT applies the GT predicate on the "event_created_at" field.
+func EventCreatedAtGT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldEventCreatedAt, v))
+}
+
+// EventCreatedAtGTE applies the GTE predicate on the "event_created_at" field.
+func EventCreatedAtGTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldEventCreatedAt, v))
+}
+
+// EventCreatedAtLT applies the LT predicate on the "event_created_at" field.
+func EventCreatedAtLT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldEventCreatedAt, v))
+}
+
+// EventCreatedAtLTE applies the LTE predicate on the "event_created_at" field.
+func EventCreatedAtLTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldEventCreatedAt, v))
+}
+
+// EventCreatedAtIsNil applies the IsNil predicate on the "event_created_at" field.
+func EventCreatedAtIsNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIsNull(FieldEventCreatedAt))
+}
+
+// EventCreatedAtNotNil applies the NotNil predicate on the "event_created_at" field.
+func EventCreatedAtNotNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotNull(FieldEventCreatedAt))
+}
+
+// ReceivedAtEQ applies the EQ predicate on the "received_at" field.
+func ReceivedAtEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldReceivedAt, v))
+}
+
+// ReceivedAtNEQ applies the NEQ predicate on the "received_at" field.
+func ReceivedAtNEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldReceivedAt, v))
+}
+
+// ReceivedAtIn applies the In predicate on the "received_at" field.
+func ReceivedAtIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldReceivedAt, vs...))
+}
+
+// ReceivedAtNotIn applies the NotIn predicate on the "received_at" field.
+func ReceivedAtNotIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldReceivedAt, vs...))
+}
+
+// ReceivedAtGT applies the GT predicate on the "received_at" field.
+func ReceivedAtGT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldReceivedAt, v))
+}
+
+// ReceivedAtGTE applies the GTE predicate on the "received_at" field.
+func ReceivedAtGTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldReceivedAt, v))
+}
+
+// ReceivedAtLT applies the LT predicate on the "received_at" field.
+func ReceivedAtLT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldReceivedAt, v))
+}
+
+// ReceivedAtLTE applies the LTE predicate on the "received_at" field.
+func ReceivedAtLTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldReceivedAt, v))
+}
+
+// ProcessedAtEQ applies the EQ predicate on the "processed_at" field.
+func ProcessedAtEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtNEQ applies the NEQ predicate on the "processed_at" field.
+func ProcessedAtNEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtIn applies the In predicate on the "processed_at" field.
+func ProcessedAtIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtNotIn applies the NotIn predicate on the "processed_at" field.
+func ProcessedAtNotIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtGT applies the GT predicate on the "processed_at" field.
+func ProcessedAtGT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldProcessedAt, v))
+}
+
+// ProcessedAtGTE applies the GTE predicate on the "processed_at" field.
+func ProcessedAtGTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldProcessedAt, v))
+}
+
+// ProcessedAtLT applies the LT predicate on the "processed_at" field.
+func ProcessedAtLT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldProcessedAt, v))
+}
+
+// ProcessedAtLTE applies the LTE predicate on the "processed_at" field.
+func ProcessedAtLTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldProcessedAt, v))
+}
+
+// ProcessedAtIsNil applies the IsNil predicate on the "processed_at" field.
+func ProcessedAtIsNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIsNull(FieldProcessedAt))
+}
+
+// ProcessedAtNotNil applies the NotNil predicate on the "processed_at" field.
+func ProcessedAtNotNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotNull(FieldProcessedAt))
+}
+
+// OutcomeEQ applies the EQ predicate on the "outcome" field.
+func OutcomeEQ(v Outcome) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldOutcome, v))
+}
+
+// OutcomeNEQ applies the NEQ predicate on the "outcome" field.
+func OutcomeNEQ(v Outcome) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldOutcome, v))
+}
+
+// OutcomeIn applies the In predicate on the "outcome" field.
+func OutcomeIn(vs ...Outcome) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldOutcome, vs...))
+}
+
+// OutcomeNotIn applies the NotIn predicate on the "outcome" field.
+func OutcomeNotIn(vs ...Outcome) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldOutcome, vs...))
+}
+
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorIsNil applies the IsNil predicate on the "error" field.
+func ErrorIsNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIsNull(FieldError))
+}
+
+// ErrorNotNil applies the NotNil predicate on the "error" field.
+func ErrorNotNil() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotNull(FieldError))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldError, v))
+}
+
+// AttemptsEQ applies the EQ predicate on the "attempts" field.
+func AttemptsEQ(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldAttempts, v))
+}
+
+// AttemptsNEQ applies the NEQ predicate on the "attempts" field.
+func AttemptsNEQ(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldAttempts, v))
+}
+
+// AttemptsIn applies the In predicate on the "attempts" field.
+func AttemptsIn(vs ...int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldAttempts, vs...))
+}
+
+// AttemptsNotIn applies the NotIn predicate on the "attempts" field.
+func AttemptsNotIn(vs ...int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldAttempts, vs...))
+}
+
+// AttemptsGT applies the GT predicate on the "attempts" field.
+func AttemptsGT(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldAttempts, v))
+}
+
+// AttemptsGTE applies the GTE predicate on the "attempts" field.
+func AttemptsGTE(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldAttempts, v))
+}
+
+// AttemptsLT applies the LT predicate on the "attempts" field.
+func AttemptsLT(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldAttempts, v))
+}
+
+// AttemptsLTE applies the LTE predicate on the "attempts" field.
+func AttemptsLTE(v int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldAttempts, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ProcessedEvent) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ProcessedEvent) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ProcessedEvent) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.NotPredicates(p))
+}
@@ -0,0 +1,898 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/processedevent"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ProcessedEventCreate is the builder for creating a ProcessedEvent entity.
+type ProcessedEventCreate struct {
+	config
+	mutation *ProcessedEventMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetProvider sets the "provider" field.
+func (pec *ProcessedEventCreate) SetProvider(s string) *ProcessedEventCreate {
+	pec.mutation.SetProvider(s)
+	return pec
+}
+
+// SetEventID sets the "event_id" field.
+func (pec *ProcessedEventCreate) SetEventID(s string) *ProcessedEventCreate {
+	pec.mutation.SetEventID(s)
+	return pec
+}
+
+// SetEventType sets the "event_type" field.
+func (pec *ProcessedEventCreate) SetEventType(s string) *ProcessedEventCreate {
+	pec.mutation.SetEventType(s)
+	return pec
+}
+
+// SetNillableEventType sets the "event_type" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableEventType(s *string) *ProcessedEventCreate {
+	if s != nil {
+		pec.SetEventType(*s)
+	}
+	return pec
+}
+
+// SetResourceID sets the "resource_id" field.
+func (pec *ProcessedEventCreate) SetResourceID(s string) *ProcessedEventCreate {
+	pec.mutation.SetResourceID(s)
+	return pec
+}
+
+// SetNillableResourceID sets the "resource_id" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableResourceID(s *string) *ProcessedEventCreate {
+	if s != nil {
+		pec.SetResourceID(*s)
+	}
+	return pec
+}
+
+// SetEventCreatedAt sets the "event_created_at" field.
+func (pec *ProcessedEventCreate) SetEventCreatedAt(t time.Time) *ProcessedEventCreate {
+	pec.mutation.SetEventCreatedAt(t)
+	return pec
+}
+
+// SetNillableEventCreatedAt sets the "event_created_at" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableEventCreatedAt(t *time.Time) *ProcessedEventCreate {
+	if t != nil {
+		pec.SetEventCreatedAt(*t)
+	}
+	return pec
+}
+
+// SetReceivedAt sets the "received_at" field.
+func (pec *ProcessedEventCreate) SetReceivedAt(t time.Time) *ProcessedEventCreate {
+	pec.mutation.SetReceivedAt(t)
+	return pec
+}
+
+// SetNillableReceivedAt sets the "received_at" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableReceivedAt(t *time.Time) *ProcessedEventCreate {
+	if t != nil {
+		pec.SetReceivedAt(*t)
+	}
+	return pec
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (pec *ProcessedEventCreate) SetProcessedAt(t time.Time) *ProcessedEventCreate {
+	pec.mutation.SetProcessedAt(t)
+	return pec
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableProcessedAt(t *time.Time) *ProcessedEventCreate {
+	if t != nil {
+		pec.SetProcessedAt(*t)
+	}
+	return pec
+}
+
+// SetOutcome sets the "outcome" field.
+func (pec *ProcessedEventCreate) SetOutcome(pr processedevent.Outcome) *ProcessedEventCreate {
+	pec.mutation.SetOutcome(pr)
+	return pec
+}
+
+// SetError sets the "error" field.
+func (pec *ProcessedEventCreate) SetError(s string) *ProcessedEventCreate {
+	pec.mutation.SetError(s)
+	return pec
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableError(s *string) *ProcessedEventCreate {
+	if s != nil {
+		pec.SetError(*s)
+	}
+	return pec
+}
+
+// SetAttempts sets the "attempts" field.
+func (pec *ProcessedEventCreate) SetAttempts(i int) *ProcessedEventCreate {
+	pec.mutation.SetAttempts(i)
+	return pec
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableAttempts(i *int) *ProcessedEventCreate {
+	if i != nil {
+		pec.SetAttempts(*i)
+	}
+	return pec
+}
+
+// Mutation returns the ProcessedEventMutation object of the builder.
+func (pec *ProcessedEventCreate) Mutation() *ProcessedEventMutation {
+	return pec.mutation
+}
+
+// Save creates the ProcessedEvent in the database.
+func (pec *ProcessedEventCreate) Save(ctx context.Context) (*ProcessedEvent, error) {
+	pec.defaults()
+	return withHooks(ctx, pec.sqlSave, pec.mutation, pec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pec *ProcessedEventCreate) SaveX(ctx context.Context) *ProcessedEvent {
+	v, err := pec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pec *ProcessedEventCreate) Exec(ctx context.Context) error {
+	_, err := pec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pec *ProcessedEventCreate) ExecX(ctx context.Context) {
+	if err := pec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pec *ProcessedEventCreate) defaults() {
+	if _, ok := pec.mutation.ReceivedAt(); !ok {
+		v := processedevent.DefaultReceivedAt()
+		pec.mutation.SetReceivedAt(v)
+	}
+	if _, ok := pec.mutation.Attempts(); !ok {
+		v := processedevent.DefaultAttempts
+		pec.mutation.SetAttempts(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pec *ProcessedEventCreate) check() error {
+	if _, ok := pec.mutation.Provider(); !ok {
+		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "ProcessedEvent.provider"`)}
+	}
+	if v, ok := pec.mutation.Provider(); ok {
+		if err := processedevent.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.provider": %w`, err)}
+		}
+	}
+	if _, ok := pec.mutation.EventID(); !ok {
+		return &ValidationError{Name: "event_id", err: errors.New(`ent: missing required field "ProcessedEvent.event_id"`)}
+	}
+	if v, ok := pec.mutation.EventID(); ok {
+		if err := processedevent.EventIDValidator(v); err != nil {
+			return &ValidationError{Name: "event_id", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.event_id": %w`, err)}
+		}
+	}
+	if _, ok := pec.mutation.ReceivedAt(); !ok {
+		return &ValidationError{Name: "received_at", err: errors.New(`ent: missing required field "ProcessedEvent.received_at"`)}
+	}
+	if _, ok := pec.mutation.Outcome(); !ok {
+		return &ValidationError{Name: "outcome", err: errors.New(`ent: missing required field "ProcessedEvent.outcome"`)}
+	}
+	if v, ok := pec.mutation.Outcome(); ok {
+		if err := processedevent.OutcomeValidator(v); err != nil {
+			return &ValidationError{Name: "outcome", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.outcome": %w`, err)}
+		}
+	}
+	if _, ok := pec.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "ProcessedEvent.attempts"`)}
+	}
+	return nil
+}
+
+func (pec *ProcessedEventCreate) sqlSave(ctx context.Context) (*ProcessedEvent, error) {
+	if err := pec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	pec.mutation.id = &_node.ID
+	pec.mutation.done = true
+	return _node, nil
+}
+
+func (pec *ProcessedEventCreate) createSpec() (*ProcessedEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ProcessedEvent{config: pec.config}
+		_spec = sqlgraph.NewCreateSpec(processedevent.Table, sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = pec.conflict
+	if value, ok := pec.mutation.Provider(); ok {
+		_spec.SetField(processedevent.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := pec.mutation.EventID(); ok {
+		_spec.SetField(processedevent.FieldEventID, field.TypeString, value)
+		_node.EventID = value
+	}
+	if value, ok := pec.mutation.EventType(); ok {
+		_spec.SetField(processedevent.FieldEventType, field.TypeString, value)
+		_node.EventType = value
+	}
+	if value, ok := pec.mutation.ResourceID(); ok {
+		_spec.SetField(processedevent.FieldResourceID, field.TypeString, value)
+		_node.ResourceID = value
+	}
+	if value, ok := pec.mutation.EventCreatedAt(); ok {
+		_spec.SetField(processedevent.FieldEventCreatedAt, field.TypeTime, value)
+		_node.EventCreatedAt = &value
+	}
+	if value, ok := pec.mutation.ReceivedAt(); ok {
+		_spec.SetField(processedevent.FieldReceivedAt, field.TypeTime, value)
+		_node.ReceivedAt = value
+	}
+	if value, ok := pec.mutation.ProcessedAt(); ok {
+		_spec.SetField(processedevent.FieldProcessedAt, field.TypeTime, value)
+		_node.ProcessedAt = &value
+	}
+	if value, ok := pec.mutation.Outcome(); ok {
+		_spec.SetField(processedevent.FieldOutcome, field.TypeEnum, value)
+		_node.Outcome = value
+	}
+	if value, ok := pec.mutation.Error(); ok {
+		_spec.SetField(processedevent.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := pec.mutation.Attempts(); ok {
+		_spec.SetField(processedevent.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ProcessedEvent.Create().
+//		SetProvider(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ProcessedEventUpsert) {
+//			SetProvider(v+v).
+//		}).
+//		Exec(ctx)
+func (pec *ProcessedEventCreate) OnConflict(opts ...sql.ConflictOption) *ProcessedEventUpsertOne {
+	pec.conflict = opts
+	return &ProcessedEventUpsertOne{
+		create: pec,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ProcessedEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (pec *ProcessedEventCreate) OnConflictColumns(columns ...string) *ProcessedEventUpsertOne {
+	pec.conflict = append(pec.conflict, sql.ConflictColumns(columns...))
+	return &ProcessedEventUpsertOne{
+		create: pec,
+	}
+}
+
+type (
+	// ProcessedEventUpsertOne is the builder for "upsert"-ing
+	//  one ProcessedEvent node.
+	ProcessedEventUpsertOne struct {
+		create *ProcessedEventCreate
+	}
+
+	// ProcessedEventUpsert is the "OnConflict" setter.
+	ProcessedEventUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetReceivedAt sets the "received_at" field.
+func (u *ProcessedEventUpsert) SetReceivedAt(v time.Time) *ProcessedEventUpsert {
+	u.Set(processedevent.FieldReceivedAt, v)
+	return u
+}
+
+// UpdateReceivedAt sets the "received_at" field to the value that was provided on create.
+func (u *ProcessedEventUpsert) UpdateReceivedAt() *ProcessedEventUpsert {
+	u.SetExcluded(processedevent.FieldReceivedAt)
+	return u
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (u *ProcessedEventUpsert) SetProcessedAt(v time.Time) *ProcessedEventUpsert {
+	u.Set(processedevent.FieldProcessedAt, v)
+	return u
+}
+
+// UpdateProcessedAt sets the "processed_at" field to the value that was provided on create.
+func (u *ProcessedEventUpsert) UpdateProcessedAt() *ProcessedEventUpsert {
+	u.SetExcluded(processedevent.FieldProcessedAt)
+	return u
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (u *ProcessedEventUpsert) ClearProcessedAt() *ProcessedEventUpsert {
+	u.SetNull(processedevent.FieldProcessedAt)
+	return u
+}
+
+// SetOutcome sets the "outcome" field.
+func (u *ProcessedEventUpsert) SetOutcome(v processedevent.Outcome) *ProcessedEventUpsert {
+	u.Set(processedevent.FieldOutcome, v)
+	return u
+}
+
+// UpdateOutcome sets the "outcome" field to the value that was provided on create.
+func (u *ProcessedEventUpsert) UpdateOutcome() *ProcessedEventUpsert {
+	u.SetExcluded(processedevent.FieldOutcome)
+	return u
+}
+
+// SetError sets the "error" field.
+func (u *ProcessedEventUpsert) SetError(v string) *ProcessedEventUpsert {
+	u.Set(processedevent.FieldError, v)
+	return u
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *ProcessedEventUpsert) UpdateError() *ProcessedEventUpsert {
+	u.SetExcluded(processedevent.FieldError)
+	return u
+}
+
+// ClearError clears the value of the "error" field.
+func (u *ProcessedEventUpsert) ClearError() *ProcessedEventUpsert {
+	u.SetNull(processedevent.FieldError)
+	return u
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *ProcessedEventUpsert) SetAttempts(v int) *ProcessedEventUpsert {
+	u.Set(processedevent.FieldAttempts, v)
+	return u
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *ProcessedEventUpsert) UpdateAttempts() *ProcessedEventUpsert {
+	u.SetExcluded(processedevent.FieldAttempts)
+	return u
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *ProcessedEventUpsert) AddAttempts(v int) *ProcessedEventUpsert {
+	u.Add(processedevent.FieldAttempts, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.ProcessedEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *ProcessedEventUpsertOne) UpdateNewValues() *ProcessedEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.Provider(); exists {
+			s.SetIgnore(processedevent.FieldProvider)
+		}
+		if _, exists := u.create.mutation.EventID(); exists {
+			s.SetIgnore(processedevent.FieldEventID)
+		}
+		if _, exists := u.create.mutation.EventType(); exists {
+			s.SetIgnore(processedevent.FieldEventType)
+		}
+		if _, exists := u.create.mutation.ResourceID(); exists {
+			s.SetIgnore(processedevent.FieldResourceID)
+		}
+		if _, exists := u.create.mutation.EventCreatedAt(); exists {
+			s.SetIgnore(processedevent.FieldEventCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ProcessedEvent.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *ProcessedEventUpsertOne) Ignore() *ProcessedEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ProcessedEventUpsertOne) DoNothing() *ProcessedEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ProcessedEventCreate.OnConflict
+// documentation for more info.
+func (u *ProcessedEventUpsertOne) Update(set func(*ProcessedEventUpsert)) *ProcessedEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ProcessedEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetReceivedAt sets the "received_at" field.
+func (u *ProcessedEventUpsertOne) SetReceivedAt(v time.Time) *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetReceivedAt(v)
+	})
+}
+
+// UpdateReceivedAt sets the "received_at" field to the value that was provided on create.
+func (u *ProcessedEventUpsertOne) UpdateReceivedAt() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateReceivedAt()
+	})
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (u *ProcessedEventUpsertOne) SetProcessedAt(v time.Time) *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetProcessedAt(v)
+	})
+}
+
+// UpdateProcessedAt sets the "processed_at" field to the value that was provided on create.
+func (u *ProcessedEventUpsertOne) UpdateProcessedAt() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateProcessedAt()
+	})
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (u *ProcessedEventUpsertOne) ClearProcessedAt() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.ClearProcessedAt()
+	})
+}
+
+// SetOutcome sets the "outcome" field.
+func (u *ProcessedEventUpsertOne) SetOutcome(v processedevent.Outcome) *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetOutcome(v)
+	})
+}
+
+// UpdateOutcome sets the "outcome" field to the value that was provided on create.
+func (u *ProcessedEventUpsertOne) UpdateOutcome() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateOutcome()
+	})
+}
+
+// SetError sets the "error" field.
+func (u *ProcessedEventUpsertOne) SetError(v string) *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetError(v)
+	})
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *ProcessedEventUpsertOne) UpdateError() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateError()
+	})
+}
+
+// ClearError clears the value of the "error" field.
+func (u *ProcessedEventUpsertOne) ClearError() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.ClearError()
+	})
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *ProcessedEventUpsertOne) SetAttempts(v int) *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetAttempts(v)
+	})
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *ProcessedEventUpsertOne) AddAttempts(v int) *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.AddAttempts(v)
+	})
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *ProcessedEventUpsertOne) UpdateAttempts() *ProcessedEventUpsertOne {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateAttempts()
+	})
+}
+
+// Exec executes the query.
+func (u *ProcessedEventUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ProcessedEventCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ProcessedEventUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *ProcessedEventUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *ProcessedEventUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ProcessedEventCreateBulk is the builder for creating many ProcessedEvent entities in bulk.
+type ProcessedEventCreateBulk struct {
+	config
+	err      error
+	builders []*ProcessedEventCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the ProcessedEvent entities in the database.
+func (pecb *ProcessedEventCreateBulk) Save(ctx context.Context) ([]*ProcessedEvent, error) {
+	if pecb.err != nil {
+		return nil, pecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pecb.builders))
+	nodes := make([]*ProcessedEvent, len(pecb.builders))
+	mutators := make([]Mutator, len(pecb.builders))
+	for i := range pecb.builders {
+		func(i int, root context.Context) {
+			builder := pecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ProcessedEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = pecb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pecb *ProcessedEventCreateBulk) SaveX(ctx context.Context) []*ProcessedEvent {
+	v, err := pecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pecb *ProcessedEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := pecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pecb *ProcessedEventCreateBulk) ExecX(ctx context.Context) {
+	if err := pecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ProcessedEvent.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ProcessedEventUpsert) {
+//			SetProvider(v+v).
+//		}).
+//		Exec(ctx)
+func (pecb *ProcessedEventCreateBulk) OnConflict(opts ...sql.ConflictOption) *ProcessedEventUpsertBulk {
+	pecb.conflict = opts
+	return &ProcessedEventUpsertBulk{
+		create: pecb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ProcessedEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (pecb *ProcessedEventCreateBulk) OnConflictColumns(columns ...string) *ProcessedEventUpsertBulk {
+	pecb.conflict = append(pecb.conflict, sql.ConflictColumns(columns...))
+	return &ProcessedEventUpsertBulk{
+		create: pecb,
+	}
+}
+
+// ProcessedEventUpsertBulk is the builder for "upsert"-ing
+// a bulk of ProcessedEvent nodes.
+type ProcessedEventUpsertBulk struct {
+	create *ProcessedEventCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.ProcessedEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *ProcessedEventUpsertBulk) UpdateNewValues() *ProcessedEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.Provider(); exists {
+				s.SetIgnore(processedevent.FieldProvider)
+			}
+			if _, exists := b.mutation.EventID(); exists {
+				s.SetIgnore(processedevent.FieldEventID)
+			}
+			if _, exists := b.mutation.EventType(); exists {
+				s.SetIgnore(processedevent.FieldEventType)
+			}
+			if _, exists := b.mutation.ResourceID(); exists {
+				s.SetIgnore(processedevent.FieldResourceID)
+			}
+			if _, exists := b.mutation.EventCreatedAt(); exists {
+				s.SetIgnore(processedevent.FieldEventCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ProcessedEvent.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *ProcessedEventUpsertBulk) Ignore() *ProcessedEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ProcessedEventUpsertBulk) DoNothing() *ProcessedEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ProcessedEventCreateBulk.OnConflict
+// documentation for more info.
+func (u *ProcessedEventUpsertBulk) Update(set func(*ProcessedEventUpsert)) *ProcessedEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ProcessedEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetReceivedAt sets the "received_at" field.
+func (u *ProcessedEventUpsertBulk) SetReceivedAt(v time.Time) *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetReceivedAt(v)
+	})
+}
+
+// UpdateReceivedAt sets the "received_at" field to the value that was provided on create.
+func (u *ProcessedEventUpsertBulk) UpdateReceivedAt() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateReceivedAt()
+	})
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (u *ProcessedEventUpsertBulk) SetProcessedAt(v time.Time) *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetProcessedAt(v)
+	})
+}
+
+// UpdateProcessedAt sets the "processed_at" field to the value that was provided on create.
+func (u *ProcessedEventUpsertBulk) UpdateProcessedAt() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateProcessedAt()
+	})
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (u *ProcessedEventUpsertBulk) ClearProcessedAt() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.ClearProcessedAt()
+	})
+}
+
+// SetOutcome sets the "outcome" field.
+func (u *ProcessedEventUpsertBulk) SetOutcome(v processedevent.Outcome) *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetOutcome(v)
+	})
+}
+
+// UpdateOutcome sets the "outcome" field to the value that was provided on create.
+func (u *ProcessedEventUpsertBulk) UpdateOutcome() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateOutcome()
+	})
+}
+
+// SetError sets the "error" field.
+func (u *ProcessedEventUpsertBulk) SetError(v string) *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetError(v)
+	})
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *ProcessedEventUpsertBulk) UpdateError() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateError()
+	})
+}
+
+// ClearError clears the value of the "error" field.
+func (u *ProcessedEventUpsertBulk) ClearError() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.ClearError()
+	})
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *ProcessedEventUpsertBulk) SetAttempts(v int) *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.SetAttempts(v)
+	})
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *ProcessedEventUpsertBulk) AddAttempts(v int) *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.AddAttempts(v)
+	})
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *ProcessedEventUpsertBulk) UpdateAttempts() *ProcessedEventUpsertBulk {
+	return u.Update(func(s *ProcessedEventUpsert) {
+		s.UpdateAttempts()
+	})
+}
+
+// Exec executes the query.
+func (u *ProcessedEventUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the ProcessedEventCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ProcessedEventCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ProcessedEventUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,446 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/predicate"
+	"db-service/ent/processedevent"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ProcessedEventUpdate is the builder for updating ProcessedEvent entities.
+type ProcessedEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProcessedEventMutation
+}
+
+// Where appends a list predicates to the ProcessedEventUpdate builder.
+func (peu *ProcessedEventUpdate) Where(ps ...predicate.ProcessedEvent) *ProcessedEventUpdate {
+	peu.mutation.Where(ps...)
+	return peu
+}
+
+// SetReceivedAt sets the "received_at" field.
+func (peu *ProcessedEventUpdate) SetReceivedAt(t time.Time) *ProcessedEventUpdate {
+	peu.mutation.SetReceivedAt(t)
+	return peu
+}
+
+// SetNillableReceivedAt sets the "received_at" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableReceivedAt(t *time.Time) *ProcessedEventUpdate {
+	if t != nil {
+		peu.SetReceivedAt(*t)
+	}
+	return peu
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (peu *ProcessedEventUpdate) SetProcessedAt(t time.Time) *ProcessedEventUpdate {
+	peu.mutation.SetProcessedAt(t)
+	return peu
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableProcessedAt(t *time.Time) *ProcessedEventUpdate {
+	if t != nil {
+		peu.SetProcessedAt(*t)
+	}
+	return peu
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (peu *ProcessedEventUpdate) ClearProcessedAt() *ProcessedEventUpdate {
+	peu.mutation.ClearProcessedAt()
+	return peu
+}
+
+// SetOutcome sets the "outcome" field.
+func (peu *ProcessedEventUpdate) SetOutcome(pr processedevent.Outcome) *ProcessedEventUpdate {
+	peu.mutation.SetOutcome(pr)
+	return peu
+}
+
+// SetNillableOutcome sets the "outcome" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableOutcome(pr *processedevent.Outcome) *ProcessedEventUpdate {
+	if pr != nil {
+		peu.SetOutcome(*pr)
+	}
+	return peu
+}
+
+// SetError sets the "error" field.
+func (peu *ProcessedEventUpdate) SetError(s string) *ProcessedEventUpdate {
+	peu.mutation.SetError(s)
+	return peu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableError(s *string) *ProcessedEventUpdate {
+	if s != nil {
+		peu.SetError(*s)
+	}
+	return peu
+}
+
+// ClearError clears the value of the "error" field.
+func (peu *ProcessedEventUpdate) ClearError() *ProcessedEventUpdate {
+	peu.mutation.ClearError()
+	return peu
+}
+
+// SetAttempts sets the "attempts" field.
+func (peu *ProcessedEventUpdate) SetAttempts(i int) *ProcessedEventUpdate {
+	peu.mutation.ResetAttempts()
+	peu.mutation.SetAttempts(i)
+	return peu
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableAttempts(i *int) *ProcessedEventUpdate {
+	if i != nil {
+		peu.SetAttempts(*i)
+	}
+	return peu
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (peu *ProcessedEventUpdate) AddAttempts(i int) *ProcessedEventUpdate {
+	peu.mutation.AddAttempts(i)
+	return peu
+}
+
+// Mutation returns the ProcessedEventMutation object of the builder.
+func (peu *ProcessedEventUpdate) Mutation() *ProcessedEventMutation {
+	return peu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (peu *ProcessedEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, peu.sqlSave, peu.mutation, peu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (peu *ProcessedEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := peu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (peu *ProcessedEventUpdate) Exec(ctx context.Context) error {
+	_, err := peu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (peu *ProcessedEventUpdate) ExecX(ctx context.Context) {
+	if err := peu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (peu *ProcessedEventUpdate) check() error {
+	if v, ok := peu.mutation.Outcome(); ok {
+		if err := processedevent.OutcomeValidator(v); err != nil {
+			return &ValidationError{Name: "outcome", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.outcome": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (peu *ProcessedEventUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := peu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(processedevent.Table, processedevent.Columns, sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt))
+	if ps := peu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if peu.mutation.EventTypeCleared() {
+		_spec.ClearField(processedevent.FieldEventType, field.TypeString)
+	}
+	if peu.mutation.ResourceIDCleared() {
+		_spec.ClearField(processedevent.FieldResourceID, field.TypeString)
+	}
+	if peu.mutation.EventCreatedAtCleared() {
+		_spec.ClearField(processedevent.FieldEventCreatedAt, field.TypeTime)
+	}
+	if value, ok := peu.mutation.ReceivedAt(); ok {
+		_spec.SetField(processedevent.FieldReceivedAt, field.TypeTime, value)
+	}
+	if value, ok := peu.mutation.ProcessedAt(); ok {
+		_spec.SetField(processedevent.FieldProcessedAt, field.TypeTime, value)
+	}
+	if peu.mutation.ProcessedAtCleared() {
+		_spec.ClearField(processedevent.FieldProcessedAt, field.TypeTime)
+	}
+	if value, ok := peu.mutation.Outcome(); ok {
+		_spec.SetField(processedevent.FieldOutcome, field.TypeEnum, value)
+	}
+	if value, ok := peu.mutation.Error(); ok {
+		_spec.SetField(processedevent.FieldError, field.TypeString, value)
+	}
+	if peu.mutation.ErrorCleared() {
+		_spec.ClearField(processedevent.FieldError, field.TypeString)
+	}
+	if value, ok := peu.mutation.Attempts(); ok {
+		_spec.SetField(processedevent.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := peu.mutation.AddedAttempts(); ok {
+		_spec.AddField(processedevent.FieldAttempts, field.TypeInt, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, peu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{processedevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	peu.mutation.done = true
+	return n, nil
+}
+
+// ProcessedEventUpdateOne is the builder for updating a single ProcessedEvent entity.
+type ProcessedEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProcessedEventMutation
+}
+
+// SetReceivedAt sets the "received_at" field.
+func (peuo *ProcessedEventUpdateOne) SetReceivedAt(t time.Time) *ProcessedEventUpdateOne {
+	peuo.mutation.SetReceivedAt(t)
+	return peuo
+}
+
+// SetNillableReceivedAt sets the "received_at" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableReceivedAt(t *time.Time) *ProcessedEventUpdateOne {
+	if t != nil {
+		peuo.SetReceivedAt(*t)
+	}
+	return peuo
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (peuo *ProcessedEventUpdateOne) SetProcessedAt(t time.Time) *ProcessedEventUpdateOne {
+	peuo.mutation.SetProcessedAt(t)
+	return peuo
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableProcessedAt(t *time.Time) *ProcessedEventUpdateOne {
+	if t != nil {
+		peuo.SetProcessedAt(*t)
+	}
+	return peuo
+}
+
+// ClearProcessedAt clears the value of the "processed_at" field.
+func (peuo *ProcessedEventUpdateOne) ClearProcessedAt() *ProcessedEventUpdateOne {
+	peuo.mutation.ClearProcessedAt()
+	return peuo
+}
+
+// SetOutcome sets the "outcome" field.
+func (peuo *ProcessedEventUpdateOne) SetOutcome(pr processedevent.Outcome) *ProcessedEventUpdateOne {
+	peuo.mutation.SetOutcome(pr)
+	return peuo
+}
+
+// SetNillableOutcome sets the "outcome" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableOutcome(pr *processedevent.Outcome) *ProcessedEventUpdateOne {
+	if pr != nil {
+		peuo.SetOutcome(*pr)
+	}
+	return peuo
+}
+
+// SetError sets the "error" field.
+func (peuo *ProcessedEventUpdateOne) SetError(s string) *ProcessedEventUpdateOne {
+	peuo.mutation.SetError(s)
+	return peuo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableError(s *string) *ProcessedEventUpdateOne {
+	if s != nil {
+		peuo.SetError(*s)
+	}
+	return peuo
+}
+
+// ClearError clears the value of the "error" field.
+func (peuo *ProcessedEventUpdateOne) ClearError() *ProcessedEventUpdateOne {
+	peuo.mutation.ClearError()
+	return peuo
+}
+
+// SetAttempts sets the "attempts" field.
+func (peuo *ProcessedEventUpdateOne) SetAttempts(i int) *ProcessedEventUpdateOne {
+	peuo.mutation.ResetAttempts()
+	peuo.mutation.SetAttempts(i)
+	return peuo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableAttempts(i *int) *ProcessedEventUpdateOne {
+	if i != nil {
+		peuo.SetAttempts(*i)
+	}
+	return peuo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (peuo *ProcessedEventUpdateOne) AddAttempts(i int) *ProcessedEventUpdateOne {
+	peuo.mutation.AddAttempts(i)
+	return peuo
+}
+
+// Mutation returns the ProcessedEventMutation object of the builder.
+func (peuo *ProcessedEventUpdateOne) Mutation() *ProcessedEventMutation {
+	return peuo.mutation
+}
+
+// Where appends a list predicates to the ProcessedEventUpdate builder.
+func (peuo *ProcessedEventUpdateOne) Where(ps ...predicate.ProcessedEvent) *ProcessedEventUpdateOne {
+	peuo.mutation.Where(ps...)
+	return peuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (peuo *ProcessedEventUpdateOne) Select(field string, fields ...string) *ProcessedEventUpdateOne {
+	peuo.fields = append([]string{field}, fields...)
+	return peuo
+}
+
+// Save executes the query and returns the updated ProcessedEvent entity.
+func (peuo *ProcessedEventUpdateOne) Save(ctx context.Context) (*ProcessedEvent, error) {
+	return withHooks(ctx, peuo.sqlSave, peuo.mutation, peuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (peuo *ProcessedEventUpdateOne) SaveX(ctx context.Context) *ProcessedEvent {
+	node, err := peuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (peuo *ProcessedEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := peuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (peuo *ProcessedEventUpdateOne) ExecX(ctx context.Context) {
+	if err := peuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (peuo *ProcessedEventUpdateOne) check() error {
+	if v, ok := peuo.mutation.Outcome(); ok {
+		if err := processedevent.OutcomeValidator(v); err != nil {
+			return &ValidationError{Name: "outcome", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.outcome": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (peuo *ProcessedEventUpdateOne) sqlSave(ctx context.Context) (_node *ProcessedEvent, err error) {
+	if err := peuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(processedevent.Table, processedevent.Columns, sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt))
+	id, ok := peuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ProcessedEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := peuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, processedevent.FieldID)
+		for _, f := range fields {
+			if !processedevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != processedevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := peuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if peuo.mutation.EventTypeCleared() {
+		_spec.ClearField(processedevent.FieldEventType, field.TypeString)
+	}
+	if peuo.mutation.ResourceIDCleared() {
+		_spec.ClearField(processedevent.FieldResourceID, field.TypeString)
+	}
+	if peuo.mutation.EventCreatedAtCleared() {
+		_spec.ClearField(processedevent.FieldEventCreatedAt, field.TypeTime)
+	}
+	if value, ok := peuo.mutation.ReceivedAt(); ok {
+		_spec.SetField(processedevent.FieldReceivedAt, field.TypeTime, value)
+	}
+	if value, ok := peuo.mutation.ProcessedAt(); ok {
+		_spec.SetField(processedevent.FieldProcessedAt, field.TypeTime, value)
+	}
+	if peuo.mutation.ProcessedAtCleared() {
+		_spec.ClearField(processedevent.FieldProcessedAt, field.TypeTime)
+	}
+	if value, ok := peuo.mutation.Outcome(); ok {
+		_spec.SetField(processedevent.FieldOutcome, field.TypeEnum, value)
+	}
+	if value, ok := peuo.mutation.Error(); ok {
+		_spec.SetField(processedevent.FieldError, field.TypeString, value)
+	}
+	if peuo.mutation.ErrorCleared() {
+		_spec.ClearField(processedevent.FieldError, field.TypeString)
+	}
+	if value, ok := peuo.mutation.Attempts(); ok {
+		_spec.SetField(processedevent.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := peuo.mutation.AddedAttempts(); ok {
+		_spec.AddField(processedevent.FieldAttempts, field.TypeInt, value)
+	}
+	_node = &ProcessedEvent{config: peuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, peuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{processedevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	peuo.mutation.done = true
+	return _node, nil
+}
@@ -3,16 +3,74 @@
 package ent
 
 import (
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"db-service/ent/processedevent"
 	"db-service/ent/schema"
 	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
 	"db-service/ent/user"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	entitlementFields := schema.Entitlement{}.Fields()
+	_ = entitlementFields
+	// entitlementDescKey is the schema descriptor for key field.
+	entitlementDescKey := entitlementFields[0].Descriptor()
+	// entitlement.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	entitlement.KeyValidator = entitlementDescKey.Validators[0].(func(string) error)
+	// entitlementDescEnabled is the schema descriptor for enabled field.
+	entitlementDescEnabled := entitlementFields[1].Descriptor()
+	// entitlement.DefaultEnabled holds the default value on creation for the enabled field.
+	entitlement.DefaultEnabled = entitlementDescEnabled.Default.(bool)
+	// entitlementDescLimit is the schema descriptor for limit field.
+	entitlementDescLimit := entitlementFields[2].Descriptor()
+	// entitlement.LimitValidator is a validator for the "limit" field. It is called by the builders before save.
+	entitlement.LimitValidator = entitlementDescLimit.Validators[0].(func(int64) error)
+	planFields := schema.Plan{}.Fields()
+	_ = planFields
+	// planDescKey is the schema descriptor for key field.
+	planDescKey := planFields[0].Descriptor()
+	// plan.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	plan.KeyValidator = planDescKey.Validators[0].(func(string) error)
+	// planDescRank is the schema descriptor for rank field.
+	planDescRank := planFields[3].Descriptor()
+	// plan.DefaultRank holds the default value on creation for the rank field.
+	plan.DefaultRank = planDescRank.Default.(int)
+	// planDescCreatedAt is the schema descriptor for created_at field.
+	planDescCreatedAt := planFields[4].Descriptor()
+	// plan.DefaultCreatedAt holds the default value on creation for the created_at field.
+	plan.DefaultCreatedAt = planDescCreatedAt.Default.(func() time.Time)
+	// planDescUpdatedAt is the schema descriptor for updated_at field.
+	planDescUpdatedAt := planFields[5].Descriptor()
+	// plan.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	plan.DefaultUpdatedAt = planDescUpdatedAt.Default.(func() time.Time)
+	// plan.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	plan.UpdateDefaultUpdatedAt = planDescUpdatedAt.UpdateDefault.(func() time.Time)
+	processedeventFields := schema.ProcessedEvent{}.Fields()
+	_ = processedeventFields
+	// processedeventDescProvider is the schema descriptor for provider field.
+	processedeventDescProvider := processedeventFields[0].Descriptor()
+	// processedevent.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	processedevent.ProviderValidator = processedeventDescProvider.Validators[0].(func(string) error)
+	// processedeventDescEventID is the schema descriptor for event_id field.
+	processedeventDescEventID := processedeventFields[1].Descriptor()
+	// processedevent.EventIDValidator is a validator for the "event_id" field. It is called by the builders before save.
+	processedevent.EventIDValidator = processedeventDescEventID.Validators[0].(func(string) error)
+	// processedeventDescReceivedAt is the schema descriptor for received_at field.
+	processedeventDescReceivedAt := processedeventFields[5].Descriptor()
+	// processedevent.DefaultReceivedAt holds the default value on creation for the received_at field.
+	processedevent.DefaultReceivedAt = processedeventDescReceivedAt.Default.(func() time.Time)
+	// processedeventDescAttempts is the schema descriptor for attempts field.
+	processedeventDescAttempts := processedeventFields[9].Descriptor()
+	// processedevent.DefaultAttempts holds the default value on creation for the attempts field.
+	processedevent.DefaultAttempts = processedeventDescAttempts.Default.(int)
 	subscriptionFields := schema.Subscription{}.Fields()
 	_ = subscriptionFields
 	// subscriptionDescStripeCustomerID is the schema descriptor for stripe_customer_id field.
@@ -23,34 +81,44 @@ func init() {
 	subscriptionDescStripeSubscriptionID := subscriptionFields[1].Descriptor()
 	// subscription.StripeSubscriptionIDValidator is a validator for the "stripe_subscription_id" field. It is called by the builders before save.
 	subscription.StripeSubscriptionIDValidator = subscriptionDescStripeSubscriptionID.Validators[0].(func(string) error)
-	// subscriptionDescStatus is the schema descriptor for status field.
-	subscriptionDescStatus := subscriptionFields[2].Descriptor()
-	// subscription.DefaultStatus holds the default value on creation for the status field.
-	subscription.DefaultStatus = subscriptionDescStatus.Default.(string)
+	// subscriptionDescTier is the schema descriptor for tier field.
+	subscriptionDescTier := subscriptionFields[3].Descriptor()
+	// subscription.TierValidator is a validator for the "tier" field. It is called by the builders before save.
+	subscription.TierValidator = subscriptionDescTier.Validators[0].(func(string) error)
+	subscriptioneventFields := schema.SubscriptionEvent{}.Fields()
+	_ = subscriptioneventFields
+	// subscriptioneventDescCreatedAt is the schema descriptor for created_at field.
+	subscriptioneventDescCreatedAt := subscriptioneventFields[4].Descriptor()
+	// subscriptionevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	subscriptionevent.DefaultCreatedAt = subscriptioneventDescCreatedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescClerkUserID is the schema descriptor for clerk_user_id field.
 	userDescClerkUserID := userFields[0].Descriptor()
 	// user.ClerkUserIDValidator is a validator for the "clerk_user_id" field. It is called by the builders before save.
 	user.ClerkUserIDValidator = userDescClerkUserID.Validators[0].(func(string) error)
+	// userDescUUID is the schema descriptor for uuid field.
+	userDescUUID := userFields[1].Descriptor()
+	// user.DefaultUUID holds the default value on creation for the uuid field.
+	user.DefaultUUID = userDescUUID.Default.(func() uuid.UUID)
 	// userDescRole is the schema descriptor for role field.
-	userDescRole := userFields[1].Descriptor()
+	userDescRole := userFields[2].Descriptor()
 	// user.DefaultRole holds the default value on creation for the role field.
 	user.DefaultRole = userDescRole.Default.(string)
 	// userDescIsSubscribed is the schema descriptor for is_subscribed field.
-	userDescIsSubscribed := userFields[2].Descriptor()
+	userDescIsSubscribed := userFields[3].Descriptor()
 	// user.DefaultIsSubscribed holds the default value on creation for the is_subscribed field.
 	user.DefaultIsSubscribed = userDescIsSubscribed.Default.(bool)
 	// userDescSubscriptionTier is the schema descriptor for subscription_tier field.
-	userDescSubscriptionTier := userFields[3].Descriptor()
+	userDescSubscriptionTier := userFields[4].Descriptor()
 	// user.DefaultSubscriptionTier holds the default value on creation for the subscription_tier field.
 	user.DefaultSubscriptionTier = userDescSubscriptionTier.Default.(string)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[4].Descriptor()
+	userDescCreatedAt := userFields[5].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[5].Descriptor()
+	userDescUpdatedAt := userFields[6].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
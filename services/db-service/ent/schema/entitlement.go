@@ -0,0 +1,52 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+)
+
+// Entitlement est un droit accordé par un plan : une fonctionnalité
+// (sync) ou une limite (max_cloud_backups, backup_retention_days...).
+// Un droit absent du plan n'est pas accordé.
+type Entitlement struct {
+    ent.Schema
+}
+
+func (Entitlement) Fields() []ent.Field {
+    return []ent.Field{
+        field.String("key").
+            NotEmpty().
+            Comment("Nom du droit, voir shared/entitlements"),
+
+        field.Bool("enabled").
+            Default(true).
+            Comment("Faux pour refuser explicitement le droit"),
+
+        field.Int64("limit").
+            Optional().
+            Nillable().
+            NonNegative().
+            Comment("Limite du droit ; NULL = illimité ou sans objet"),
+    }
+}
+
+func (Entitlement) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("plan", Plan.Type).
+            Ref("entitlements").
+            Unique().
+            Required().
+            Immutable(),
+    }
+}
+
+func (Entitlement) Indexes() []ent.Index {
+    return []ent.Index{
+        // Un droit par plan
+        index.Fields("key").
+            Edges("plan").
+            Unique(),
+    }
+}
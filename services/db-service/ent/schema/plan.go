@@ -0,0 +1,53 @@
+package schema
+
+import (
+    "time"
+
+    "entgo.io/ent"
+    "entgo.io/ent/dialect/entsql"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+)
+
+// Plan décrit une offre (free, basic, premium...) et les droits qu'elle donne.
+// Les plans sont des données : leurs limites se modifient sans redéploiement.
+type Plan struct {
+    ent.Schema
+}
+
+func (Plan) Fields() []ent.Field {
+    return []ent.Field{
+        field.String("key").
+            NotEmpty().
+            Unique().
+            Immutable().
+            Comment("Identifiant du plan, égal au tier des abonnements (subscription.tier)"),
+
+        field.String("name").
+            Optional().
+            Comment("Nom affiché"),
+
+        field.Strings("stripe_price_ids").
+            Optional().
+            Comment("Prix Stripe qui vendent ce plan (mensuel, annuel...)"),
+
+        field.Int("rank").
+            Default(0).
+            Comment("Rang du plan : quand un utilisateur a plusieurs abonnements, le tier du plan de plus haut rang l'emporte"),
+
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+
+        field.Time("updated_at").
+            Default(time.Now).
+            UpdateDefault(time.Now),
+    }
+}
+
+func (Plan) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.To("entitlements", Entitlement.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+    }
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+    "time"
+
+    "entgo.io/ent"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+)
+
+// ProcessedEvent enregistre les webhooks reçus (Stripe, Clerk, MailerLite)
+// pour ignorer les doublons et les événements arrivés dans le désordre.
+// Voir le package events.
+type ProcessedEvent struct {
+    ent.Schema
+}
+
+func (ProcessedEvent) Fields() []ent.Field {
+    return []ent.Field{
+        field.String("provider").
+            NotEmpty().
+            Immutable().
+            Comment("Émetteur du webhook : stripe, clerk, mailerlite"),
+
+        field.String("event_id").
+            NotEmpty().
+            Immutable().
+            Comment("ID de l'événement chez l'émetteur"),
+
+        field.String("event_type").
+            Optional().
+            Immutable(),
+
+        field.String("resource_id").
+            Optional().
+            Immutable().
+            Comment("Objet concerné (abonnement Stripe, utilisateur Clerk…), pour l'ordre des événements"),
+
+        field.Time("event_created_at").
+            Optional().
+            Nillable().
+            Immutable().
+            Comment("Date de création de l'événement chez l'émetteur"),
+
+        field.Time("received_at").
+            Default(time.Now).
+            Comment("Dernière réception"),
+
+        field.Time("processed_at").
+            Optional().
+            Nillable(),
+
+        field.Enum("outcome").
+            Values("processed", "stale", "failed").
+            Comment("processed : appliqué ; stale : plus ancien qu'un événement déjà appliqué ; failed : à retenter"),
+
+        field.String("error").
+            Optional().
+            Comment("Erreur du dernier essai en échec"),
+
+        field.Int("attempts").
+            Default(1),
+    }
+}
+
+func (ProcessedEvent) Indexes() []ent.Index {
+    return []ent.Index{
+        index.Fields("provider", "event_id").
+            Unique(),
+        // Dernier événement appliqué par objet
+        index.Fields("provider", "resource_id", "event_created_at"),
+    }
+}
@@ -2,8 +2,10 @@ package schema
 
 import (
     "entgo.io/ent"
+    "entgo.io/ent/dialect/entsql"
     "entgo.io/ent/schema/field"
     "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/index"
 )
 
 // Subscription relie un utilisateur à son abonnement Stripe.
@@ -13,9 +15,9 @@ type Subscription struct {
 
 func (Subscription) Fields() []ent.Field {
     return []ent.Field{
+        // Un client Stripe peut avoir plusieurs abonnements successifs
         field.String("stripe_customer_id").
             NotEmpty().
-            Unique().
             Comment("ID du client Stripe"),
 
         field.String("stripe_subscription_id").
@@ -23,9 +25,16 @@ func (Subscription) Fields() []ent.Field {
             Unique().
             Comment("ID de l'abonnement Stripe"),
 
-        field.String("status").
-            Default("inactive").
-            Comment("Statut de l'abonnement : active, past_due, canceled, etc."),
+        // Les transitions autorisées sont contrôlées par le package lifecycle.
+        // Sans valeur par défaut : un abonnement n'est actif que si on le dit
+        field.Enum("status").
+            Values("trialing", "active", "past_due", "canceled", "expired", "paused").
+            Comment("Statut de l'abonnement"),
+
+        // Sans valeur par défaut : un abonnement n'accorde que le niveau demandé
+        field.String("tier").
+            NotEmpty().
+            Comment("Niveau accordé par l'abonnement : basic, premium, etc."),
 
         field.Time("current_period_end").
             Optional().
@@ -39,5 +48,14 @@ func (Subscription) Edges() []ent.Edge {
             Ref("subscription").
             Unique().
             Required(),
+
+        edge.To("events", SubscriptionEvent.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+    }
+}
+
+func (Subscription) Indexes() []ent.Index {
+    return []ent.Index{
+        index.Fields("stripe_customer_id"),
     }
 }
@@ -0,0 +1,62 @@
+package schema
+
+import (
+    "time"
+
+    "entgo.io/ent"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+)
+
+// SubscriptionEvent historise chaque changement de statut d'un abonnement.
+type SubscriptionEvent struct {
+    ent.Schema
+}
+
+func (SubscriptionEvent) Fields() []ent.Field {
+    return []ent.Field{
+        field.Enum("previous_status").
+            Values("trialing", "active", "past_due", "canceled", "expired", "paused").
+            Optional().
+            Nillable().
+            Immutable().
+            Comment("Statut avant la transition (vide à la création de l'abonnement)"),
+
+        field.Enum("status").
+            Values("trialing", "active", "past_due", "canceled", "expired", "paused").
+            Immutable().
+            Comment("Statut après la transition"),
+
+        field.Enum("source").
+            Values("webhook", "admin", "scheduler").
+            Immutable().
+            Comment("Origine de la transition"),
+
+        field.String("reason").
+            Optional().
+            Immutable().
+            Comment("Détail libre : type d'événement Stripe, motif de l'admin, etc."),
+
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+    }
+}
+
+func (SubscriptionEvent) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("subscription", Subscription.Type).
+            Ref("events").
+            Unique().
+            Required().
+            Immutable(),
+    }
+}
+
+func (SubscriptionEvent) Indexes() []ent.Index {
+    return []ent.Index{
+        // Historique d'un abonnement
+        index.Edges("subscription"),
+    }
+}
@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"entgo.io/ent"
-	"entgo.io/ent/schema/field"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
 )
 
 // User maps your internal users linked to Clerk.
@@ -21,17 +23,26 @@ func (User) Fields() []ent.Field {
 					Unique().
 					Comment("ID utilisateur fourni par Clerk"),
 
+			field.UUID("uuid", uuid.UUID{}).
+					Default(uuid.New).
+					Unique().
+					Immutable().
+					// gen_random_uuid() remplit aussi les lignes existantes lors de l'ajout de la colonne
+					Annotations(entsql.DefaultExpr("gen_random_uuid()")).
+					Comment("Identifiant public stable, utilisé par l'extension (settings.uuid)"),
+
 			field.String("role").
 					Default("user").
 					Comment("Rôle interne de l'utilisateur"),
 
+			// is_subscribed et subscription_tier sont dérivés des abonnements (package tiers)
 			field.Bool("is_subscribed").
 					Default(false).
 					Comment("Indique si l'utilisateur a un abonnement actif Stripe"),
 
 			field.String("subscription_tier").
 					Default("free").
-					Comment("Niveau d'abonnement : free, basic, premium, etc."),
+					Comment("Niveau d'abonnement : clé du Plan (free, basic, premium...)"),
 
 			field.Time("created_at").
 					Default(func() time.Time { return time.Now() }).
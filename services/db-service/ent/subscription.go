@@ -22,8 +22,10 @@ type Subscription struct {
 	StripeCustomerID string `json:"stripe_customer_id,omitempty"`
 	// ID de l'abonnement Stripe
 	StripeSubscriptionID string `json:"stripe_subscription_id,omitempty"`
-	// Statut de l'abonnement : active, past_due, canceled, etc.
-	Status string `json:"status,omitempty"`
+	// Statut de l'abonnement
+	Status subscription.Status `json:"status,omitempty"`
+	// Niveau accordé par l'abonnement : basic, premium, etc.
+	Tier string `json:"tier,omitempty"`
 	// Fin de la période actuelle de l'abonnement
 	CurrentPeriodEnd time.Time `json:"current_period_end,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -37,9 +39,11 @@ type Subscription struct {
 type SubscriptionEdges struct {
 	// User holds the value of the user edge.
 	User *User `json:"user,omitempty"`
+	// Events holds the value of the events edge.
+	Events []*SubscriptionEvent `json:"events,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // UserOrErr returns the User value or an error if the edge
@@ -53,6 +57,15 @@ func (e SubscriptionEdges) UserOrErr() (*User, error) {
 	return nil, &NotLoadedError{edge: "user"}
 }
 
+// EventsOrErr returns the Events value or an error if the edge
+// was not loaded in eager-loading.
+func (e SubscriptionEdges) EventsOrErr() ([]*SubscriptionEvent, error) {
+	if e.loadedTypes[1] {
+		return e.Events, nil
+	}
+	return nil, &NotLoadedError{edge: "events"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Subscription) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -60,7 +73,7 @@ func (*Subscription) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case subscription.FieldID:
 			values[i] = new(sql.NullInt64)
-		case subscription.FieldStripeCustomerID, subscription.FieldStripeSubscriptionID, subscription.FieldStatus:
+		case subscription.FieldStripeCustomerID, subscription.FieldStripeSubscriptionID, subscription.FieldStatus, subscription.FieldTier:
 			values[i] = new(sql.NullString)
 		case subscription.FieldCurrentPeriodEnd:
 			values[i] = new(sql.NullTime)
@@ -103,7 +116,13 @@ func (s *Subscription) assignValues(columns []string, values []any) error {
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field status", values[i])
 			} else if value.Valid {
-				s.Status = value.String
+				s.Status = subscription.Status(value.String)
+			}
+		case subscription.FieldTier:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tier", values[i])
+			} else if value.Valid {
+				s.Tier = value.String
 			}
 		case subscription.FieldCurrentPeriodEnd:
 			if value, ok := values[i].(*sql.NullTime); !ok {
@@ -136,6 +155,11 @@ func (s *Subscription) QueryUser() *UserQuery {
 	return NewSubscriptionClient(s.config).QueryUser(s)
 }
 
+// QueryEvents queries the "events" edge of the Subscription entity.
+func (s *Subscription) QueryEvents() *SubscriptionEventQuery {
+	return NewSubscriptionClient(s.config).QueryEvents(s)
+}
+
 // Update returns a builder for updating this Subscription.
 // Note that you need to call Subscription.Unwrap() before calling this method if this Subscription
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -166,7 +190,10 @@ func (s *Subscription) String() string {
 	builder.WriteString(s.StripeSubscriptionID)
 	builder.WriteString(", ")
 	builder.WriteString("status=")
-	builder.WriteString(s.Status)
+	builder.WriteString(fmt.Sprintf("%v", s.Status))
+	builder.WriteString(", ")
+	builder.WriteString("tier=")
+	builder.WriteString(s.Tier)
 	builder.WriteString(", ")
 	builder.WriteString("current_period_end=")
 	builder.WriteString(s.CurrentPeriodEnd.Format(time.ANSIC))
@@ -3,6 +3,8 @@
 package subscription
 
 import (
+	"fmt"
+
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 )
@@ -18,10 +20,14 @@ const (
 	FieldStripeSubscriptionID = "stripe_subscription_id"
 	// FieldStatus holds the string denoting the status field in the database.
 	FieldStatus = "status"
+	// FieldTier holds the string denoting the tier field in the database.
+	FieldTier = "tier"
 	// FieldCurrentPeriodEnd holds the string denoting the current_period_end field in the database.
 	FieldCurrentPeriodEnd = "current_period_end"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
+	// EdgeEvents holds the string denoting the events edge name in mutations.
+	EdgeEvents = "events"
 	// Table holds the table name of the subscription in the database.
 	Table = "subscriptions"
 	// UserTable is the table that holds the user relation/edge.
@@ -31,6 +37,13 @@ const (
 	UserInverseTable = "users"
 	// UserColumn is the table column denoting the user relation/edge.
 	UserColumn = "user_subscription"
+	// EventsTable is the table that holds the events relation/edge.
+	EventsTable = "subscription_events"
+	// EventsInverseTable is the table name for the SubscriptionEvent entity.
+	// It exists in this package in order to avoid circular dependency with the "subscriptionevent" package.
+	EventsInverseTable = "subscription_events"
+	// EventsColumn is the table column denoting the events relation/edge.
+	EventsColumn = "subscription_events"
 )
 
 // Columns holds all SQL columns for subscription fields.
@@ -39,6 +52,7 @@ var Columns = []string{
 	FieldStripeCustomerID,
 	FieldStripeSubscriptionID,
 	FieldStatus,
+	FieldTier,
 	FieldCurrentPeriodEnd,
 }
 
@@ -68,10 +82,37 @@ var (
 	StripeCustomerIDValidator func(string) error
 	// StripeSubscriptionIDValidator is a validator for the "stripe_subscription_id" field. It is called by the builders before save.
 	StripeSubscriptionIDValidator func(string) error
-	// DefaultStatus holds the default value on creation for the "status" field.
-	DefaultStatus string
+	// TierValidator is a validator for the "tier" field. It is called by the builders before save.
+	TierValidator func(string) error
+)
+
+// Status defines the type for the "status" enum field.
+type Status string
+
+// Status values.
+const (
+	StatusTrialing Status = "trialing"
+	StatusActive   Status = "active"
+	StatusPastDue  Status = "past_due"
+	StatusCanceled Status = "canceled"
+	StatusExpired  Status = "expired"
+	StatusPaused   Status = "paused"
 )
 
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusTrialing, StatusActive, StatusPastDue, StatusCanceled, StatusExpired, StatusPaused:
+		return nil
+	default:
+		return fmt.Errorf("subscription: invalid enum value for status field: %q", s)
+	}
+}
+
 // OrderOption defines the ordering options for the Subscription queries.
 type OrderOption func(*sql.Selector)
 
@@ -95,6 +136,11 @@ func ByStatus(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStatus, opts...).ToFunc()
 }
 
+// ByTier orders the results by the tier field.
+func ByTier(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTier, opts...).ToFunc()
+}
+
 // ByCurrentPeriodEnd orders the results by the current_period_end field.
 func ByCurrentPeriodEnd(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCurrentPeriodEnd, opts...).ToFunc()
@@ -106,6 +152,20 @@ func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newUserStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByEventsCount orders the results by events count.
+func ByEventsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newEventsStep(), opts...)
+	}
+}
+
+// ByEvents orders the results by events terms.
+func ByEvents(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newEventsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newUserStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -113,3 +173,10 @@ func newUserStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
 	)
 }
+func newEventsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(EventsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, EventsTable, EventsColumn),
+	)
+}
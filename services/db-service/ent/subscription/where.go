@@ -65,9 +65,9 @@ func StripeSubscriptionID(v string) predicate.Subscription {
 	return predicate.Subscription(sql.FieldEQ(FieldStripeSubscriptionID, v))
 }
 
-// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
-func Status(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldEQ(FieldStatus, v))
+// Tier applies equality check predicate on the "tier" field. It's identical to TierEQ.
+func Tier(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldEQ(FieldTier, v))
 }
 
 // CurrentPeriodEnd applies equality check predicate on the "current_period_end" field. It's identical to CurrentPeriodEndEQ.
@@ -206,68 +206,88 @@ func StripeSubscriptionIDContainsFold(v string) predicate.Subscription {
 }
 
 // StatusEQ applies the EQ predicate on the "status" field.
-func StatusEQ(v string) predicate.Subscription {
+func StatusEQ(v Status) predicate.Subscription {
 	return predicate.Subscription(sql.FieldEQ(FieldStatus, v))
 }
 
 // StatusNEQ applies the NEQ predicate on the "status" field.
-func StatusNEQ(v string) predicate.Subscription {
+func StatusNEQ(v Status) predicate.Subscription {
 	return predicate.Subscription(sql.FieldNEQ(FieldStatus, v))
 }
 
 // StatusIn applies the In predicate on the "status" field.
-func StatusIn(vs ...string) predicate.Subscription {
+func StatusIn(vs ...Status) predicate.Subscription {
 	return predicate.Subscription(sql.FieldIn(FieldStatus, vs...))
 }
 
 // StatusNotIn applies the NotIn predicate on the "status" field.
-func StatusNotIn(vs ...string) predicate.Subscription {
+func StatusNotIn(vs ...Status) predicate.Subscription {
 	return predicate.Subscription(sql.FieldNotIn(FieldStatus, vs...))
 }
 
-// StatusGT applies the GT predicate on the "status" field.
-func StatusGT(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldGT(FieldStatus, v))
+// TierEQ applies the EQ predicate on the "tier" field.
+func TierEQ(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldEQ(FieldTier, v))
+}
+
+// TierNEQ applies the NEQ predicate on the "tier" field.
+func TierNEQ(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldNEQ(FieldTier, v))
+}
+
+// TierIn applies the In predicate on the "tier" field.
+func TierIn(vs ...string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldIn(FieldTier, vs...))
+}
+
+// TierNotIn applies the NotIn predicate on the "tier" field.
+func TierNotIn(vs ...string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldNotIn(FieldTier, vs...))
 }
 
-// StatusGTE applies the GTE predicate on the "status" field.
-func StatusGTE(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldGTE(FieldStatus, v))
+// TierGT applies the GT predicate on the "tier" field.
+func TierGT(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldGT(FieldTier, v))
 }
 
-// StatusLT applies the LT predicate on the "status" field.
-func StatusLT(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldLT(FieldStatus, v))
+// TierGTE applies the GTE predicate on the "tier" field.
+func TierGTE(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldGTE(FieldTier, v))
 }
 
-// StatusLTE applies the LTE predicate on the "status" field.
-func StatusLTE(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldLTE(FieldStatus, v))
+// TierLT applies the LT predicate on the "tier" field.
+func TierLT(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldLT(FieldTier, v))
 }
 
-// StatusContains applies the Contains predicate on the "status" field.
-func StatusContains(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldContains(FieldStatus, v))
+// TierLTE applies the LTE predicate on the "tier" field.
+func TierLTE(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldLTE(FieldTier, v))
 }
 
-// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
-func StatusHasPrefix(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldHasPrefix(FieldStatus, v))
+// TierContains applies the Contains predicate on the "tier" field.
+func TierContains(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldContains(FieldTier, v))
 }
 
-// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
-func StatusHasSuffix(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldHasSuffix(FieldStatus, v))
+// TierHasPrefix applies the HasPrefix predicate on the "tier" field.
+func TierHasPrefix(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldHasPrefix(FieldTier, v))
 }
 
-// StatusEqualFold applies the EqualFold predicate on the "status" field.
-func StatusEqualFold(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldEqualFold(FieldStatus, v))
+// TierHasSuffix applies the HasSuffix predicate on the "tier" field.
+func TierHasSuffix(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldHasSuffix(FieldTier, v))
 }
 
-// StatusContainsFold applies the ContainsFold predicate on the "status" field.
-func StatusContainsFold(v string) predicate.Subscription {
-	return predicate.Subscription(sql.FieldContainsFold(FieldStatus, v))
+// TierEqualFold applies the EqualFold predicate on the "tier" field.
+func TierEqualFold(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldEqualFold(FieldTier, v))
+}
+
+// TierContainsFold applies the ContainsFold predicate on the "tier" field.
+func TierContainsFold(v string) predicate.Subscription {
+	return predicate.Subscription(sql.FieldContainsFold(FieldTier, v))
 }
 
 // CurrentPeriodEndEQ applies the EQ predicate on the "current_period_end" field.
@@ -343,6 +363,29 @@ func HasUserWith(preds ...predicate.User) predicate.Subscription {
 	})
 }
 
+// HasEvents applies the HasEdge predicate on the "events" edge.
+func HasEvents() predicate.Subscription {
+	return predicate.Subscription(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, EventsTable, EventsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasEventsWith applies the HasEdge predicate on the "events" edge with a given conditions (other predicates).
+func HasEventsWith(preds ...predicate.SubscriptionEvent) predicate.Subscription {
+	return predicate.Subscription(func(s *sql.Selector) {
+		step := newEventsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Subscription) predicate.Subscription {
 	return predicate.Subscription(sql.AndPredicates(predicates...))
@@ -5,11 +5,13 @@ package ent
 import (
 	"context"
 	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
 	"db-service/ent/user"
 	"errors"
 	"fmt"
 	"time"
 
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 )
@@ -19,6 +21,7 @@ type SubscriptionCreate struct {
 	config
 	mutation *SubscriptionMutation
 	hooks    []Hook
+	conflict []sql.ConflictOption
 }
 
 // SetStripeCustomerID sets the "stripe_customer_id" field.
@@ -34,16 +37,14 @@ func (sc *SubscriptionCreate) SetStripeSubscriptionID(s string) *SubscriptionCre
 }
 
 // SetStatus sets the "status" field.
-func (sc *SubscriptionCreate) SetStatus(s string) *SubscriptionCreate {
+func (sc *SubscriptionCreate) SetStatus(s subscription.Status) *SubscriptionCreate {
 	sc.mutation.SetStatus(s)
 	return sc
 }
 
-// SetNillableStatus sets the "status" field if the given value is not nil.
-func (sc *SubscriptionCreate) SetNillableStatus(s *string) *SubscriptionCreate {
-	if s != nil {
-		sc.SetStatus(*s)
-	}
+// SetTier sets the "tier" field.
+func (sc *SubscriptionCreate) SetTier(s string) *SubscriptionCreate {
+	sc.mutation.SetTier(s)
 	return sc
 }
 
@@ -72,6 +73,21 @@ func (sc *SubscriptionCreate) SetUser(u *User) *SubscriptionCreate {
 	return sc.SetUserID(u.ID)
 }
 
+// AddEventIDs adds the "events" edge to the SubscriptionEvent entity by IDs.
+func (sc *SubscriptionCreate) AddEventIDs(ids ...int) *SubscriptionCreate {
+	sc.mutation.AddEventIDs(ids...)
+	return sc
+}
+
+// AddEvents adds the "events" edges to the SubscriptionEvent entity.
+func (sc *SubscriptionCreate) AddEvents(s ...*SubscriptionEvent) *SubscriptionCreate {
+	ids := make([]int, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return sc.AddEventIDs(ids...)
+}
+
 // Mutation returns the SubscriptionMutation object of the builder.
 func (sc *SubscriptionCreate) Mutation() *SubscriptionMutation {
 	return sc.mutation
@@ -79,7 +95,6 @@ func (sc *SubscriptionCreate) Mutation() *SubscriptionMutation {
 
 // Save creates the Subscription in the database.
 func (sc *SubscriptionCreate) Save(ctx context.Context) (*Subscription, error) {
-	sc.defaults()
 	return withHooks(ctx, sc.sqlSave, sc.mutation, sc.hooks)
 }
 
@@ -105,14 +120,6 @@ func (sc *SubscriptionCreate) ExecX(ctx context.Context) {
 	}
 }
 
-// defaults sets the default values of the builder before save.
-func (sc *SubscriptionCreate) defaults() {
-	if _, ok := sc.mutation.Status(); !ok {
-		v := subscription.DefaultStatus
-		sc.mutation.SetStatus(v)
-	}
-}
-
 // check runs all checks and user-defined validators on the builder.
 func (sc *SubscriptionCreate) check() error {
 	if _, ok := sc.mutation.StripeCustomerID(); !ok {
@@ -134,6 +141,19 @@ func (sc *SubscriptionCreate) check() error {
 	if _, ok := sc.mutation.Status(); !ok {
 		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Subscription.status"`)}
 	}
+	if v, ok := sc.mutation.Status(); ok {
+		if err := subscription.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Subscription.status": %w`, err)}
+		}
+	}
+	if _, ok := sc.mutation.Tier(); !ok {
+		return &ValidationError{Name: "tier", err: errors.New(`ent: missing required field "Subscription.tier"`)}
+	}
+	if v, ok := sc.mutation.Tier(); ok {
+		if err := subscription.TierValidator(v); err != nil {
+			return &ValidationError{Name: "tier", err: fmt.Errorf(`ent: validator failed for field "Subscription.tier": %w`, err)}
+		}
+	}
 	if len(sc.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Subscription.user"`)}
 	}
@@ -163,6 +183,7 @@ func (sc *SubscriptionCreate) createSpec() (*Subscription, *sqlgraph.CreateSpec)
 		_node = &Subscription{config: sc.config}
 		_spec = sqlgraph.NewCreateSpec(subscription.Table, sqlgraph.NewFieldSpec(subscription.FieldID, field.TypeInt))
 	)
+	_spec.OnConflict = sc.conflict
 	if value, ok := sc.mutation.StripeCustomerID(); ok {
 		_spec.SetField(subscription.FieldStripeCustomerID, field.TypeString, value)
 		_node.StripeCustomerID = value
@@ -172,9 +193,13 @@ func (sc *SubscriptionCreate) createSpec() (*Subscription, *sqlgraph.CreateSpec)
 		_node.StripeSubscriptionID = value
 	}
 	if value, ok := sc.mutation.Status(); ok {
-		_spec.SetField(subscription.FieldStatus, field.TypeString, value)
+		_spec.SetField(subscription.FieldStatus, field.TypeEnum, value)
 		_node.Status = value
 	}
+	if value, ok := sc.mutation.Tier(); ok {
+		_spec.SetField(subscription.FieldTier, field.TypeString, value)
+		_node.Tier = value
+	}
 	if value, ok := sc.mutation.CurrentPeriodEnd(); ok {
 		_spec.SetField(subscription.FieldCurrentPeriodEnd, field.TypeTime, value)
 		_node.CurrentPeriodEnd = value
@@ -196,14 +221,296 @@ func (sc *SubscriptionCreate) createSpec() (*Subscription, *sqlgraph.CreateSpec)
 		_node.user_subscription = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := sc.mutation.EventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Subscription.Create().
+//		SetStripeCustomerID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SubscriptionUpsert) {
+//			SetStripeCustomerID(v+v).
+//		}).
+//		Exec(ctx)
+func (sc *SubscriptionCreate) OnConflict(opts ...sql.ConflictOption) *SubscriptionUpsertOne {
+	sc.conflict = opts
+	return &SubscriptionUpsertOne{
+		create: sc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Subscription.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (sc *SubscriptionCreate) OnConflictColumns(columns ...string) *SubscriptionUpsertOne {
+	sc.conflict = append(sc.conflict, sql.ConflictColumns(columns...))
+	return &SubscriptionUpsertOne{
+		create: sc,
+	}
+}
+
+type (
+	// SubscriptionUpsertOne is the builder for "upsert"-ing
+	//  one Subscription node.
+	SubscriptionUpsertOne struct {
+		create *SubscriptionCreate
+	}
+
+	// SubscriptionUpsert is the "OnConflict" setter.
+	SubscriptionUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetStripeCustomerID sets the "stripe_customer_id" field.
+func (u *SubscriptionUpsert) SetStripeCustomerID(v string) *SubscriptionUpsert {
+	u.Set(subscription.FieldStripeCustomerID, v)
+	return u
+}
+
+// UpdateStripeCustomerID sets the "stripe_customer_id" field to the value that was provided on create.
+func (u *SubscriptionUpsert) UpdateStripeCustomerID() *SubscriptionUpsert {
+	u.SetExcluded(subscription.FieldStripeCustomerID)
+	return u
+}
+
+// SetStripeSubscriptionID sets the "stripe_subscription_id" field.
+func (u *SubscriptionUpsert) SetStripeSubscriptionID(v string) *SubscriptionUpsert {
+	u.Set(subscription.FieldStripeSubscriptionID, v)
+	return u
+}
+
+// UpdateStripeSubscriptionID sets the "stripe_subscription_id" field to the value that was provided on create.
+func (u *SubscriptionUpsert) UpdateStripeSubscriptionID() *SubscriptionUpsert {
+	u.SetExcluded(subscription.FieldStripeSubscriptionID)
+	return u
+}
+
+// SetStatus sets the "status" field.
+func (u *SubscriptionUpsert) SetStatus(v subscription.Status) *SubscriptionUpsert {
+	u.Set(subscription.FieldStatus, v)
+	return u
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *SubscriptionUpsert) UpdateStatus() *SubscriptionUpsert {
+	u.SetExcluded(subscription.FieldStatus)
+	return u
+}
+
+// SetTier sets the "tier" field.
+func (u *SubscriptionUpsert) SetTier(v string) *SubscriptionUpsert {
+	u.Set(subscription.FieldTier, v)
+	return u
+}
+
+// UpdateTier sets the "tier" field to the value that was provided on create.
+func (u *SubscriptionUpsert) UpdateTier() *SubscriptionUpsert {
+	u.SetExcluded(subscription.FieldTier)
+	return u
+}
+
+// SetCurrentPeriodEnd sets the "current_period_end" field.
+func (u *SubscriptionUpsert) SetCurrentPeriodEnd(v time.Time) *SubscriptionUpsert {
+	u.Set(subscription.FieldCurrentPeriodEnd, v)
+	return u
+}
+
+// UpdateCurrentPeriodEnd sets the "current_period_end" field to the value that was provided on create.
+func (u *SubscriptionUpsert) UpdateCurrentPeriodEnd() *SubscriptionUpsert {
+	u.SetExcluded(subscription.FieldCurrentPeriodEnd)
+	return u
+}
+
+// ClearCurrentPeriodEnd clears the value of the "current_period_end" field.
+func (u *SubscriptionUpsert) ClearCurrentPeriodEnd() *SubscriptionUpsert {
+	u.SetNull(subscription.FieldCurrentPeriodEnd)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.Subscription.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SubscriptionUpsertOne) UpdateNewValues() *SubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Subscription.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *SubscriptionUpsertOne) Ignore() *SubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SubscriptionUpsertOne) DoNothing() *SubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SubscriptionCreate.OnConflict
+// documentation for more info.
+func (u *SubscriptionUpsertOne) Update(set func(*SubscriptionUpsert)) *SubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SubscriptionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetStripeCustomerID sets the "stripe_customer_id" field.
+func (u *SubscriptionUpsertOne) SetStripeCustomerID(v string) *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetStripeCustomerID(v)
+	})
+}
+
+// UpdateStripeCustomerID sets the "stripe_customer_id" field to the value that was provided on create.
+func (u *SubscriptionUpsertOne) UpdateStripeCustomerID() *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateStripeCustomerID()
+	})
+}
+
+// SetStripeSubscriptionID sets the "stripe_subscription_id" field.
+func (u *SubscriptionUpsertOne) SetStripeSubscriptionID(v string) *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetStripeSubscriptionID(v)
+	})
+}
+
+// UpdateStripeSubscriptionID sets the "stripe_subscription_id" field to the value that was provided on create.
+func (u *SubscriptionUpsertOne) UpdateStripeSubscriptionID() *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateStripeSubscriptionID()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *SubscriptionUpsertOne) SetStatus(v subscription.Status) *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *SubscriptionUpsertOne) UpdateStatus() *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetTier sets the "tier" field.
+func (u *SubscriptionUpsertOne) SetTier(v string) *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetTier(v)
+	})
+}
+
+// UpdateTier sets the "tier" field to the value that was provided on create.
+func (u *SubscriptionUpsertOne) UpdateTier() *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateTier()
+	})
+}
+
+// SetCurrentPeriodEnd sets the "current_period_end" field.
+func (u *SubscriptionUpsertOne) SetCurrentPeriodEnd(v time.Time) *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetCurrentPeriodEnd(v)
+	})
+}
+
+// UpdateCurrentPeriodEnd sets the "current_period_end" field to the value that was provided on create.
+func (u *SubscriptionUpsertOne) UpdateCurrentPeriodEnd() *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateCurrentPeriodEnd()
+	})
+}
+
+// ClearCurrentPeriodEnd clears the value of the "current_period_end" field.
+func (u *SubscriptionUpsertOne) ClearCurrentPeriodEnd() *SubscriptionUpsertOne {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.ClearCurrentPeriodEnd()
+	})
+}
+
+// Exec executes the query.
+func (u *SubscriptionUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SubscriptionCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SubscriptionUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *SubscriptionUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *SubscriptionUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
 // SubscriptionCreateBulk is the builder for creating many Subscription entities in bulk.
 type SubscriptionCreateBulk struct {
 	config
 	err      error
 	builders []*SubscriptionCreate
+	conflict []sql.ConflictOption
 }
 
 // Save creates the Subscription entities in the database.
@@ -217,7 +524,6 @@ func (scb *SubscriptionCreateBulk) Save(ctx context.Context) ([]*Subscription, e
 	for i := range scb.builders {
 		func(i int, root context.Context) {
 			builder := scb.builders[i]
-			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*SubscriptionMutation)
 				if !ok {
@@ -233,6 +539,7 @@ func (scb *SubscriptionCreateBulk) Save(ctx context.Context) ([]*Subscription, e
 					_, err = mutators[i+1].Mutate(root, scb.builders[i+1].mutation)
 				} else {
 					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = scb.conflict
 					// Invoke the actual operation on the latest mutation in the chain.
 					if err = sqlgraph.BatchCreate(ctx, scb.driver, spec); err != nil {
 						if sqlgraph.IsConstraintError(err) {
@@ -286,3 +593,184 @@ func (scb *SubscriptionCreateBulk) ExecX(ctx context.Context) {
 		panic(err)
 	}
 }
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Subscription.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SubscriptionUpsert) {
+//			SetStripeCustomerID(v+v).
+//		}).
+//		Exec(ctx)
+func (scb *SubscriptionCreateBulk) OnConflict(opts ...sql.ConflictOption) *SubscriptionUpsertBulk {
+	scb.conflict = opts
+	return &SubscriptionUpsertBulk{
+		create: scb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Subscription.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (scb *SubscriptionCreateBulk) OnConflictColumns(columns ...string) *SubscriptionUpsertBulk {
+	scb.conflict = append(scb.conflict, sql.ConflictColumns(columns...))
+	return &SubscriptionUpsertBulk{
+		create: scb,
+	}
+}
+
+// SubscriptionUpsertBulk is the builder for "upsert"-ing
+// a bulk of Subscription nodes.
+type SubscriptionUpsertBulk struct {
+	create *SubscriptionCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Subscription.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SubscriptionUpsertBulk) UpdateNewValues() *SubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Subscription.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *SubscriptionUpsertBulk) Ignore() *SubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SubscriptionUpsertBulk) DoNothing() *SubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SubscriptionCreateBulk.OnConflict
+// documentation for more info.
+func (u *SubscriptionUpsertBulk) Update(set func(*SubscriptionUpsert)) *SubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SubscriptionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetStripeCustomerID sets the "stripe_customer_id" field.
+func (u *SubscriptionUpsertBulk) SetStripeCustomerID(v string) *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetStripeCustomerID(v)
+	})
+}
+
+// UpdateStripeCustomerID sets the "stripe_customer_id" field to the value that was provided on create.
+func (u *SubscriptionUpsertBulk) UpdateStripeCustomerID() *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateStripeCustomerID()
+	})
+}
+
+// SetStripeSubscriptionID sets the "stripe_subscription_id" field.
+func (u *SubscriptionUpsertBulk) SetStripeSubscriptionID(v string) *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetStripeSubscriptionID(v)
+	})
+}
+
+// UpdateStripeSubscriptionID sets the "stripe_subscription_id" field to the value that was provided on create.
+func (u *SubscriptionUpsertBulk) UpdateStripeSubscriptionID() *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateStripeSubscriptionID()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *SubscriptionUpsertBulk) SetStatus(v subscription.Status) *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *SubscriptionUpsertBulk) UpdateStatus() *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetTier sets the "tier" field.
+func (u *SubscriptionUpsertBulk) SetTier(v string) *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetTier(v)
+	})
+}
+
+// UpdateTier sets the "tier" field to the value that was provided on create.
+func (u *SubscriptionUpsertBulk) UpdateTier() *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateTier()
+	})
+}
+
+// SetCurrentPeriodEnd sets the "current_period_end" field.
+func (u *SubscriptionUpsertBulk) SetCurrentPeriodEnd(v time.Time) *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.SetCurrentPeriodEnd(v)
+	})
+}
+
+// UpdateCurrentPeriodEnd sets the "current_period_end" field to the value that was provided on create.
+func (u *SubscriptionUpsertBulk) UpdateCurrentPeriodEnd() *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.UpdateCurrentPeriodEnd()
+	})
+}
+
+// ClearCurrentPeriodEnd clears the value of the "current_period_end" field.
+func (u *SubscriptionUpsertBulk) ClearCurrentPeriodEnd() *SubscriptionUpsertBulk {
+	return u.Update(func(s *SubscriptionUpsert) {
+		s.ClearCurrentPeriodEnd()
+	})
+}
+
+// Exec executes the query.
+func (u *SubscriptionUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the SubscriptionCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SubscriptionCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SubscriptionUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -4,8 +4,10 @@ package ent
 
 import (
 	"context"
+	"database/sql/driver"
 	"db-service/ent/predicate"
 	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
 	"db-service/ent/user"
 	"fmt"
 	"math"
@@ -24,6 +26,7 @@ type SubscriptionQuery struct {
 	inters     []Interceptor
 	predicates []predicate.Subscription
 	withUser   *UserQuery
+	withEvents *SubscriptionEventQuery
 	withFKs    bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
@@ -83,6 +86,28 @@ func (sq *SubscriptionQuery) QueryUser() *UserQuery {
 	return query
 }
 
+// QueryEvents chains the current query on the "events" edge.
+func (sq *SubscriptionQuery) QueryEvents() *SubscriptionEventQuery {
+	query := (&SubscriptionEventClient{config: sq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := sq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := sq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(subscription.Table, subscription.FieldID, selector),
+			sqlgraph.To(subscriptionevent.Table, subscriptionevent.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, subscription.EventsTable, subscription.EventsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(sq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Subscription entity from the query.
 // Returns a *NotFoundError when no Subscription was found.
 func (sq *SubscriptionQuery) First(ctx context.Context) (*Subscription, error) {
@@ -276,6 +301,7 @@ func (sq *SubscriptionQuery) Clone() *SubscriptionQuery {
 		inters:     append([]Interceptor{}, sq.inters...),
 		predicates: append([]predicate.Subscription{}, sq.predicates...),
 		withUser:   sq.withUser.Clone(),
+		withEvents: sq.withEvents.Clone(),
 		// clone intermediate query.
 		sql:  sq.sql.Clone(),
 		path: sq.path,
@@ -293,6 +319,17 @@ func (sq *SubscriptionQuery) WithUser(opts ...func(*UserQuery)) *SubscriptionQue
 	return sq
 }
 
+// WithEvents tells the query-builder to eager-load the nodes that are connected to
+// the "events" edge. The optional arguments are used to configure the query builder of the edge.
+func (sq *SubscriptionQuery) WithEvents(opts ...func(*SubscriptionEventQuery)) *SubscriptionQuery {
+	query := (&SubscriptionEventClient{config: sq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	sq.withEvents = query
+	return sq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -372,8 +409,9 @@ func (sq *SubscriptionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]
 		nodes       = []*Subscription{}
 		withFKs     = sq.withFKs
 		_spec       = sq.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [2]bool{
 			sq.withUser != nil,
+			sq.withEvents != nil,
 		}
 	)
 	if sq.withUser != nil {
@@ -406,6 +444,13 @@ func (sq *SubscriptionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]
 			return nil, err
 		}
 	}
+	if query := sq.withEvents; query != nil {
+		if err := sq.loadEvents(ctx, query, nodes,
+			func(n *Subscription) { n.Edges.Events = []*SubscriptionEvent{} },
+			func(n *Subscription, e *SubscriptionEvent) { n.Edges.Events = append(n.Edges.Events, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -441,6 +486,37 @@ func (sq *SubscriptionQuery) loadUser(ctx context.Context, query *UserQuery, nod
 	}
 	return nil
 }
+func (sq *SubscriptionQuery) loadEvents(ctx context.Context, query *SubscriptionEventQuery, nodes []*Subscription, init func(*Subscription), assign func(*Subscription, *SubscriptionEvent)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Subscription)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.SubscriptionEvent(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(subscription.EventsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.subscription_events
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "subscription_events" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "subscription_events" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (sq *SubscriptionQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := sq.querySpec()
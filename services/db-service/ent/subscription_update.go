@@ -6,6 +6,7 @@ import (
 	"context"
 	"db-service/ent/predicate"
 	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
 	"db-service/ent/user"
 	"errors"
 	"fmt"
@@ -58,19 +59,33 @@ func (su *SubscriptionUpdate) SetNillableStripeSubscriptionID(s *string) *Subscr
 }
 
 // SetStatus sets the "status" field.
-func (su *SubscriptionUpdate) SetStatus(s string) *SubscriptionUpdate {
+func (su *SubscriptionUpdate) SetStatus(s subscription.Status) *SubscriptionUpdate {
 	su.mutation.SetStatus(s)
 	return su
 }
 
 // SetNillableStatus sets the "status" field if the given value is not nil.
-func (su *SubscriptionUpdate) SetNillableStatus(s *string) *SubscriptionUpdate {
+func (su *SubscriptionUpdate) SetNillableStatus(s *subscription.Status) *SubscriptionUpdate {
 	if s != nil {
 		su.SetStatus(*s)
 	}
 	return su
 }
 
+// SetTier sets the "tier" field.
+func (su *SubscriptionUpdate) SetTier(s string) *SubscriptionUpdate {
+	su.mutation.SetTier(s)
+	return su
+}
+
+// SetNillableTier sets the "tier" field if the given value is not nil.
+func (su *SubscriptionUpdate) SetNillableTier(s *string) *SubscriptionUpdate {
+	if s != nil {
+		su.SetTier(*s)
+	}
+	return su
+}
+
 // SetCurrentPeriodEnd sets the "current_period_end" field.
 func (su *SubscriptionUpdate) SetCurrentPeriodEnd(t time.Time) *SubscriptionUpdate {
 	su.mutation.SetCurrentPeriodEnd(t)
@@ -102,6 +117,21 @@ func (su *SubscriptionUpdate) SetUser(u *User) *SubscriptionUpdate {
 	return su.SetUserID(u.ID)
 }
 
+// AddEventIDs adds the "events" edge to the SubscriptionEvent entity by IDs.
+func (su *SubscriptionUpdate) AddEventIDs(ids ...int) *SubscriptionUpdate {
+	su.mutation.AddEventIDs(ids...)
+	return su
+}
+
+// AddEvents adds the "events" edges to the SubscriptionEvent entity.
+func (su *SubscriptionUpdate) AddEvents(s ...*SubscriptionEvent) *SubscriptionUpdate {
+	ids := make([]int, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return su.AddEventIDs(ids...)
+}
+
 // Mutation returns the SubscriptionMutation object of the builder.
 func (su *SubscriptionUpdate) Mutation() *SubscriptionMutation {
 	return su.mutation
@@ -113,6 +143,27 @@ func (su *SubscriptionUpdate) ClearUser() *SubscriptionUpdate {
 	return su
 }
 
+// ClearEvents clears all "events" edges to the SubscriptionEvent entity.
+func (su *SubscriptionUpdate) ClearEvents() *SubscriptionUpdate {
+	su.mutation.ClearEvents()
+	return su
+}
+
+// RemoveEventIDs removes the "events" edge to SubscriptionEvent entities by IDs.
+func (su *SubscriptionUpdate) RemoveEventIDs(ids ...int) *SubscriptionUpdate {
+	su.mutation.RemoveEventIDs(ids...)
+	return su
+}
+
+// RemoveEvents removes "events" edges to SubscriptionEvent entities.
+func (su *SubscriptionUpdate) RemoveEvents(s ...*SubscriptionEvent) *SubscriptionUpdate {
+	ids := make([]int, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return su.RemoveEventIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (su *SubscriptionUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, su.sqlSave, su.mutation, su.hooks)
@@ -152,6 +203,16 @@ func (su *SubscriptionUpdate) check() error {
 			return &ValidationError{Name: "stripe_subscription_id", err: fmt.Errorf(`ent: validator failed for field "Subscription.stripe_subscription_id": %w`, err)}
 		}
 	}
+	if v, ok := su.mutation.Status(); ok {
+		if err := subscription.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Subscription.status": %w`, err)}
+		}
+	}
+	if v, ok := su.mutation.Tier(); ok {
+		if err := subscription.TierValidator(v); err != nil {
+			return &ValidationError{Name: "tier", err: fmt.Errorf(`ent: validator failed for field "Subscription.tier": %w`, err)}
+		}
+	}
 	if su.mutation.UserCleared() && len(su.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Subscription.user"`)
 	}
@@ -177,7 +238,10 @@ func (su *SubscriptionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		_spec.SetField(subscription.FieldStripeSubscriptionID, field.TypeString, value)
 	}
 	if value, ok := su.mutation.Status(); ok {
-		_spec.SetField(subscription.FieldStatus, field.TypeString, value)
+		_spec.SetField(subscription.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := su.mutation.Tier(); ok {
+		_spec.SetField(subscription.FieldTier, field.TypeString, value)
 	}
 	if value, ok := su.mutation.CurrentPeriodEnd(); ok {
 		_spec.SetField(subscription.FieldCurrentPeriodEnd, field.TypeTime, value)
@@ -214,6 +278,51 @@ func (su *SubscriptionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if su.mutation.EventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := su.mutation.RemovedEventsIDs(); len(nodes) > 0 && !su.mutation.EventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := su.mutation.EventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, su.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{subscription.Label}
@@ -263,19 +372,33 @@ func (suo *SubscriptionUpdateOne) SetNillableStripeSubscriptionID(s *string) *Su
 }
 
 // SetStatus sets the "status" field.
-func (suo *SubscriptionUpdateOne) SetStatus(s string) *SubscriptionUpdateOne {
+func (suo *SubscriptionUpdateOne) SetStatus(s subscription.Status) *SubscriptionUpdateOne {
 	suo.mutation.SetStatus(s)
 	return suo
 }
 
 // SetNillableStatus sets the "status" field if the given value is not nil.
-func (suo *SubscriptionUpdateOne) SetNillableStatus(s *string) *SubscriptionUpdateOne {
+func (suo *SubscriptionUpdateOne) SetNillableStatus(s *subscription.Status) *SubscriptionUpdateOne {
 	if s != nil {
 		suo.SetStatus(*s)
 	}
 	return suo
 }
 
+// SetTier sets the "tier" field.
+func (suo *SubscriptionUpdateOne) SetTier(s string) *SubscriptionUpdateOne {
+	suo.mutation.SetTier(s)
+	return suo
+}
+
+// SetNillableTier sets the "tier" field if the given value is not nil.
+func (suo *SubscriptionUpdateOne) SetNillableTier(s *string) *SubscriptionUpdateOne {
+	if s != nil {
+		suo.SetTier(*s)
+	}
+	return suo
+}
+
 // SetCurrentPeriodEnd sets the "current_period_end" field.
 func (suo *SubscriptionUpdateOne) SetCurrentPeriodEnd(t time.Time) *SubscriptionUpdateOne {
 	suo.mutation.SetCurrentPeriodEnd(t)
@@ -307,6 +430,21 @@ func (suo *SubscriptionUpdateOne) SetUser(u *User) *SubscriptionUpdateOne {
 	return suo.SetUserID(u.ID)
 }
 
+// AddEventIDs adds the "events" edge to the SubscriptionEvent entity by IDs.
+func (suo *SubscriptionUpdateOne) AddEventIDs(ids ...int) *SubscriptionUpdateOne {
+	suo.mutation.AddEventIDs(ids...)
+	return suo
+}
+
+// AddEvents adds the "events" edges to the SubscriptionEvent entity.
+func (suo *SubscriptionUpdateOne) AddEvents(s ...*SubscriptionEvent) *SubscriptionUpdateOne {
+	ids := make([]int, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return suo.AddEventIDs(ids...)
+}
+
 // Mutation returns the SubscriptionMutation object of the builder.
 func (suo *SubscriptionUpdateOne) Mutation() *SubscriptionMutation {
 	return suo.mutation
@@ -318,6 +456,27 @@ func (suo *SubscriptionUpdateOne) ClearUser() *SubscriptionUpdateOne {
 	return suo
 }
 
+// ClearEvents clears all "events" edges to the SubscriptionEvent entity.
+func (suo *SubscriptionUpdateOne) ClearEvents() *SubscriptionUpdateOne {
+	suo.mutation.ClearEvents()
+	return suo
+}
+
+// RemoveEventIDs removes the "events" edge to SubscriptionEvent entities by IDs.
+func (suo *SubscriptionUpdateOne) RemoveEventIDs(ids ...int) *SubscriptionUpdateOne {
+	suo.mutation.RemoveEventIDs(ids...)
+	return suo
+}
+
+// RemoveEvents removes "events" edges to SubscriptionEvent entities.
+func (suo *SubscriptionUpdateOne) RemoveEvents(s ...*SubscriptionEvent) *SubscriptionUpdateOne {
+	ids := make([]int, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return suo.RemoveEventIDs(ids...)
+}
+
 // Where appends a list predicates to the SubscriptionUpdate builder.
 func (suo *SubscriptionUpdateOne) Where(ps ...predicate.Subscription) *SubscriptionUpdateOne {
 	suo.mutation.Where(ps...)
@@ -370,6 +529,16 @@ func (suo *SubscriptionUpdateOne) check() error {
 			return &ValidationError{Name: "stripe_subscription_id", err: fmt.Errorf(`ent: validator failed for field "Subscription.stripe_subscription_id": %w`, err)}
 		}
 	}
+	if v, ok := suo.mutation.Status(); ok {
+		if err := subscription.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Subscription.status": %w`, err)}
+		}
+	}
+	if v, ok := suo.mutation.Tier(); ok {
+		if err := subscription.TierValidator(v); err != nil {
+			return &ValidationError{Name: "tier", err: fmt.Errorf(`ent: validator failed for field "Subscription.tier": %w`, err)}
+		}
+	}
 	if suo.mutation.UserCleared() && len(suo.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Subscription.user"`)
 	}
@@ -412,7 +581,10 @@ func (suo *SubscriptionUpdateOne) sqlSave(ctx context.Context) (_node *Subscript
 		_spec.SetField(subscription.FieldStripeSubscriptionID, field.TypeString, value)
 	}
 	if value, ok := suo.mutation.Status(); ok {
-		_spec.SetField(subscription.FieldStatus, field.TypeString, value)
+		_spec.SetField(subscription.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := suo.mutation.Tier(); ok {
+		_spec.SetField(subscription.FieldTier, field.TypeString, value)
 	}
 	if value, ok := suo.mutation.CurrentPeriodEnd(); ok {
 		_spec.SetField(subscription.FieldCurrentPeriodEnd, field.TypeTime, value)
@@ -449,6 +621,51 @@ func (suo *SubscriptionUpdateOne) sqlSave(ctx context.Context) (_node *Subscript
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if suo.mutation.EventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := suo.mutation.RemovedEventsIDs(); len(nodes) > 0 && !suo.mutation.EventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := suo.mutation.EventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   subscription.EventsTable,
+			Columns: []string{subscription.EventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Subscription{config: suo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,192 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// SubscriptionEvent is the model entity for the SubscriptionEvent schema.
+type SubscriptionEvent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Statut avant la transition (vide à la création de l'abonnement)
+	PreviousStatus *subscriptionevent.PreviousStatus `json:"previous_status,omitempty"`
+	// Statut après la transition
+	Status subscriptionevent.Status `json:"status,omitempty"`
+	// Origine de la transition
+	Source subscriptionevent.Source `json:"source,omitempty"`
+	// Détail libre : type d'événement Stripe, motif de l'admin, etc.
+	Reason string `json:"reason,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the SubscriptionEventQuery when eager-loading is set.
+	Edges               SubscriptionEventEdges `json:"edges"`
+	subscription_events *int
+	selectValues        sql.SelectValues
+}
+
+// SubscriptionEventEdges holds the relations/edges for other nodes in the graph.
+type SubscriptionEventEdges struct {
+	// Subscription holds the value of the subscription edge.
+	Subscription *Subscription `json:"subscription,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// SubscriptionOrErr returns the Subscription value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e SubscriptionEventEdges) SubscriptionOrErr() (*Subscription, error) {
+	if e.Subscription != nil {
+		return e.Subscription, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: subscription.Label}
+	}
+	return nil, &NotLoadedError{edge: "subscription"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SubscriptionEvent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case subscriptionevent.FieldID:
+			values[i] = new(sql.NullInt64)
+		case subscriptionevent.FieldPreviousStatus, subscriptionevent.FieldStatus, subscriptionevent.FieldSource, subscriptionevent.FieldReason:
+			values[i] = new(sql.NullString)
+		case subscriptionevent.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case subscriptionevent.ForeignKeys[0]: // subscription_events
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SubscriptionEvent fields.
+func (se *SubscriptionEvent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case subscriptionevent.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			se.ID = int(value.Int64)
+		case subscriptionevent.FieldPreviousStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field previous_status", values[i])
+			} else if value.Valid {
+				se.PreviousStatus = new(subscriptionevent.PreviousStatus)
+				*se.PreviousStatus = subscriptionevent.PreviousStatus(value.String)
+			}
+		case subscriptionevent.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				se.Status = subscriptionevent.Status(value.String)
+			}
+		case subscriptionevent.FieldSource:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field source", values[i])
+			} else if value.Valid {
+				se.Source = subscriptionevent.Source(value.String)
+			}
+		case subscriptionevent.FieldReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field reason", values[i])
+			} else if value.Valid {
+				se.Reason = value.String
+			}
+		case subscriptionevent.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				se.CreatedAt = value.Time
+			}
+		case subscriptionevent.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field subscription_events", value)
+			} else if value.Valid {
+				se.subscription_events = new(int)
+				*se.subscription_events = int(value.Int64)
+			}
+		default:
+			se.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SubscriptionEvent.
+// This includes values selected through modifiers, order, etc.
+func (se *SubscriptionEvent) Value(name string) (ent.Value, error) {
+	return se.selectValues.Get(name)
+}
+
+// QuerySubscription queries the "subscription" edge of the SubscriptionEvent entity.
+func (se *SubscriptionEvent) QuerySubscription() *SubscriptionQuery {
+	return NewSubscriptionEventClient(se.config).QuerySubscription(se)
+}
+
+// Update returns a builder for updating this SubscriptionEvent.
+// Note that you need to call SubscriptionEvent.Unwrap() before calling this method if this SubscriptionEvent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (se *SubscriptionEvent) Update() *SubscriptionEventUpdateOne {
+	return NewSubscriptionEventClient(se.config).UpdateOne(se)
+}
+
+// Unwrap unwraps the SubscriptionEvent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (se *SubscriptionEvent) Unwrap() *SubscriptionEvent {
+	_tx, ok := se.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SubscriptionEvent is not a transactional entity")
+	}
+	se.config.driver = _tx.drv
+	return se
+}
+
+// String implements the fmt.Stringer.
+func (se *SubscriptionEvent) String() string {
+	var builder strings.Builder
+	builder.WriteString("SubscriptionEvent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", se.ID))
+	if v := se.PreviousStatus; v != nil {
+		builder.WriteString("previous_status=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", se.Status))
+	builder.WriteString(", ")
+	builder.WriteString("source=")
+	builder.WriteString(fmt.Sprintf("%v", se.Source))
+	builder.WriteString(", ")
+	builder.WriteString("reason=")
+	builder.WriteString(se.Reason)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(se.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SubscriptionEvents is a parsable slice of SubscriptionEvent.
+type SubscriptionEvents []*SubscriptionEvent
@@ -0,0 +1,200 @@
+// Code generated by ent, DO NOT EDIT.
+
+package subscriptionevent
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the subscriptionevent type in the database.
+	Label = "subscription_event"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldPreviousStatus holds the string denoting the previous_status field in the database.
+	FieldPreviousStatus = "previous_status"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldSource holds the string denoting the source field in the database.
+	FieldSource = "source"
+	// FieldReason holds the string denoting the reason field in the database.
+	FieldReason = "reason"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgeSubscription holds the string denoting the subscription edge name in mutations.
+	EdgeSubscription = "subscription"
+	// Table holds the table name of the subscriptionevent in the database.
+	Table = "subscription_events"
+	// SubscriptionTable is the table that holds the subscription relation/edge.
+	SubscriptionTable = "subscription_events"
+	// SubscriptionInverseTable is the table name for the Subscription entity.
+	// It exists in this package in order to avoid circular dependency with the "subscription" package.
+	SubscriptionInverseTable = "subscriptions"
+	// SubscriptionColumn is the table column denoting the subscription relation/edge.
+	SubscriptionColumn = "subscription_events"
+)
+
+// Columns holds all SQL columns for subscriptionevent fields.
+var Columns = []string{
+	FieldID,
+	FieldPreviousStatus,
+	FieldStatus,
+	FieldSource,
+	FieldReason,
+	FieldCreatedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "subscription_events"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"subscription_events",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// PreviousStatus defines the type for the "previous_status" enum field.
+type PreviousStatus string
+
+// PreviousStatus values.
+const (
+	PreviousStatusTrialing PreviousStatus = "trialing"
+	PreviousStatusActive   PreviousStatus = "active"
+	PreviousStatusPastDue  PreviousStatus = "past_due"
+	PreviousStatusCanceled PreviousStatus = "canceled"
+	PreviousStatusExpired  PreviousStatus = "expired"
+	PreviousStatusPaused   PreviousStatus = "paused"
+)
+
+func (ps PreviousStatus) String() string {
+	return string(ps)
+}
+
+// PreviousStatusValidator is a validator for the "previous_status" field enum values. It is called by the builders before save.
+func PreviousStatusValidator(ps PreviousStatus) error {
+	switch ps {
+	case PreviousStatusTrialing, PreviousStatusActive, PreviousStatusPastDue, PreviousStatusCanceled, PreviousStatusExpired, PreviousStatusPaused:
+		return nil
+	default:
+		return fmt.Errorf("subscriptionevent: invalid enum value for previous_status field: %q", ps)
+	}
+}
+
+// Status defines the type for the "status" enum field.
+type Status string
+
+// Status values.
+const (
+	StatusTrialing Status = "trialing"
+	StatusActive   Status = "active"
+	StatusPastDue  Status = "past_due"
+	StatusCanceled Status = "canceled"
+	StatusExpired  Status = "expired"
+	StatusPaused   Status = "paused"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusTrialing, StatusActive, StatusPastDue, StatusCanceled, StatusExpired, StatusPaused:
+		return nil
+	default:
+		return fmt.Errorf("subscriptionevent: invalid enum value for status field: %q", s)
+	}
+}
+
+// Source defines the type for the "source" enum field.
+type Source string
+
+// Source values.
+const (
+	SourceWebhook   Source = "webhook"
+	SourceAdmin     Source = "admin"
+	SourceScheduler Source = "scheduler"
+)
+
+func (s Source) String() string {
+	return string(s)
+}
+
+// SourceValidator is a validator for the "source" field enum values. It is called by the builders before save.
+func SourceValidator(s Source) error {
+	switch s {
+	case SourceWebhook, SourceAdmin, SourceScheduler:
+		return nil
+	default:
+		return fmt.Errorf("subscriptionevent: invalid enum value for source field: %q", s)
+	}
+}
+
+// OrderOption defines the ordering options for the SubscriptionEvent queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByPreviousStatus orders the results by the previous_status field.
+func ByPreviousStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPreviousStatus, opts...).ToFunc()
+}
+
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// BySource orders the results by the source field.
+func BySource(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSource, opts...).ToFunc()
+}
+
+// ByReason orders the results by the reason field.
+func ByReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldReason, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// BySubscriptionField orders the results by subscription field.
+func BySubscriptionField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSubscriptionStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newSubscriptionStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SubscriptionInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, SubscriptionTable, SubscriptionColumn),
+	)
+}
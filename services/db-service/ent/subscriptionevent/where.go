@@ -0,0 +1,289 @@
+// Code generated by ent, DO NOT EDIT.
+
+package subscriptionevent
+
+import (
+	"db-service/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldLTE(FieldID, id))
+}
+
+// Reason applies equality check predicate on the "reason" field. It's identical to ReasonEQ.
+func Reason(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldReason, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// PreviousStatusEQ applies the EQ predicate on the "previous_status" field.
+func PreviousStatusEQ(v PreviousStatus) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldPreviousStatus, v))
+}
+
+// PreviousStatusNEQ applies the NEQ predicate on the "previous_status" field.
+func PreviousStatusNEQ(v PreviousStatus) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNEQ(FieldPreviousStatus, v))
+}
+
+// PreviousStatusIn applies the In predicate on the "previous_status" field.
+func PreviousStatusIn(vs ...PreviousStatus) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIn(FieldPreviousStatus, vs...))
+}
+
+// PreviousStatusNotIn applies the NotIn predicate on the "previous_status" field.
+func PreviousStatusNotIn(vs ...PreviousStatus) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotIn(FieldPreviousStatus, vs...))
+}
+
+// PreviousStatusIsNil applies the IsNil predicate on the "previous_status" field.
+func PreviousStatusIsNil() predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIsNull(FieldPreviousStatus))
+}
+
+// PreviousStatusNotNil applies the NotNil predicate on the "previous_status" field.
+func PreviousStatusNotNil() predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotNull(FieldPreviousStatus))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v Status) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v Status) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...Status) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...Status) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// SourceEQ applies the EQ predicate on the "source" field.
+func SourceEQ(v Source) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldSource, v))
+}
+
+// SourceNEQ applies the NEQ predicate on the "source" field.
+func SourceNEQ(v Source) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNEQ(FieldSource, v))
+}
+
+// SourceIn applies the In predicate on the "source" field.
+func SourceIn(vs ...Source) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIn(FieldSource, vs...))
+}
+
+// SourceNotIn applies the NotIn predicate on the "source" field.
+func SourceNotIn(vs ...Source) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotIn(FieldSource, vs...))
+}
+
+// ReasonEQ applies the EQ predicate on the "reason" field.
+func ReasonEQ(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldReason, v))
+}
+
+// ReasonNEQ applies the NEQ predicate on the "reason" field.
+func ReasonNEQ(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNEQ(FieldReason, v))
+}
+
+// ReasonIn applies the In predicate on the "reason" field.
+func ReasonIn(vs ...string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIn(FieldReason, vs...))
+}
+
+// ReasonNotIn applies the NotIn predicate on the "reason" field.
+func ReasonNotIn(vs ...string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotIn(FieldReason, vs...))
+}
+
+// ReasonGT applies the GT predicate on the "reason" field.
+func ReasonGT(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldGT(FieldReason, v))
+}
+
+// ReasonGTE applies the GTE predicate on the "reason" field.
+func ReasonGTE(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldGTE(FieldReason, v))
+}
+
+// ReasonLT applies the LT predicate on the "reason" field.
+func ReasonLT(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldLT(FieldReason, v))
+}
+
+// ReasonLTE applies the LTE predicate on the "reason" field.
+func ReasonLTE(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldLTE(FieldReason, v))
+}
+
+// ReasonContains applies the Contains predicate on the "reason" field.
+func ReasonContains(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldContains(FieldReason, v))
+}
+
+// ReasonHasPrefix applies the HasPrefix predicate on the "reason" field.
+func ReasonHasPrefix(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldHasPrefix(FieldReason, v))
+}
+
+// ReasonHasSuffix applies the HasSuffix predicate on the "reason" field.
+func ReasonHasSuffix(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldHasSuffix(FieldReason, v))
+}
+
+// ReasonIsNil applies the IsNil predicate on the "reason" field.
+func ReasonIsNil() predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIsNull(FieldReason))
+}
+
+// ReasonNotNil applies the NotNil predicate on the "reason" field.
+func ReasonNotNil() predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotNull(FieldReason))
+}
+
+// ReasonEqualFold applies the EqualFold predicate on the "reason" field.
+func ReasonEqualFold(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEqualFold(FieldReason, v))
+}
+
+// ReasonContainsFold applies the ContainsFold predicate on the "reason" field.
+func ReasonContainsFold(v string) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldContainsFold(FieldReason, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasSubscription applies the HasEdge predicate on the "subscription" edge.
+func HasSubscription() predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, SubscriptionTable, SubscriptionColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSubscriptionWith applies the HasEdge predicate on the "subscription" edge with a given conditions (other predicates).
+func HasSubscriptionWith(preds ...predicate.Subscription) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(func(s *sql.Selector) {
+		step := newSubscriptionStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SubscriptionEvent) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SubscriptionEvent) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SubscriptionEvent) predicate.SubscriptionEvent {
+	return predicate.SubscriptionEvent(sql.NotPredicates(p))
+}
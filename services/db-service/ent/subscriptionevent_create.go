@@ -0,0 +1,578 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// SubscriptionEventCreate is the builder for creating a SubscriptionEvent entity.
+type SubscriptionEventCreate struct {
+	config
+	mutation *SubscriptionEventMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetPreviousStatus sets the "previous_status" field.
+func (sec *SubscriptionEventCreate) SetPreviousStatus(ss subscriptionevent.PreviousStatus) *SubscriptionEventCreate {
+	sec.mutation.SetPreviousStatus(ss)
+	return sec
+}
+
+// SetNillablePreviousStatus sets the "previous_status" field if the given value is not nil.
+func (sec *SubscriptionEventCreate) SetNillablePreviousStatus(ss *subscriptionevent.PreviousStatus) *SubscriptionEventCreate {
+	if ss != nil {
+		sec.SetPreviousStatus(*ss)
+	}
+	return sec
+}
+
+// SetStatus sets the "status" field.
+func (sec *SubscriptionEventCreate) SetStatus(s subscriptionevent.Status) *SubscriptionEventCreate {
+	sec.mutation.SetStatus(s)
+	return sec
+}
+
+// SetSource sets the "source" field.
+func (sec *SubscriptionEventCreate) SetSource(s subscriptionevent.Source) *SubscriptionEventCreate {
+	sec.mutation.SetSource(s)
+	return sec
+}
+
+// SetReason sets the "reason" field.
+func (sec *SubscriptionEventCreate) SetReason(s string) *SubscriptionEventCreate {
+	sec.mutation.SetReason(s)
+	return sec
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (sec *SubscriptionEventCreate) SetNillableReason(s *string) *SubscriptionEventCreate {
+	if s != nil {
+		sec.SetReason(*s)
+	}
+	return sec
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sec *SubscriptionEventCreate) SetCreatedAt(t time.Time) *SubscriptionEventCreate {
+	sec.mutation.SetCreatedAt(t)
+	return sec
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sec *SubscriptionEventCreate) SetNillableCreatedAt(t *time.Time) *SubscriptionEventCreate {
+	if t != nil {
+		sec.SetCreatedAt(*t)
+	}
+	return sec
+}
+
+// SetSubscriptionID sets the "subscription" edge to the Subscription entity by ID.
+func (sec *SubscriptionEventCreate) SetSubscriptionID(id int) *SubscriptionEventCreate {
+	sec.mutation.SetSubscriptionID(id)
+	return sec
+}
+
+// SetSubscription sets the "subscription" edge to the Subscription entity.
+func (sec *SubscriptionEventCreate) SetSubscription(s *Subscription) *SubscriptionEventCreate {
+	return sec.SetSubscriptionID(s.ID)
+}
+
+// Mutation returns the SubscriptionEventMutation object of the builder.
+func (sec *SubscriptionEventCreate) Mutation() *SubscriptionEventMutation {
+	return sec.mutation
+}
+
+// Save creates the SubscriptionEvent in the database.
+func (sec *SubscriptionEventCreate) Save(ctx context.Context) (*SubscriptionEvent, error) {
+	sec.defaults()
+	return withHooks(ctx, sec.sqlSave, sec.mutation, sec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sec *SubscriptionEventCreate) SaveX(ctx context.Context) *SubscriptionEvent {
+	v, err := sec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sec *SubscriptionEventCreate) Exec(ctx context.Context) error {
+	_, err := sec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sec *SubscriptionEventCreate) ExecX(ctx context.Context) {
+	if err := sec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sec *SubscriptionEventCreate) defaults() {
+	if _, ok := sec.mutation.CreatedAt(); !ok {
+		v := subscriptionevent.DefaultCreatedAt()
+		sec.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sec *SubscriptionEventCreate) check() error {
+	if v, ok := sec.mutation.PreviousStatus(); ok {
+		if err := subscriptionevent.PreviousStatusValidator(v); err != nil {
+			return &ValidationError{Name: "previous_status", err: fmt.Errorf(`ent: validator failed for field "SubscriptionEvent.previous_status": %w`, err)}
+		}
+	}
+	if _, ok := sec.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "SubscriptionEvent.status"`)}
+	}
+	if v, ok := sec.mutation.Status(); ok {
+		if err := subscriptionevent.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "SubscriptionEvent.status": %w`, err)}
+		}
+	}
+	if _, ok := sec.mutation.Source(); !ok {
+		return &ValidationError{Name: "source", err: errors.New(`ent: missing required field "SubscriptionEvent.source"`)}
+	}
+	if v, ok := sec.mutation.Source(); ok {
+		if err := subscriptionevent.SourceValidator(v); err != nil {
+			return &ValidationError{Name: "source", err: fmt.Errorf(`ent: validator failed for field "SubscriptionEvent.source": %w`, err)}
+		}
+	}
+	if _, ok := sec.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SubscriptionEvent.created_at"`)}
+	}
+	if len(sec.mutation.SubscriptionIDs()) == 0 {
+		return &ValidationError{Name: "subscription", err: errors.New(`ent: missing required edge "SubscriptionEvent.subscription"`)}
+	}
+	return nil
+}
+
+func (sec *SubscriptionEventCreate) sqlSave(ctx context.Context) (*SubscriptionEvent, error) {
+	if err := sec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	sec.mutation.id = &_node.ID
+	sec.mutation.done = true
+	return _node, nil
+}
+
+func (sec *SubscriptionEventCreate) createSpec() (*SubscriptionEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SubscriptionEvent{config: sec.config}
+		_spec = sqlgraph.NewCreateSpec(subscriptionevent.Table, sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt))
+	)
+	_spec.OnConflict = sec.conflict
+	if value, ok := sec.mutation.PreviousStatus(); ok {
+		_spec.SetField(subscriptionevent.FieldPreviousStatus, field.TypeEnum, value)
+		_node.PreviousStatus = &value
+	}
+	if value, ok := sec.mutation.Status(); ok {
+		_spec.SetField(subscriptionevent.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := sec.mutation.Source(); ok {
+		_spec.SetField(subscriptionevent.FieldSource, field.TypeEnum, value)
+		_node.Source = value
+	}
+	if value, ok := sec.mutation.Reason(); ok {
+		_spec.SetField(subscriptionevent.FieldReason, field.TypeString, value)
+		_node.Reason = value
+	}
+	if value, ok := sec.mutation.CreatedAt(); ok {
+		_spec.SetField(subscriptionevent.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := sec.mutation.SubscriptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   subscriptionevent.SubscriptionTable,
+			Columns: []string{subscriptionevent.SubscriptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(subscription.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.subscription_events = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.SubscriptionEvent.Create().
+//		SetPreviousStatus(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SubscriptionEventUpsert) {
+//			SetPreviousStatus(v+v).
+//		}).
+//		Exec(ctx)
+func (sec *SubscriptionEventCreate) OnConflict(opts ...sql.ConflictOption) *SubscriptionEventUpsertOne {
+	sec.conflict = opts
+	return &SubscriptionEventUpsertOne{
+		create: sec,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.SubscriptionEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (sec *SubscriptionEventCreate) OnConflictColumns(columns ...string) *SubscriptionEventUpsertOne {
+	sec.conflict = append(sec.conflict, sql.ConflictColumns(columns...))
+	return &SubscriptionEventUpsertOne{
+		create: sec,
+	}
+}
+
+type (
+	// SubscriptionEventUpsertOne is the builder for "upsert"-ing
+	//  one SubscriptionEvent node.
+	SubscriptionEventUpsertOne struct {
+		create *SubscriptionEventCreate
+	}
+
+	// SubscriptionEventUpsert is the "OnConflict" setter.
+	SubscriptionEventUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.SubscriptionEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SubscriptionEventUpsertOne) UpdateNewValues() *SubscriptionEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.PreviousStatus(); exists {
+			s.SetIgnore(subscriptionevent.FieldPreviousStatus)
+		}
+		if _, exists := u.create.mutation.Status(); exists {
+			s.SetIgnore(subscriptionevent.FieldStatus)
+		}
+		if _, exists := u.create.mutation.Source(); exists {
+			s.SetIgnore(subscriptionevent.FieldSource)
+		}
+		if _, exists := u.create.mutation.Reason(); exists {
+			s.SetIgnore(subscriptionevent.FieldReason)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(subscriptionevent.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.SubscriptionEvent.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *SubscriptionEventUpsertOne) Ignore() *SubscriptionEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SubscriptionEventUpsertOne) DoNothing() *SubscriptionEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SubscriptionEventCreate.OnConflict
+// documentation for more info.
+func (u *SubscriptionEventUpsertOne) Update(set func(*SubscriptionEventUpsert)) *SubscriptionEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SubscriptionEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *SubscriptionEventUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SubscriptionEventCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SubscriptionEventUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *SubscriptionEventUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *SubscriptionEventUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// SubscriptionEventCreateBulk is the builder for creating many SubscriptionEvent entities in bulk.
+type SubscriptionEventCreateBulk struct {
+	config
+	err      error
+	builders []*SubscriptionEventCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the SubscriptionEvent entities in the database.
+func (secb *SubscriptionEventCreateBulk) Save(ctx context.Context) ([]*SubscriptionEvent, error) {
+	if secb.err != nil {
+		return nil, secb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(secb.builders))
+	nodes := make([]*SubscriptionEvent, len(secb.builders))
+	mutators := make([]Mutator, len(secb.builders))
+	for i := range secb.builders {
+		func(i int, root context.Context) {
+			builder := secb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SubscriptionEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, secb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = secb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, secb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, secb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (secb *SubscriptionEventCreateBulk) SaveX(ctx context.Context) []*SubscriptionEvent {
+	v, err := secb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (secb *SubscriptionEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := secb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (secb *SubscriptionEventCreateBulk) ExecX(ctx context.Context) {
+	if err := secb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.SubscriptionEvent.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SubscriptionEventUpsert) {
+//			SetPreviousStatus(v+v).
+//		}).
+//		Exec(ctx)
+func (secb *SubscriptionEventCreateBulk) OnConflict(opts ...sql.ConflictOption) *SubscriptionEventUpsertBulk {
+	secb.conflict = opts
+	return &SubscriptionEventUpsertBulk{
+		create: secb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.SubscriptionEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (secb *SubscriptionEventCreateBulk) OnConflictColumns(columns ...string) *SubscriptionEventUpsertBulk {
+	secb.conflict = append(secb.conflict, sql.ConflictColumns(columns...))
+	return &SubscriptionEventUpsertBulk{
+		create: secb,
+	}
+}
+
+// SubscriptionEventUpsertBulk is the builder for "upsert"-ing
+// a bulk of SubscriptionEvent nodes.
+type SubscriptionEventUpsertBulk struct {
+	create *SubscriptionEventCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.SubscriptionEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SubscriptionEventUpsertBulk) UpdateNewValues() *SubscriptionEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.PreviousStatus(); exists {
+				s.SetIgnore(subscriptionevent.FieldPreviousStatus)
+			}
+			if _, exists := b.mutation.Status(); exists {
+				s.SetIgnore(subscriptionevent.FieldStatus)
+			}
+			if _, exists := b.mutation.Source(); exists {
+				s.SetIgnore(subscriptionevent.FieldSource)
+			}
+			if _, exists := b.mutation.Reason(); exists {
+				s.SetIgnore(subscriptionevent.FieldReason)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(subscriptionevent.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.SubscriptionEvent.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *SubscriptionEventUpsertBulk) Ignore() *SubscriptionEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SubscriptionEventUpsertBulk) DoNothing() *SubscriptionEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SubscriptionEventCreateBulk.OnConflict
+// documentation for more info.
+func (u *SubscriptionEventUpsertBulk) Update(set func(*SubscriptionEventUpsert)) *SubscriptionEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SubscriptionEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *SubscriptionEventUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the SubscriptionEventCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SubscriptionEventCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SubscriptionEventUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
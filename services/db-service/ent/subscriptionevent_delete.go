@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/predicate"
+	"db-service/ent/subscriptionevent"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// SubscriptionEventDelete is the builder for deleting a SubscriptionEvent entity.
+type SubscriptionEventDelete struct {
+	config
+	hooks    []Hook
+	mutation *SubscriptionEventMutation
+}
+
+// Where appends a list predicates to the SubscriptionEventDelete builder.
+func (sed *SubscriptionEventDelete) Where(ps ...predicate.SubscriptionEvent) *SubscriptionEventDelete {
+	sed.mutation.Where(ps...)
+	return sed
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (sed *SubscriptionEventDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, sed.sqlExec, sed.mutation, sed.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sed *SubscriptionEventDelete) ExecX(ctx context.Context) int {
+	n, err := sed.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (sed *SubscriptionEventDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(subscriptionevent.Table, sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt))
+	if ps := sed.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, sed.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	sed.mutation.done = true
+	return affected, err
+}
+
+// SubscriptionEventDeleteOne is the builder for deleting a single SubscriptionEvent entity.
+type SubscriptionEventDeleteOne struct {
+	sed *SubscriptionEventDelete
+}
+
+// Where appends a list predicates to the SubscriptionEventDelete builder.
+func (sedo *SubscriptionEventDeleteOne) Where(ps ...predicate.SubscriptionEvent) *SubscriptionEventDeleteOne {
+	sedo.sed.mutation.Where(ps...)
+	return sedo
+}
+
+// Exec executes the deletion query.
+func (sedo *SubscriptionEventDeleteOne) Exec(ctx context.Context) error {
+	n, err := sedo.sed.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{subscriptionevent.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sedo *SubscriptionEventDeleteOne) ExecX(ctx context.Context) {
+	if err := sedo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,614 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/predicate"
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// SubscriptionEventQuery is the builder for querying SubscriptionEvent entities.
+type SubscriptionEventQuery struct {
+	config
+	ctx              *QueryContext
+	order            []subscriptionevent.OrderOption
+	inters           []Interceptor
+	predicates       []predicate.SubscriptionEvent
+	withSubscription *SubscriptionQuery
+	withFKs          bool
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the SubscriptionEventQuery builder.
+func (seq *SubscriptionEventQuery) Where(ps ...predicate.SubscriptionEvent) *SubscriptionEventQuery {
+	seq.predicates = append(seq.predicates, ps...)
+	return seq
+}
+
+// Limit the number of records to be returned by this query.
+func (seq *SubscriptionEventQuery) Limit(limit int) *SubscriptionEventQuery {
+	seq.ctx.Limit = &limit
+	return seq
+}
+
+// Offset to start from.
+func (seq *SubscriptionEventQuery) Offset(offset int) *SubscriptionEventQuery {
+	seq.ctx.Offset = &offset
+	return seq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (seq *SubscriptionEventQuery) Unique(unique bool) *SubscriptionEventQuery {
+	seq.ctx.Unique = &unique
+	return seq
+}
+
+// Order specifies how the records should be ordered.
+func (seq *SubscriptionEventQuery) Order(o ...subscriptionevent.OrderOption) *SubscriptionEventQuery {
+	seq.order = append(seq.order, o...)
+	return seq
+}
+
+// QuerySubscription chains the current query on the "subscription" edge.
+func (seq *SubscriptionEventQuery) QuerySubscription() *SubscriptionQuery {
+	query := (&SubscriptionClient{config: seq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := seq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := seq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(subscriptionevent.Table, subscriptionevent.FieldID, selector),
+			sqlgraph.To(subscription.Table, subscription.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, subscriptionevent.SubscriptionTable, subscriptionevent.SubscriptionColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(seq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first SubscriptionEvent entity from the query.
+// Returns a *NotFoundError when no SubscriptionEvent was found.
+func (seq *SubscriptionEventQuery) First(ctx context.Context) (*SubscriptionEvent, error) {
+	nodes, err := seq.Limit(1).All(setContextOp(ctx, seq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{subscriptionevent.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) FirstX(ctx context.Context) *SubscriptionEvent {
+	node, err := seq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first SubscriptionEvent ID from the query.
+// Returns a *NotFoundError when no SubscriptionEvent ID was found.
+func (seq *SubscriptionEventQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = seq.Limit(1).IDs(setContextOp(ctx, seq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{subscriptionevent.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) FirstIDX(ctx context.Context) int {
+	id, err := seq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single SubscriptionEvent entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one SubscriptionEvent entity is found.
+// Returns a *NotFoundError when no SubscriptionEvent entities are found.
+func (seq *SubscriptionEventQuery) Only(ctx context.Context) (*SubscriptionEvent, error) {
+	nodes, err := seq.Limit(2).All(setContextOp(ctx, seq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{subscriptionevent.Label}
+	default:
+		return nil, &NotSingularError{subscriptionevent.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) OnlyX(ctx context.Context) *SubscriptionEvent {
+	node, err := seq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only SubscriptionEvent ID in the query.
+// Returns a *NotSingularError when more than one SubscriptionEvent ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (seq *SubscriptionEventQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = seq.Limit(2).IDs(setContextOp(ctx, seq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{subscriptionevent.Label}
+	default:
+		err = &NotSingularError{subscriptionevent.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) OnlyIDX(ctx context.Context) int {
+	id, err := seq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of SubscriptionEvents.
+func (seq *SubscriptionEventQuery) All(ctx context.Context) ([]*SubscriptionEvent, error) {
+	ctx = setContextOp(ctx, seq.ctx, ent.OpQueryAll)
+	if err := seq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*SubscriptionEvent, *SubscriptionEventQuery]()
+	return withInterceptors[[]*SubscriptionEvent](ctx, seq, qr, seq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) AllX(ctx context.Context) []*SubscriptionEvent {
+	nodes, err := seq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of SubscriptionEvent IDs.
+func (seq *SubscriptionEventQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if seq.ctx.Unique == nil && seq.path != nil {
+		seq.Unique(true)
+	}
+	ctx = setContextOp(ctx, seq.ctx, ent.OpQueryIDs)
+	if err = seq.Select(subscriptionevent.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) IDsX(ctx context.Context) []int {
+	ids, err := seq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (seq *SubscriptionEventQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, seq.ctx, ent.OpQueryCount)
+	if err := seq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, seq, querierCount[*SubscriptionEventQuery](), seq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) CountX(ctx context.Context) int {
+	count, err := seq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (seq *SubscriptionEventQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, seq.ctx, ent.OpQueryExist)
+	switch _, err := seq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (seq *SubscriptionEventQuery) ExistX(ctx context.Context) bool {
+	exist, err := seq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the SubscriptionEventQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (seq *SubscriptionEventQuery) Clone() *SubscriptionEventQuery {
+	if seq == nil {
+		return nil
+	}
+	return &SubscriptionEventQuery{
+		config:           seq.config,
+		ctx:              seq.ctx.Clone(),
+		order:            append([]subscriptionevent.OrderOption{}, seq.order...),
+		inters:           append([]Interceptor{}, seq.inters...),
+		predicates:       append([]predicate.SubscriptionEvent{}, seq.predicates...),
+		withSubscription: seq.withSubscription.Clone(),
+		// clone intermediate query.
+		sql:  seq.sql.Clone(),
+		path: seq.path,
+	}
+}
+
+// WithSubscription tells the query-builder to eager-load the nodes that are connected to
+// the "subscription" edge. The optional arguments are used to configure the query builder of the edge.
+func (seq *SubscriptionEventQuery) WithSubscription(opts ...func(*SubscriptionQuery)) *SubscriptionEventQuery {
+	query := (&SubscriptionClient{config: seq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	seq.withSubscription = query
+	return seq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		PreviousStatus subscriptionevent.PreviousStatus `json:"previous_status,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.SubscriptionEvent.Query().
+//		GroupBy(subscriptionevent.FieldPreviousStatus).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (seq *SubscriptionEventQuery) GroupBy(field string, fields ...string) *SubscriptionEventGroupBy {
+	seq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &SubscriptionEventGroupBy{build: seq}
+	grbuild.flds = &seq.ctx.Fields
+	grbuild.label = subscriptionevent.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		PreviousStatus subscriptionevent.PreviousStatus `json:"previous_status,omitempty"`
+//	}
+//
+//	client.SubscriptionEvent.Query().
+//		Select(subscriptionevent.FieldPreviousStatus).
+//		Scan(ctx, &v)
+func (seq *SubscriptionEventQuery) Select(fields ...string) *SubscriptionEventSelect {
+	seq.ctx.Fields = append(seq.ctx.Fields, fields...)
+	sbuild := &SubscriptionEventSelect{SubscriptionEventQuery: seq}
+	sbuild.label = subscriptionevent.Label
+	sbuild.flds, sbuild.scan = &seq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a SubscriptionEventSelect configured with the given aggregations.
+func (seq *SubscriptionEventQuery) Aggregate(fns ...AggregateFunc) *SubscriptionEventSelect {
+	return seq.Select().Aggregate(fns...)
+}
+
+func (seq *SubscriptionEventQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range seq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, seq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range seq.ctx.Fields {
+		if !subscriptionevent.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if seq.path != nil {
+		prev, err := seq.path(ctx)
+		if err != nil {
+			return err
+		}
+		seq.sql = prev
+	}
+	return nil
+}
+
+func (seq *SubscriptionEventQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*SubscriptionEvent, error) {
+	var (
+		nodes       = []*SubscriptionEvent{}
+		withFKs     = seq.withFKs
+		_spec       = seq.querySpec()
+		loadedTypes = [1]bool{
+			seq.withSubscription != nil,
+		}
+	)
+	if seq.withSubscription != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, subscriptionevent.ForeignKeys...)
+	}
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*SubscriptionEvent).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &SubscriptionEvent{config: seq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, seq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := seq.withSubscription; query != nil {
+		if err := seq.loadSubscription(ctx, query, nodes, nil,
+			func(n *SubscriptionEvent, e *Subscription) { n.Edges.Subscription = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (seq *SubscriptionEventQuery) loadSubscription(ctx context.Context, query *SubscriptionQuery, nodes []*SubscriptionEvent, init func(*SubscriptionEvent), assign func(*SubscriptionEvent, *Subscription)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*SubscriptionEvent)
+	for i := range nodes {
+		if nodes[i].subscription_events == nil {
+			continue
+		}
+		fk := *nodes[i].subscription_events
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(subscription.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "subscription_events" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (seq *SubscriptionEventQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := seq.querySpec()
+	_spec.Node.Columns = seq.ctx.Fields
+	if len(seq.ctx.Fields) > 0 {
+		_spec.Unique = seq.ctx.Unique != nil && *seq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, seq.driver, _spec)
+}
+
+func (seq *SubscriptionEventQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(subscriptionevent.Table, subscriptionevent.Columns, sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt))
+	_spec.From = seq.sql
+	if unique := seq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if seq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := seq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, subscriptionevent.FieldID)
+		for i := range fields {
+			if fields[i] != subscriptionevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := seq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := seq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := seq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := seq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (seq *SubscriptionEventQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(seq.driver.Dialect())
+	t1 := builder.Table(subscriptionevent.Table)
+	columns := seq.ctx.Fields
+	if len(columns) == 0 {
+		columns = subscriptionevent.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if seq.sql != nil {
+		selector = seq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if seq.ctx.Unique != nil && *seq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range seq.predicates {
+		p(selector)
+	}
+	for _, p := range seq.order {
+		p(selector)
+	}
+	if offset := seq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := seq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// SubscriptionEventGroupBy is the group-by builder for SubscriptionEvent entities.
+type SubscriptionEventGroupBy struct {
+	selector
+	build *SubscriptionEventQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (segb *SubscriptionEventGroupBy) Aggregate(fns ...AggregateFunc) *SubscriptionEventGroupBy {
+	segb.fns = append(segb.fns, fns...)
+	return segb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (segb *SubscriptionEventGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, segb.build.ctx, ent.OpQueryGroupBy)
+	if err := segb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*SubscriptionEventQuery, *SubscriptionEventGroupBy](ctx, segb.build, segb, segb.build.inters, v)
+}
+
+func (segb *SubscriptionEventGroupBy) sqlScan(ctx context.Context, root *SubscriptionEventQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(segb.fns))
+	for _, fn := range segb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*segb.flds)+len(segb.fns))
+		for _, f := range *segb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*segb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := segb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// SubscriptionEventSelect is the builder for selecting fields of SubscriptionEvent entities.
+type SubscriptionEventSelect struct {
+	*SubscriptionEventQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ses *SubscriptionEventSelect) Aggregate(fns ...AggregateFunc) *SubscriptionEventSelect {
+	ses.fns = append(ses.fns, fns...)
+	return ses
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ses *SubscriptionEventSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ses.ctx, ent.OpQuerySelect)
+	if err := ses.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*SubscriptionEventQuery, *SubscriptionEventSelect](ctx, ses.SubscriptionEventQuery, ses, ses.inters, v)
+}
+
+func (ses *SubscriptionEventSelect) sqlScan(ctx context.Context, root *SubscriptionEventQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ses.fns))
+	for _, fn := range ses.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ses.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ses.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
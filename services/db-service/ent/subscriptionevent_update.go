@@ -0,0 +1,209 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"db-service/ent/predicate"
+	"db-service/ent/subscriptionevent"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// SubscriptionEventUpdate is the builder for updating SubscriptionEvent entities.
+type SubscriptionEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SubscriptionEventMutation
+}
+
+// Where appends a list predicates to the SubscriptionEventUpdate builder.
+func (seu *SubscriptionEventUpdate) Where(ps ...predicate.SubscriptionEvent) *SubscriptionEventUpdate {
+	seu.mutation.Where(ps...)
+	return seu
+}
+
+// Mutation returns the SubscriptionEventMutation object of the builder.
+func (seu *SubscriptionEventUpdate) Mutation() *SubscriptionEventMutation {
+	return seu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (seu *SubscriptionEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, seu.sqlSave, seu.mutation, seu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (seu *SubscriptionEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := seu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (seu *SubscriptionEventUpdate) Exec(ctx context.Context) error {
+	_, err := seu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (seu *SubscriptionEventUpdate) ExecX(ctx context.Context) {
+	if err := seu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (seu *SubscriptionEventUpdate) check() error {
+	if seu.mutation.SubscriptionCleared() && len(seu.mutation.SubscriptionIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "SubscriptionEvent.subscription"`)
+	}
+	return nil
+}
+
+func (seu *SubscriptionEventUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := seu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(subscriptionevent.Table, subscriptionevent.Columns, sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt))
+	if ps := seu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if seu.mutation.PreviousStatusCleared() {
+		_spec.ClearField(subscriptionevent.FieldPreviousStatus, field.TypeEnum)
+	}
+	if seu.mutation.ReasonCleared() {
+		_spec.ClearField(subscriptionevent.FieldReason, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, seu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{subscriptionevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	seu.mutation.done = true
+	return n, nil
+}
+
+// SubscriptionEventUpdateOne is the builder for updating a single SubscriptionEvent entity.
+type SubscriptionEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SubscriptionEventMutation
+}
+
+// Mutation returns the SubscriptionEventMutation object of the builder.
+func (seuo *SubscriptionEventUpdateOne) Mutation() *SubscriptionEventMutation {
+	return seuo.mutation
+}
+
+// Where appends a list predicates to the SubscriptionEventUpdate builder.
+func (seuo *SubscriptionEventUpdateOne) Where(ps ...predicate.SubscriptionEvent) *SubscriptionEventUpdateOne {
+	seuo.mutation.Where(ps...)
+	return seuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (seuo *SubscriptionEventUpdateOne) Select(field string, fields ...string) *SubscriptionEventUpdateOne {
+	seuo.fields = append([]string{field}, fields...)
+	return seuo
+}
+
+// Save executes the query and returns the updated SubscriptionEvent entity.
+func (seuo *SubscriptionEventUpdateOne) Save(ctx context.Context) (*SubscriptionEvent, error) {
+	return withHooks(ctx, seuo.sqlSave, seuo.mutation, seuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (seuo *SubscriptionEventUpdateOne) SaveX(ctx context.Context) *SubscriptionEvent {
+	node, err := seuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (seuo *SubscriptionEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := seuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (seuo *SubscriptionEventUpdateOne) ExecX(ctx context.Context) {
+	if err := seuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (seuo *SubscriptionEventUpdateOne) check() error {
+	if seuo.mutation.SubscriptionCleared() && len(seuo.mutation.SubscriptionIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "SubscriptionEvent.subscription"`)
+	}
+	return nil
+}
+
+func (seuo *SubscriptionEventUpdateOne) sqlSave(ctx context.Context) (_node *SubscriptionEvent, err error) {
+	if err := seuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(subscriptionevent.Table, subscriptionevent.Columns, sqlgraph.NewFieldSpec(subscriptionevent.FieldID, field.TypeInt))
+	id, ok := seuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SubscriptionEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := seuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, subscriptionevent.FieldID)
+		for _, f := range fields {
+			if !subscriptionevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != subscriptionevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := seuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if seuo.mutation.PreviousStatusCleared() {
+		_spec.ClearField(subscriptionevent.FieldPreviousStatus, field.TypeEnum)
+	}
+	if seuo.mutation.ReasonCleared() {
+		_spec.ClearField(subscriptionevent.FieldReason, field.TypeString)
+	}
+	_node = &SubscriptionEvent{config: seuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, seuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{subscriptionevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	seuo.mutation.done = true
+	return _node, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
 )
 
 // User is the model entity for the User schema.
@@ -19,11 +20,13 @@ type User struct {
 	ID int `json:"id,omitempty"`
 	// ID utilisateur fourni par Clerk
 	ClerkUserID string `json:"clerk_user_id,omitempty"`
+	// Identifiant public stable, utilisé par l'extension (settings.uuid)
+	UUID uuid.UUID `json:"uuid,omitempty"`
 	// Rôle interne de l'utilisateur
 	Role string `json:"role,omitempty"`
 	// Indique si l'utilisateur a un abonnement actif Stripe
 	IsSubscribed bool `json:"is_subscribed,omitempty"`
-	// Niveau d'abonnement : free, basic, premium, etc.
+	// Niveau d'abonnement : clé du Plan (free, basic, premium...)
 	SubscriptionTier string `json:"subscription_tier,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
@@ -66,6 +69,8 @@ func (*User) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt, user.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
+		case user.FieldUUID:
+			values[i] = new(uuid.UUID)
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -93,6 +98,12 @@ func (u *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				u.ClerkUserID = value.String
 			}
+		case user.FieldUUID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field uuid", values[i])
+			} else if value != nil {
+				u.UUID = *value
+			}
 		case user.FieldRole:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field role", values[i])
@@ -167,6 +178,9 @@ func (u *User) String() string {
 	builder.WriteString("clerk_user_id=")
 	builder.WriteString(u.ClerkUserID)
 	builder.WriteString(", ")
+	builder.WriteString("uuid=")
+	builder.WriteString(fmt.Sprintf("%v", u.UUID))
+	builder.WriteString(", ")
 	builder.WriteString("role=")
 	builder.WriteString(u.Role)
 	builder.WriteString(", ")
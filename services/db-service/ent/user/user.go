@@ -7,6 +7,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
 )
 
 const (
@@ -16,6 +17,8 @@ const (
 	FieldID = "id"
 	// FieldClerkUserID holds the string denoting the clerk_user_id field in the database.
 	FieldClerkUserID = "clerk_user_id"
+	// FieldUUID holds the string denoting the uuid field in the database.
+	FieldUUID = "uuid"
 	// FieldRole holds the string denoting the role field in the database.
 	FieldRole = "role"
 	// FieldIsSubscribed holds the string denoting the is_subscribed field in the database.
@@ -43,6 +46,7 @@ const (
 var Columns = []string{
 	FieldID,
 	FieldClerkUserID,
+	FieldUUID,
 	FieldRole,
 	FieldIsSubscribed,
 	FieldSubscriptionTier,
@@ -63,6 +67,8 @@ func ValidColumn(column string) bool {
 var (
 	// ClerkUserIDValidator is a validator for the "clerk_user_id" field. It is called by the builders before save.
 	ClerkUserIDValidator func(string) error
+	// DefaultUUID holds the default value on creation for the "uuid" field.
+	DefaultUUID func() uuid.UUID
 	// DefaultRole holds the default value on creation for the "role" field.
 	DefaultRole string
 	// DefaultIsSubscribed holds the default value on creation for the "is_subscribed" field.
@@ -90,6 +96,11 @@ func ByClerkUserID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldClerkUserID, opts...).ToFunc()
 }
 
+// ByUUID orders the results by the uuid field.
+func ByUUID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUUID, opts...).ToFunc()
+}
+
 // ByRole orders the results by the role field.
 func ByRole(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldRole, opts...).ToFunc()
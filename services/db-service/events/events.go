@@ -0,0 +1,211 @@
+// Package events makes webhook processing idempotent and ordered.
+//
+// Providers (Stripe, Clerk, MailerLite) deliver events at least once and in
+// no particular order. Process records every event in the processed_events
+// table, in the same transaction as its effects:
+//
+//   - an event already processed (or found stale) is skipped, even when two
+//     deliveries run concurrently: the second one waits on the row lock of
+//     the first and then sees it as processed;
+//   - an event older than the last one applied to the same resource (e.g. a
+//     Stripe subscription) is recorded as stale and skipped, so that a late
+//     "subscription.updated" cannot overwrite a newer state;
+//   - a failed event is recorded as failed and processed again on the next
+//     delivery.
+package events
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"db-service/ent"
+	"db-service/ent/processedevent"
+)
+
+// Providers of webhook events.
+const (
+	ProviderStripe     = "stripe"
+	ProviderClerk      = "clerk"
+	ProviderMailerLite = "mailerlite"
+)
+
+// Event identifies a webhook event.
+type Event struct {
+	Provider string
+	ID       string
+	Type     string
+	// ResourceID is the object the event is about (a Stripe subscription, a
+	// Clerk user). With CreatedAt, it enables the ordering protection.
+	ResourceID string
+	// CreatedAt is the creation time of the event at the provider.
+	CreatedAt time.Time
+}
+
+// Outcome is the result of Process.
+type Outcome string
+
+const (
+	// Processed: fn ran and its changes were committed.
+	Processed Outcome = "processed"
+	// Duplicate: the event was already processed or found stale.
+	Duplicate Outcome = "duplicate"
+	// Stale: a newer event was already applied to the same resource.
+	Stale Outcome = "stale"
+	// Failed: fn (or the bookkeeping) failed; the event will be processed
+	// again on the next delivery.
+	Failed Outcome = "failed"
+)
+
+// Now returns the current time; replaced in tests.
+var Now = time.Now
+
+// Process runs fn in a transaction, at most once per event, unless the event
+// is stale. fn must make all its changes with the transaction it receives.
+// The returned error is fn's (or a database error) when the outcome is Failed.
+func Process(ctx context.Context, client *ent.Client, ev Event, fn func(ctx context.Context, tx *ent.Tx) error) (Outcome, error) {
+	if ev.Provider == "" || ev.ID == "" {
+		return Failed, errors.New("events: missing provider or event ID")
+	}
+
+	outcome, err := process(ctx, client, ev, fn)
+	if err != nil {
+		// The transaction was rolled back: record the failure on its own
+		if rerr := recordFailure(ctx, client, ev, err); rerr != nil {
+			err = fmt.Errorf("%w (recording failure: %v)", err, rerr)
+		}
+		return Failed, err
+	}
+	return outcome, nil
+}
+
+func process(ctx context.Context, client *ent.Client, ev Event, fn func(ctx context.Context, tx *ent.Tx) error) (Outcome, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return Failed, err
+	}
+
+	outcome, err := claim(ctx, tx, ev)
+	if err == nil && outcome == Processed {
+		err = fn(ctx, tx)
+	}
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return Failed, fmt.Errorf("%w: rolling back: %v", err, rerr)
+		}
+		return Failed, err
+	}
+	if outcome == Duplicate {
+		return outcome, tx.Rollback()
+	}
+	return outcome, tx.Commit()
+}
+
+// claim records ev as processed in tx and returns Processed, unless it was
+// already handled (Duplicate) or is older than the last event applied to
+// its resource (Stale, recorded as such).
+func claim(ctx context.Context, tx *ent.Tx, ev Event) (Outcome, error) {
+	ordered := ev.ResourceID != "" && !ev.CreatedAt.IsZero()
+	if ordered {
+		// Serialize the events of a resource, so that the staleness check
+		// below cannot miss a concurrent newer event
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", ev.Provider+":"+ev.ResourceID); err != nil {
+			return Failed, err
+		}
+	}
+
+	now := Now()
+	create := tx.ProcessedEvent.Create().
+		SetProvider(ev.Provider).
+		SetEventID(ev.ID).
+		SetEventType(ev.Type).
+		SetResourceID(ev.ResourceID).
+		SetReceivedAt(now).
+		SetProcessedAt(now).
+		SetOutcome(processedevent.OutcomeProcessed)
+	if !ev.CreatedAt.IsZero() {
+		create.SetEventCreatedAt(ev.CreatedAt)
+	}
+	// A previous failed attempt is taken over; any other existing row means
+	// the event was handled, and the upsert returns no row.
+	id, err := create.
+		OnConflict(
+			sql.ConflictColumns(processedevent.FieldProvider, processedevent.FieldEventID),
+			sql.UpdateWhere(sql.EQ(sql.Table(processedevent.Table).C(processedevent.FieldOutcome), processedevent.OutcomeFailed)),
+		).
+		Update(func(u *ent.ProcessedEventUpsert) {
+			u.UpdateReceivedAt().
+				UpdateProcessedAt().
+				UpdateOutcome().
+				ClearError().
+				AddAttempts(1)
+		}).
+		ID(ctx)
+	if errors.Is(err, stdsql.ErrNoRows) {
+		return Duplicate, nil
+	}
+	if err != nil {
+		return Failed, err
+	}
+
+	if ordered {
+		newer, err := tx.ProcessedEvent.Query().
+			Where(
+				processedevent.Provider(ev.Provider),
+				processedevent.ResourceID(ev.ResourceID),
+				processedevent.OutcomeEQ(processedevent.OutcomeProcessed),
+				processedevent.EventCreatedAtGT(ev.CreatedAt),
+				processedevent.IDNEQ(id),
+			).
+			Exist(ctx)
+		if err != nil {
+			return Failed, err
+		}
+		if newer {
+			err := tx.ProcessedEvent.UpdateOneID(id).
+				SetOutcome(processedevent.OutcomeStale).
+				Exec(ctx)
+			if err != nil {
+				return Failed, err
+			}
+			return Stale, nil
+		}
+	}
+
+	return Processed, nil
+}
+
+// recordFailure stores the error of a failed attempt, without overwriting the
+// row of a concurrent successful delivery.
+func recordFailure(ctx context.Context, client *ent.Client, ev Event, cause error) error {
+	create := client.ProcessedEvent.Create().
+		SetProvider(ev.Provider).
+		SetEventID(ev.ID).
+		SetEventType(ev.Type).
+		SetResourceID(ev.ResourceID).
+		SetReceivedAt(Now()).
+		SetOutcome(processedevent.OutcomeFailed).
+		SetError(cause.Error())
+	if !ev.CreatedAt.IsZero() {
+		create.SetEventCreatedAt(ev.CreatedAt)
+	}
+	err := create.
+		OnConflict(
+			sql.ConflictColumns(processedevent.FieldProvider, processedevent.FieldEventID),
+			sql.UpdateWhere(sql.EQ(sql.Table(processedevent.Table).C(processedevent.FieldOutcome), processedevent.OutcomeFailed)),
+		).
+		Update(func(u *ent.ProcessedEventUpsert) {
+			u.UpdateReceivedAt().
+				UpdateError().
+				AddAttempts(1)
+		}).
+		Exec(ctx)
+	if errors.Is(err, stdsql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,161 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"db-service/dbtest"
+	"db-service/ent"
+	"db-service/ent/processedevent"
+	"db-service/ent/user"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := func(id string, at time.Duration) Event {
+		return Event{Provider: ProviderStripe, ID: id, Type: "customer.subscription.updated", ResourceID: "sub_1", CreatedAt: created.Add(at)}
+	}
+	errFailed := errors.New("db-service unavailable")
+
+	type delivery struct {
+		event   Event
+		fail    bool // fn returns errFailed
+		outcome Outcome
+		ran     bool // fn's changes were committed
+	}
+	tests := []struct {
+		name       string
+		deliveries []delivery
+		outcome    processedevent.Outcome // stored for the last event
+		attempts   int
+	}{
+		{
+			name:       "processed",
+			deliveries: []delivery{{event: event("evt_1", 0), outcome: Processed, ran: true}},
+			outcome:    processedevent.OutcomeProcessed,
+			attempts:   1,
+		},
+		{
+			name: "duplicate",
+			deliveries: []delivery{
+				{event: event("evt_1", 0), outcome: Processed, ran: true},
+				{event: event("evt_1", 0), outcome: Duplicate},
+			},
+			outcome:  processedevent.OutcomeProcessed,
+			attempts: 1,
+		},
+		{
+			name: "out of order",
+			deliveries: []delivery{
+				{event: event("evt_2", time.Minute), outcome: Processed, ran: true},
+				{event: event("evt_1", 0), outcome: Stale},
+			},
+			outcome:  processedevent.OutcomeStale,
+			attempts: 1,
+		},
+		{
+			name: "stale redelivered",
+			deliveries: []delivery{
+				{event: event("evt_2", time.Minute), outcome: Processed, ran: true},
+				{event: event("evt_1", 0), outcome: Stale},
+				{event: event("evt_1", 0), outcome: Duplicate},
+			},
+			outcome:  processedevent.OutcomeStale,
+			attempts: 1,
+		},
+		{
+			name: "in order",
+			deliveries: []delivery{
+				{event: event("evt_1", 0), outcome: Processed, ran: true},
+				{event: event("evt_2", time.Minute), outcome: Processed, ran: true},
+			},
+			outcome:  processedevent.OutcomeProcessed,
+			attempts: 1,
+		},
+		{
+			name: "other resource",
+			deliveries: []delivery{
+				{event: event("evt_2", time.Minute), outcome: Processed, ran: true},
+				{event: Event{Provider: ProviderStripe, ID: "evt_1", ResourceID: "sub_2", CreatedAt: created}, outcome: Processed, ran: true},
+			},
+			outcome:  processedevent.OutcomeProcessed,
+			attempts: 1,
+		},
+		{
+			name:       "failed",
+			deliveries: []delivery{{event: event("evt_1", 0), fail: true, outcome: Failed}},
+			outcome:    processedevent.OutcomeFailed,
+			attempts:   1,
+		},
+		{
+			name: "failed then retried",
+			deliveries: []delivery{
+				{event: event("evt_1", 0), fail: true, outcome: Failed},
+				{event: event("evt_1", 0), fail: true, outcome: Failed},
+				{event: event("evt_1", 0), outcome: Processed, ran: true},
+				{event: event("evt_1", 0), outcome: Duplicate},
+			},
+			outcome:  processedevent.OutcomeProcessed,
+			attempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dbtest.Open(t)
+
+			var last Event
+			for i, d := range tt.deliveries {
+				last = d.event
+				// Each delivery creates a user named after its index
+				clerkID := string(rune('a' + i))
+				outcome, err := Process(ctx, client, d.event, func(ctx context.Context, tx *ent.Tx) error {
+					if err := tx.User.Create().SetClerkUserID(clerkID).Exec(ctx); err != nil {
+						return err
+					}
+					if d.fail {
+						return errFailed
+					}
+					return nil
+				})
+				if outcome != d.outcome {
+					t.Errorf("delivery %d: outcome = %s, want %s", i, outcome, d.outcome)
+				}
+				if d.fail != errors.Is(err, errFailed) || (!d.fail && err != nil) {
+					t.Errorf("delivery %d: error = %v", i, err)
+				}
+				if ran := client.User.Query().Where(user.ClerkUserID(clerkID)).ExistX(ctx); ran != d.ran {
+					t.Errorf("delivery %d: changes committed = %v, want %v", i, ran, d.ran)
+				}
+			}
+
+			row := client.ProcessedEvent.Query().
+				Where(processedevent.Provider(last.Provider), processedevent.EventID(last.ID)).
+				OnlyX(ctx)
+			if row.Outcome != tt.outcome || row.Attempts != tt.attempts {
+				t.Errorf("stored (%s, %d attempt(s)), want (%s, %d)", row.Outcome, row.Attempts, tt.outcome, tt.attempts)
+			}
+			wantError := ""
+			if tt.outcome == processedevent.OutcomeFailed {
+				wantError = errFailed.Error()
+			}
+			if row.Error != wantError {
+				t.Errorf("stored error = %q, want %q", row.Error, wantError)
+			}
+		})
+	}
+}
+
+func TestProcessMissingID(t *testing.T) {
+	client := dbtest.Open(t)
+	ran := false
+	outcome, err := Process(context.Background(), client, Event{Provider: ProviderClerk}, func(context.Context, *ent.Tx) error {
+		ran = true
+		return nil
+	})
+	if outcome != Failed || err == nil || ran {
+		t.Errorf("Process = (%s, %v), ran = %v, want a failure without running fn", outcome, err, ran)
+	}
+}
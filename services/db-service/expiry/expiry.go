@@ -0,0 +1,247 @@
+// Package expiry expires the subscriptions whose period ended without any
+// webhook acting on it.
+//
+// Stripe normally reports the end of a subscription with a webhook; when
+// that webhook is lost, a canceled or past_due subscription would keep its
+// tier forever. The Job periodically moves those subscriptions to expired
+// once their period end plus the grace period of its tiers.Policy has
+// passed (recording a SubscriptionEvent with the scheduler source), and
+// recomputes the tier of the users still marked as subscribed through a
+// lapsed subscription.
+//
+// Several db-service instances may run the Job: each batch runs in a
+// transaction holding a Postgres advisory lock, and an instance that cannot
+// take it leaves the work to the one holding it.
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"db-service/ent"
+	"db-service/ent/subscription"
+	"db-service/ent/user"
+	"db-service/lifecycle"
+	"db-service/tiers"
+)
+
+// DefaultInterval is the time between two runs of the Job.
+const DefaultInterval = 15 * time.Minute
+
+// lockKey names the advisory lock shared by the instances.
+const lockKey = "db-service:expiry"
+
+const defaultBatchSize = 100
+
+// expirable lists the statuses moved to expired once their period lapsed.
+// Active and trialing subscriptions are renewed by Stripe and only lose
+// their tier (see tiers.Policy.Entitles) until a webhook says otherwise.
+var expirable = []subscription.Status{
+	subscription.StatusCanceled,
+	subscription.StatusPastDue,
+}
+
+// Job expires lapsed subscriptions.
+type Job struct {
+	Client *ent.Client
+	// Interval between two runs; DefaultInterval when zero.
+	Interval time.Duration
+	// BatchSize is the number of subscriptions handled per transaction.
+	BatchSize int
+	// Policy gives the grace period and the clock; it must be the one of the
+	// client's tiers hook.
+	Policy tiers.Policy
+}
+
+// Result reports what a run did.
+type Result struct {
+	// Expired is the number of subscriptions moved to expired.
+	Expired int
+	// Recomputed is the number of users whose tier changed without any of
+	// their subscriptions expiring (those are recomputed by tiers.Hook).
+	Recomputed int
+	// Skipped is set when another instance held the lock.
+	Skipped bool
+}
+
+// Run runs the Job immediately, then every Interval until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	interval := j.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := j.RunOnce(ctx)
+		switch {
+		case err != nil:
+			log.Printf("Subscription expiry failed: %v", err)
+		case res.Expired > 0 || res.Recomputed > 0:
+			log.Printf("Subscription expiry: %d subscription(s) expired, %d other user(s) downgraded", res.Expired, res.Recomputed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce expires the lapsed subscriptions and recomputes the affected users.
+func (j *Job) RunOnce(ctx context.Context) (Result, error) {
+	var res Result
+	now := j.Policy.Now()
+	cutoff := now.Add(-j.Policy.GracePeriod)
+
+	for {
+		n, locked, err := j.batch(ctx, func(tx *ent.Tx) (int, error) {
+			return expireBatch(ctx, tx, cutoff, j.batchSize())
+		})
+		if err != nil {
+			return res, err
+		}
+		if !locked {
+			res.Skipped = true
+			return res, nil
+		}
+		res.Expired += n
+		if n < j.batchSize() {
+			break
+		}
+	}
+
+	lastID := 0
+	for {
+		var ids []int
+		_, locked, err := j.batch(ctx, func(tx *ent.Tx) (int, error) {
+			var err error
+			ids, err = lapsedUsers(ctx, tx, cutoff, lastID, j.batchSize())
+			if err != nil {
+				return 0, err
+			}
+			for _, id := range ids {
+				changed, err := j.Policy.Recompute(ctx, tx.Client(), id, now)
+				if err != nil {
+					return 0, err
+				}
+				if changed {
+					res.Recomputed++
+				}
+			}
+			return len(ids), nil
+		})
+		if err != nil || !locked {
+			res.Skipped = !locked
+			return res, err
+		}
+		if len(ids) < j.batchSize() {
+			return res, nil
+		}
+		lastID = ids[len(ids)-1]
+	}
+}
+
+// batch runs fn in a transaction holding the advisory lock. It returns
+// locked=false, without running fn, when another instance holds the lock.
+func (j *Job) batch(ctx context.Context, fn func(tx *ent.Tx) (int, error)) (int, bool, error) {
+	tx, err := j.Client.Tx(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	locked, err := tryLock(ctx, tx)
+	if err == nil && !locked {
+		return 0, false, tx.Rollback()
+	}
+	var n int
+	if err == nil {
+		n, err = fn(tx)
+	}
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return 0, locked, fmt.Errorf("%w: rolling back: %v", err, rerr)
+		}
+		return 0, locked, err
+	}
+	return n, true, tx.Commit()
+}
+
+// tryLock takes the advisory lock until the end of tx, without waiting.
+func tryLock(ctx context.Context, tx *ent.Tx) (bool, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT pg_try_advisory_xact_lock(hashtext($1))", lockKey)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var locked bool
+	if rows.Next() {
+		if err := rows.Scan(&locked); err != nil {
+			return false, err
+		}
+	}
+	return locked, rows.Err()
+}
+
+// expireBatch moves up to limit lapsed subscriptions to expired.
+func expireBatch(ctx context.Context, tx *ent.Tx, cutoff time.Time, limit int) (int, error) {
+	subs, err := tx.Subscription.Query().
+		Where(
+			subscription.StatusIn(expirable...),
+			subscription.CurrentPeriodEndNotNil(),
+			subscription.CurrentPeriodEndLTE(cutoff),
+		).
+		Order(ent.Asc(subscription.FieldID)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, sub := range subs {
+		reason := fmt.Sprintf("period ended %s", sub.CurrentPeriodEnd.UTC().Format(time.RFC3339))
+		err := tx.Subscription.UpdateOne(sub).
+			SetStatus(subscription.StatusExpired).
+			Exec(lifecycle.WithSource(ctx, lifecycle.SourceScheduler, reason))
+		// A webhook may have changed the subscription since it was read
+		if ent.IsNotFound(err) || lifecycle.IsTransitionError(err) || errors.Is(err, lifecycle.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("expiring subscription %d: %w", sub.ID, err)
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// lapsedUsers returns up to limit users after lastID still marked as
+// subscribed while one of their subscriptions lapsed.
+func lapsedUsers(ctx context.Context, tx *ent.Tx, cutoff time.Time, lastID, limit int) ([]int, error) {
+	return tx.User.Query().
+		Where(
+			user.IDGT(lastID),
+			user.IsSubscribed(true),
+			user.HasSubscriptionWith(
+				subscription.CurrentPeriodEndNotNil(),
+				subscription.CurrentPeriodEndLTE(cutoff),
+			),
+		).
+		Order(ent.Asc(user.FieldID)).
+		Limit(limit).
+		IDs(ctx)
+}
+
+func (j *Job) batchSize() int {
+	if j.BatchSize > 0 {
+		return j.BatchSize
+	}
+	return defaultBatchSize
+}
@@ -0,0 +1,92 @@
+package expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"db-service/dbtest"
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+	"db-service/lifecycle"
+	"db-service/tiers"
+)
+
+func TestRunOnce(t *testing.T) {
+	ctx := lifecycle.WithSource(context.Background(), lifecycle.SourceWebhook, "test")
+	end := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := end.Add(-time.Hour)
+	policy := tiers.Policy{GracePeriod: 24 * time.Hour, Clock: func() time.Time { return now }}
+
+	client := dbtest.Open(t)
+	client.Subscription.Use(lifecycle.Hook(), policy.Hook())
+
+	subscribe := func(clerkID string, status subscription.Status) (int, int) {
+		t.Helper()
+		u := client.User.Create().SetClerkUserID(clerkID).SaveX(ctx)
+		sub := client.Subscription.Create().
+			SetUserID(u.ID).
+			SetStripeCustomerID("cus_" + clerkID).
+			SetStripeSubscriptionID("sub_" + clerkID).
+			SetStatus(status).
+			SetTier("premium").
+			SetCurrentPeriodEnd(end).
+			SaveX(ctx)
+		return u.ID, sub.ID
+	}
+	canceledUser, canceled := subscribe("canceled", subscription.StatusCanceled)
+	activeUser, active := subscribe("active", subscription.StatusActive)
+
+	job := &Job{Client: client, Policy: policy, BatchSize: 1}
+	tests := []struct {
+		name       string
+		now        time.Time
+		want       Result
+		status     subscription.Status
+		subscribed bool
+	}{
+		{name: "within the period", now: end.Add(-time.Minute), status: subscription.StatusCanceled, subscribed: true},
+		{name: "within the grace period", now: end.Add(24*time.Hour - time.Second), status: subscription.StatusCanceled, subscribed: true},
+		{name: "grace period over", now: end.Add(24 * time.Hour), want: Result{Expired: 1, Recomputed: 1}, status: subscription.StatusExpired},
+		{name: "next run", now: end.Add(48 * time.Hour), status: subscription.StatusExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = tt.now
+			res, err := job.RunOnce(context.Background())
+			if err != nil {
+				t.Fatalf("RunOnce: %v", err)
+			}
+			if res != tt.want {
+				t.Errorf("RunOnce = %+v, want %+v", res, tt.want)
+			}
+			if got := client.Subscription.GetX(ctx, canceled).Status; got != tt.status {
+				t.Errorf("canceled subscription status = %s, want %s", got, tt.status)
+			}
+			// Renewed by Stripe: never expired by the job, only downgraded
+			if got := client.Subscription.GetX(ctx, active).Status; got != subscription.StatusActive {
+				t.Errorf("active subscription status = %s, want active", got)
+			}
+			for _, id := range []int{canceledUser, activeUser} {
+				u := client.User.GetX(ctx, id)
+				wantTier := tiers.Free
+				if tt.subscribed {
+					wantTier = "premium"
+				}
+				if u.IsSubscribed != tt.subscribed || u.SubscriptionTier != wantTier {
+					t.Errorf("user %d = (%v, %s), want (%v, %s)", id, u.IsSubscribed, u.SubscriptionTier, tt.subscribed, wantTier)
+				}
+			}
+		})
+	}
+
+	n := client.SubscriptionEvent.Query().
+		Where(
+			subscriptionevent.SourceEQ(subscriptionevent.SourceScheduler),
+			subscriptionevent.StatusEQ(subscriptionevent.StatusExpired),
+		).
+		CountX(ctx)
+	if n != 1 {
+		t.Errorf("%d expiry event(s) recorded, want 1", n)
+	}
+}
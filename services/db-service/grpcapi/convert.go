@@ -0,0 +1,86 @@
+package grpcapi
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"db-service/ent"
+	"shared/dbservicepb"
+)
+
+func toUser(u *ent.User) *dbservicepb.User {
+	if u == nil {
+		return nil
+	}
+	return &dbservicepb.User{
+		Id:               int64(u.ID),
+		ClerkUserId:      u.ClerkUserID,
+		Uuid:             u.UUID.String(),
+		Role:             u.Role,
+		IsSubscribed:     u.IsSubscribed,
+		SubscriptionTier: u.SubscriptionTier,
+		CreatedAt:        timestamppb.New(u.CreatedAt),
+		UpdatedAt:        timestamppb.New(u.UpdatedAt),
+	}
+}
+
+func toSubscription(s *ent.Subscription) *dbservicepb.Subscription {
+	pb := &dbservicepb.Subscription{
+		Id:                   int64(s.ID),
+		StripeCustomerId:     s.StripeCustomerID,
+		StripeSubscriptionId: s.StripeSubscriptionID,
+		Status:               string(s.Status),
+		Tier:                 s.Tier,
+		User:                 toUser(s.Edges.User),
+	}
+	if !s.CurrentPeriodEnd.IsZero() {
+		pb.CurrentPeriodEnd = timestamppb.New(s.CurrentPeriodEnd)
+	}
+	return pb
+}
+
+func toSubscriptions(subs []*ent.Subscription) *dbservicepb.ListSubscriptionsResponse {
+	resp := &dbservicepb.ListSubscriptionsResponse{}
+	for _, s := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, toSubscription(s))
+	}
+	return resp
+}
+
+// nextCursor returns the next_cursor of a page, empty on the last one.
+func nextCursor(c *string) string {
+	if c == nil {
+		return ""
+	}
+	return *c
+}
+
+func toSubscriptionEvent(e *ent.SubscriptionEvent) *dbservicepb.SubscriptionEvent {
+	pb := &dbservicepb.SubscriptionEvent{
+		Id:        int64(e.ID),
+		Status:    string(e.Status),
+		Source:    string(e.Source),
+		Reason:    e.Reason,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.PreviousStatus != nil {
+		pb.PreviousStatus = string(*e.PreviousStatus)
+	}
+	if e.Edges.Subscription != nil {
+		pb.SubscriptionId = int64(e.Edges.Subscription.ID)
+	}
+	return pb
+}
+
+func toPlan(p *ent.Plan) *dbservicepb.Plan {
+	pb := &dbservicepb.Plan{
+		Id:             int64(p.ID),
+		Key:            p.Key,
+		Name:           p.Name,
+		StripePriceIds: p.StripePriceIds,
+		Rank:           int64(p.Rank),
+	}
+	for _, e := range p.Edges.Entitlements {
+		pb.Entitlements = append(pb.Entitlements, &dbservicepb.Entitlement{Key: e.Key, Enabled: e.Enabled, Limit: e.Limit})
+	}
+	return pb
+}
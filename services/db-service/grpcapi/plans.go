@@ -0,0 +1,84 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"db-service/ent"
+	"db-service/ent/user"
+	"db-service/plans"
+	"shared/dbservicepb"
+)
+
+// planServer mirrors handlers/plans.
+type planServer struct {
+	dbservicepb.UnimplementedPlanServiceServer
+	client *ent.Client
+}
+
+func (s *planServer) ListPlans(ctx context.Context, _ *dbservicepb.ListPlansRequest) (*dbservicepb.ListPlansResponse, error) {
+	all, err := plans.List(ctx, s.client)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve plans")
+	}
+
+	resp := &dbservicepb.ListPlansResponse{}
+	for _, p := range all {
+		resp.Plans = append(resp.Plans, toPlan(p))
+	}
+	return resp, nil
+}
+
+func (s *planServer) GetPlan(ctx context.Context, req *dbservicepb.GetPlanRequest) (*dbservicepb.Plan, error) {
+	p, err := plans.Get(ctx, s.client, req.GetKey())
+	if err != nil {
+		return nil, entError(err, "Plan not found", "Failed to retrieve plan")
+	}
+	return toPlan(p), nil
+}
+
+func (s *planServer) GetPlanByPriceID(ctx context.Context, req *dbservicepb.GetPlanByPriceIDRequest) (*dbservicepb.Plan, error) {
+	if req.GetStripePriceId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "stripe_price_id is required")
+	}
+	p, err := plans.ByPriceID(ctx, s.client, req.GetStripePriceId())
+	if err != nil {
+		return nil, entError(err, "Plan not found", "Failed to retrieve plan")
+	}
+	return toPlan(p), nil
+}
+
+func (s *planServer) GetUserEntitlements(ctx context.Context, req *dbservicepb.GetUserEntitlementsRequest) (*dbservicepb.Entitlements, error) {
+	v := viewer(ctx)
+
+	var u *ent.User
+	var err error
+	switch {
+	case req.GetUserId() != 0:
+		if err := requireSelfOrAdmin(ctx, req.GetUserId()); err != nil {
+			return nil, err
+		}
+		u, err = s.client.User.Get(ctx, int(req.GetUserId()))
+	case req.GetClerkUserId() != "":
+		if !v.IsAdmin() && (v == nil || v.ClerkUserID != req.GetClerkUserId()) {
+			return nil, status.Error(codes.PermissionDenied, "Cannot access another user's record")
+		}
+		u, err = s.client.User.Query().Where(user.ClerkUserID(req.GetClerkUserId())).Only(ctx)
+	default:
+		if v == nil || v.User == nil {
+			return nil, status.Error(codes.NotFound, "User not found")
+		}
+		u = v.User
+	}
+	if err != nil {
+		return nil, entError(err, "User not found", "Failed to retrieve entitlements")
+	}
+
+	set, err := plans.ForUser(ctx, s.client, u)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve entitlements")
+	}
+	return set.Proto(), nil
+}
@@ -0,0 +1,133 @@
+// Package grpcapi serves the gRPC API of db-service (see shared/dbservicepb)
+// with the same ent client and authorization rules as the REST handlers.
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"db-service/ent"
+	"db-service/lifecycle"
+	"db-service/middleware"
+	"shared/dbservicepb"
+	"shared/jwtauth"
+)
+
+// NewServer returns a gRPC server exposing the user, subscription and plan services.
+// apiKeys maps the API keys of internal services to their names (see ParseAPIKeys).
+func NewServer(client *ent.Client, verifier *jwtauth.Verifier, apiKeys map[string]string) *grpc.Server {
+	srv := grpc.NewServer(grpc.UnaryInterceptor(authInterceptor(client, verifier, apiKeys)))
+	dbservicepb.RegisterUserServiceServer(srv, &userServer{client: client})
+	dbservicepb.RegisterSubscriptionServiceServer(srv, &subscriptionServer{client: client})
+	dbservicepb.RegisterPlanServiceServer(srv, &planServer{client: client})
+	return srv
+}
+
+// ParseAPIKeys parses the SERVICE_API_KEYS setting: a comma-separated list of
+// name=key pairs, one per internal service (e.g. "payment-service=s3cr3t").
+func ParseAPIKeys(s string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, key, ok := strings.Cut(pair, "=")
+		if !ok || name == "" || len(key) < 16 {
+			return nil, fmt.Errorf("invalid API key entry %q: expected name=key with a key of at least 16 characters", name)
+		}
+		keys[key] = name
+	}
+	return keys, nil
+}
+
+// authInterceptor is the gRPC counterpart of jwtauth.Middleware and
+// middleware.ViewerMiddleware: it verifies the bearer token of the
+// `authorization` metadata and stores the caller in the context. Internal
+// services authenticate with the `x-api-key` metadata instead.
+func authInterceptor(client *ent.Client, verifier *jwtauth.Verifier, apiKeys map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			service := serviceFor(apiKeys, values[0])
+			if service == "" {
+				return nil, status.Error(codes.Unauthenticated, "invalid_api_key")
+			}
+			return handler(middleware.WithViewer(ctx, &middleware.Viewer{Service: service}), req)
+		}
+
+		var token string
+		if values := md.Get("authorization"); len(values) > 0 {
+			token, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing_token")
+		}
+
+		principal, err := verifier.Verify(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid_token")
+		}
+
+		viewer, err := middleware.LoadViewer(ctx, client, principal.UserID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal_error")
+		}
+
+		return handler(middleware.WithViewer(ctx, viewer), req)
+	}
+}
+
+// serviceFor returns the service owning key, or "" when it is unknown.
+// Every key is compared in constant time.
+func serviceFor(apiKeys map[string]string, key string) string {
+	service := ""
+	for k, name := range apiKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			service = name
+		}
+	}
+	return service
+}
+
+func viewer(ctx context.Context) *middleware.Viewer {
+	return middleware.ViewerFromContext(ctx)
+}
+
+func requireAdmin(ctx context.Context) error {
+	if !viewer(ctx).IsAdmin() {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return nil
+}
+
+func requireSelfOrAdmin(ctx context.Context, userID int64) error {
+	if !viewer(ctx).CanAccess(int(userID)) {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return nil
+}
+
+// entError maps ent errors to gRPC statuses; msg is used for unexpected errors.
+func entError(err error, notFound, msg string) error {
+	switch {
+	case ent.IsNotFound(err):
+		return status.Error(codes.NotFound, notFound)
+	case ent.IsConstraintError(err):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case ent.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case lifecycle.IsTransitionError(err), errors.Is(err, lifecycle.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
@@ -0,0 +1,256 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"db-service/ent"
+	"db-service/ent/predicate"
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+	"db-service/ent/user"
+	"db-service/events"
+	"db-service/lifecycle"
+	"db-service/pagination"
+	"shared/dbservicepb"
+)
+
+// subscriptionServer mirrors handlers/subscriptions.
+type subscriptionServer struct {
+	dbservicepb.UnimplementedSubscriptionServiceServer
+	client *ent.Client
+}
+
+func (s *subscriptionServer) CreateSubscription(ctx context.Context, req *dbservicepb.CreateSubscriptionRequest) (*dbservicepb.Subscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == 0 || req.GetStripeCustomerId() == "" || req.GetStripeSubscriptionId() == "" || req.GetStatus() == "" || req.GetTier() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id, stripe_customer_id, stripe_subscription_id, status and tier are required")
+	}
+	st, err := lifecycle.ParseStatus(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	source, err := lifecycle.ParseSource(req.GetSource())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ev, err := webhookEvent(req.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+	ctx = lifecycle.WithSource(ctx, source, req.GetReason())
+
+	exists, err := s.client.User.Query().Where(user.ID(int(req.GetUserId()))).Exist(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to create subscription")
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+
+	var created *ent.Subscription
+	outcome, err := s.write(ctx, ev, func(tx *ent.Tx) error {
+		create := tx.Subscription.
+			Create().
+			SetUserID(int(req.GetUserId())).
+			SetStripeCustomerID(req.GetStripeCustomerId()).
+			SetStripeSubscriptionID(req.GetStripeSubscriptionId()).
+			SetStatus(st).
+			SetTier(req.GetTier())
+		if req.CurrentPeriodEnd != nil {
+			create.SetCurrentPeriodEnd(req.GetCurrentPeriodEnd().AsTime())
+		}
+
+		var err error
+		created, err = create.Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, entError(err, "User not found", "Failed to create subscription")
+	}
+	if outcome != events.Processed {
+		return s.GetSubscriptionByStripeID(ctx, &dbservicepb.GetSubscriptionByStripeIDRequest{
+			StripeSubscriptionId: req.GetStripeSubscriptionId(),
+		})
+	}
+	return s.load(ctx, created.ID)
+}
+
+func (s *subscriptionServer) GetSubscriptionByStripeID(ctx context.Context, req *dbservicepb.GetSubscriptionByStripeIDRequest) (*dbservicepb.Subscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.client.Subscription.
+		Query().
+		Where(subscription.StripeSubscriptionID(req.GetStripeSubscriptionId())).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		return nil, entError(err, "Subscription not found", "Failed to retrieve subscription")
+	}
+	return toSubscription(sub), nil
+}
+
+func (s *subscriptionServer) GetUserSubscriptions(ctx context.Context, req *dbservicepb.GetUserSubscriptionsRequest) (*dbservicepb.ListSubscriptionsResponse, error) {
+	if err := requireSelfOrAdmin(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	subs, err := s.client.Subscription.
+		Query().
+		Where(subscription.HasUserWith(user.ID(int(req.GetUserId())))).
+		WithUser().
+		Order(ent.Desc(subscription.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve subscriptions")
+	}
+	return toSubscriptions(subs), nil
+}
+
+func (s *subscriptionServer) UpdateSubscriptionStatus(ctx context.Context, req *dbservicepb.UpdateSubscriptionStatusRequest) (*dbservicepb.Subscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetStatus() == "" {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+	st, err := lifecycle.ParseStatus(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	source, err := lifecycle.ParseSource(req.GetSource())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ev, err := webhookEvent(req.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = lifecycle.WithSource(ctx, source, req.GetReason())
+	_, err = s.write(ctx, ev, func(tx *ent.Tx) error {
+		updater := tx.Subscription.
+			UpdateOneID(int(req.GetId())).
+			SetStatus(st)
+		if req.CurrentPeriodEnd != nil {
+			updater.SetCurrentPeriodEnd(req.GetCurrentPeriodEnd().AsTime())
+		}
+		if req.Tier != nil {
+			updater.SetTier(req.GetTier())
+		}
+		return updater.Exec(ctx)
+	})
+	if err != nil {
+		return nil, entError(err, "Subscription not found", "Failed to update subscription")
+	}
+	return s.load(ctx, int(req.GetId()))
+}
+
+func (s *subscriptionServer) ListSubscriptions(ctx context.Context, req *dbservicepb.ListSubscriptionsRequest) (*dbservicepb.ListSubscriptionsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	p, err := pagination.NewParams(int(req.GetLimit()), req.GetCursor(), false)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	query := s.client.Subscription.
+		Query().
+		WithUser().
+		Where(predicate.Subscription(p.Predicate("", subscription.FieldID))).
+		Order(subscription.ByID(p.OrderTerm())).
+		Limit(p.Limit + 1)
+	if req.GetStatus() != "" {
+		st, err := lifecycle.ParseStatus(req.GetStatus())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.Where(subscription.StatusEQ(st))
+	}
+
+	subs, err := query.All(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve subscriptions")
+	}
+
+	page := pagination.NewPage(subs, p, func(s *ent.Subscription) pagination.Cursor {
+		return pagination.Cursor{ID: s.ID}
+	})
+	resp := toSubscriptions(page.Data)
+	resp.NextCursor = nextCursor(page.NextCursor)
+	return resp, nil
+}
+
+func (s *subscriptionServer) GetUserSubscriptionEvents(ctx context.Context, req *dbservicepb.GetUserSubscriptionEventsRequest) (*dbservicepb.ListSubscriptionEventsResponse, error) {
+	if err := requireSelfOrAdmin(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	history, err := s.client.SubscriptionEvent.
+		Query().
+		Where(subscriptionevent.HasSubscriptionWith(subscription.HasUserWith(user.ID(int(req.GetUserId()))))).
+		WithSubscription().
+		Order(ent.Desc(subscriptionevent.FieldCreatedAt), ent.Desc(subscriptionevent.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve subscription events")
+	}
+
+	resp := &dbservicepb.ListSubscriptionEventsResponse{}
+	for _, e := range history {
+		resp.Events = append(resp.Events, toSubscriptionEvent(e))
+	}
+	return resp, nil
+}
+
+// webhookEvent converts the optional event a write comes from.
+func webhookEvent(ref *dbservicepb.WebhookEvent) (*events.Event, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	if ref.GetProvider() == "" || ref.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "event provider and id are required")
+	}
+	ev := &events.Event{
+		Provider:   ref.GetProvider(),
+		ID:         ref.GetId(),
+		Type:       ref.GetType(),
+		ResourceID: ref.GetResourceId(),
+	}
+	if ref.CreatedAt != nil {
+		ev.CreatedAt = ref.GetCreatedAt().AsTime()
+	}
+	return ev, nil
+}
+
+// write runs fn in a transaction. With a webhook event, fn runs at most once
+// per event and not at all for a stale one; the outcome tells which.
+func (s *subscriptionServer) write(ctx context.Context, ev *events.Event, fn func(tx *ent.Tx) error) (events.Outcome, error) {
+	if ev == nil {
+		return events.Processed, lifecycle.WithTx(ctx, s.client, fn)
+	}
+	return events.Process(ctx, s.client, *ev, func(ctx context.Context, tx *ent.Tx) error {
+		return fn(tx)
+	})
+}
+
+// load reloads a subscription with its user edge.
+func (s *subscriptionServer) load(ctx context.Context, id int) (*dbservicepb.Subscription, error) {
+	sub, err := s.client.Subscription.
+		Query().
+		Where(subscription.ID(id)).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		return nil, entError(err, "Subscription not found", "Failed to retrieve subscription")
+	}
+	return toSubscription(sub), nil
+}
@@ -0,0 +1,168 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"db-service/ent"
+	"db-service/ent/predicate"
+	"db-service/ent/user"
+	"db-service/pagination"
+	"shared/dbservicepb"
+)
+
+// userServer mirrors handlers/users.
+type userServer struct {
+	dbservicepb.UnimplementedUserServiceServer
+	client *ent.Client
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *dbservicepb.CreateUserRequest) (*dbservicepb.User, error) {
+	if req.GetClerkUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "clerk_user_id is required")
+	}
+
+	v := viewer(ctx)
+	if !v.IsAdmin() {
+		if v == nil || req.GetClerkUserId() != v.ClerkUserID {
+			return nil, status.Error(codes.PermissionDenied, "Cannot create another user's record")
+		}
+		if req.GetRole() != "" {
+			return nil, status.Error(codes.PermissionDenied, "Only admins can set the role")
+		}
+	}
+
+	create := s.client.User.
+		Create().
+		SetClerkUserID(req.GetClerkUserId())
+	if req.GetRole() != "" {
+		create.SetRole(req.GetRole())
+	}
+
+	u, err := create.Save(ctx)
+	if err != nil {
+		return nil, entError(err, "User not found", "Failed to create user")
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *dbservicepb.GetUserRequest) (*dbservicepb.User, error) {
+	if err := requireSelfOrAdmin(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	u, err := s.client.User.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, entError(err, "User not found", "Failed to retrieve user")
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) GetUserByClerkID(ctx context.Context, req *dbservicepb.GetUserByClerkIDRequest) (*dbservicepb.User, error) {
+	v := viewer(ctx)
+	if !v.IsAdmin() && (v == nil || v.ClerkUserID != req.GetClerkUserId()) {
+		return nil, status.Error(codes.PermissionDenied, "Cannot access another user's record")
+	}
+
+	u, err := s.client.User.
+		Query().
+		Where(user.ClerkUserID(req.GetClerkUserId())).
+		Only(ctx)
+	if err != nil {
+		return nil, entError(err, "User not found", "Failed to retrieve user")
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *dbservicepb.ListUsersRequest) (*dbservicepb.ListUsersResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	p, err := pagination.NewParams(int(req.GetLimit()), req.GetCursor(), false)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	users, err := s.client.User.
+		Query().
+		Where(predicate.User(p.Predicate("", user.FieldID))).
+		Order(user.ByID(p.OrderTerm())).
+		Limit(p.Limit + 1).
+		All(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve users")
+	}
+
+	page := pagination.NewPage(users, p, func(u *ent.User) pagination.Cursor {
+		return pagination.Cursor{ID: u.ID}
+	})
+	resp := &dbservicepb.ListUsersResponse{NextCursor: nextCursor(page.NextCursor)}
+	for _, u := range page.Data {
+		resp.Users = append(resp.Users, toUser(u))
+	}
+	return resp, nil
+}
+
+func (s *userServer) GetUsersByUUID(ctx context.Context, req *dbservicepb.GetUsersByUUIDRequest) (*dbservicepb.ListUsersResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if len(req.GetUuids()) > pagination.MaxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d uuids per call", pagination.MaxLimit)
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.GetUuids()))
+	for _, v := range req.GetUuids() {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q", v)
+		}
+		ids = append(ids, id)
+	}
+
+	users, err := s.client.User.Query().Where(user.UUIDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve users")
+	}
+
+	resp := &dbservicepb.ListUsersResponse{}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toUser(u))
+	}
+	return resp, nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *dbservicepb.UpdateUserRequest) (*dbservicepb.User, error) {
+	if err := requireSelfOrAdmin(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	if !viewer(ctx).IsAdmin() && req.Role != nil {
+		return nil, status.Error(codes.PermissionDenied, "Only admins can change the role")
+	}
+
+	updater := s.client.User.UpdateOneID(int(req.GetId()))
+	if req.Role != nil {
+		updater.SetRole(req.GetRole())
+	}
+
+	u, err := updater.Save(ctx)
+	if err != nil {
+		return nil, entError(err, "User not found", "Failed to update user")
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) DeleteUser(ctx context.Context, req *dbservicepb.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.client.User.DeleteOneID(int(req.GetId())).Exec(ctx); err != nil {
+		return nil, entError(err, "User not found", "Failed to delete user")
+	}
+	return &emptypb.Empty{}, nil
+}
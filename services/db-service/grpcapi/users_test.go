@@ -0,0 +1,91 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"db-service/dbtest"
+	"db-service/middleware"
+	"shared/dbservicepb"
+)
+
+func TestListUsers(t *testing.T) {
+	ctx := middleware.WithViewer(context.Background(), &middleware.Viewer{Service: "test"})
+	client := dbtest.Open(t)
+	for i := range 5 {
+		client.User.Create().SetClerkUserID(fmt.Sprintf("user_%d", i)).ExecX(ctx)
+	}
+	s := &userServer{client: client}
+
+	var ids []int64
+	var pages int
+	req := &dbservicepb.ListUsersRequest{Limit: 2}
+	for {
+		resp, err := s.ListUsers(ctx, req)
+		if err != nil {
+			t.Fatalf("ListUsers: %v", err)
+		}
+		pages++
+		for _, u := range resp.GetUsers() {
+			ids = append(ids, u.GetId())
+		}
+		if resp.GetNextCursor() == "" {
+			break
+		}
+		req.Cursor = resp.GetNextCursor()
+	}
+	if want := []int64{1, 2, 3, 4, 5}; !slices.Equal(ids, want) || pages != 3 {
+		t.Errorf("%d page(s) of %v, want 3 of %v", pages, ids, want)
+	}
+
+	for name, req := range map[string]*dbservicepb.ListUsersRequest{
+		"limit too large": {Limit: 101},
+		"negative limit":  {Limit: -1},
+		"invalid cursor":  {Cursor: "garbage"},
+	} {
+		if _, err := s.ListUsers(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: ListUsers error = %v, want InvalidArgument", name, err)
+		}
+	}
+
+	if _, err := s.ListUsers(context.Background(), &dbservicepb.ListUsersRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListUsers without a viewer = %v, want PermissionDenied", err)
+	}
+}
+
+func TestGetUsersByUUID(t *testing.T) {
+	ctx := middleware.WithViewer(context.Background(), &middleware.Viewer{Service: "test"})
+	client := dbtest.Open(t)
+	u := client.User.Create().SetClerkUserID("user_1").SaveX(ctx)
+	client.User.Create().SetClerkUserID("user_2").ExecX(ctx)
+	s := &userServer{client: client}
+
+	resp, err := s.GetUsersByUUID(ctx, &dbservicepb.GetUsersByUUIDRequest{
+		Uuids: []string{u.UUID.String(), "00000000-0000-0000-0000-000000000000"},
+	})
+	if err != nil {
+		t.Fatalf("GetUsersByUUID: %v", err)
+	}
+	if len(resp.GetUsers()) != 1 || resp.GetUsers()[0].GetId() != int64(u.ID) {
+		t.Errorf("users = %v, want user %d only", resp.GetUsers(), u.ID)
+	}
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = u.UUID.String()
+	}
+	for name, uuids := range map[string][]string{
+		"too many":     tooMany,
+		"invalid uuid": {"not-a-uuid"},
+	} {
+		_, err := s.GetUsersByUUID(ctx, &dbservicepb.GetUsersByUUIDRequest{Uuids: uuids})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: GetUsersByUUID error = %v, want InvalidArgument", name, err)
+		}
+	}
+}
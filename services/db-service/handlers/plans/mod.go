@@ -0,0 +1,317 @@
+package plan
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+
+    "github.com/gofiber/fiber/v2"
+
+    "db-service/ent"
+    "db-service/ent/entitlement"
+    "db-service/ent/plan"
+    "db-service/middleware"
+    "db-service/plans"
+)
+
+// PlanHandler holds the ent client.
+type PlanHandler struct {
+    Client *ent.Client
+}
+
+// NewPlanHandler creates a new PlanHandler.
+func NewPlanHandler(client *ent.Client) *PlanHandler {
+    return &PlanHandler{Client: client}
+}
+
+// EntitlementInput sets an entitlement of a plan.
+type EntitlementInput struct {
+    Enabled *bool  `json:"enabled"` // true by default
+    Limit   *int64 `json:"limit"`   // null for unlimited
+}
+
+// GetEntitlements handles GET requests for the entitlements of the caller.
+func (h *PlanHandler) GetEntitlements(c *fiber.Ctx) error {
+    viewer := middleware.ViewerFrom(c)
+    if viewer == nil || viewer.User == nil {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+    }
+    return h.respondWithEntitlements(c, viewer.User)
+}
+
+// GetUserEntitlements handles GET requests for the entitlements of a user.
+func (h *PlanHandler) GetUserEntitlements(c *fiber.Ctx) error {
+    userID, err := strconv.Atoi(c.Params("user_id"))
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+    }
+
+    u, err := h.Client.User.Get(c.UserContext(), userID)
+    if ent.IsNotFound(err) {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+    }
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve entitlements"})
+    }
+    return h.respondWithEntitlements(c, u)
+}
+
+func (h *PlanHandler) respondWithEntitlements(c *fiber.Ctx, u *ent.User) error {
+    set, err := plans.ForUser(c.UserContext(), h.Client, u)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve entitlements"})
+    }
+    return c.Status(fiber.StatusOK).JSON(set)
+}
+
+// ListPlans handles GET requests for the plan catalog.
+func (h *PlanHandler) ListPlans(c *fiber.Ctx) error {
+    all, err := plans.List(c.UserContext(), h.Client)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve plans"})
+    }
+    return c.Status(fiber.StatusOK).JSON(all)
+}
+
+// GetPlan handles GET requests for a plan by key.
+func (h *PlanHandler) GetPlan(c *fiber.Ctx) error {
+    return h.respondWithPlan(c, fiber.StatusOK, c.Params("key"))
+}
+
+// CreatePlan handles POST requests to add a plan to the catalog.
+func (h *PlanHandler) CreatePlan(c *fiber.Ctx) error {
+    type CreatePlanInput struct {
+        Key            string                      `json:"key"`
+        Name           string                      `json:"name"`
+        StripePriceIDs []string                    `json:"stripe_price_ids"`
+        Rank           int                         `json:"rank"`
+        Entitlements   map[string]EntitlementInput `json:"entitlements"`
+    }
+
+    input := new(CreatePlanInput)
+    if err := c.BodyParser(input); err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+    }
+    if input.Key == "" {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "key is required"})
+    }
+
+    if !h.checkPrices(c, input.Key, input.StripePriceIDs) {
+        return nil
+    }
+
+    ctx := c.UserContext()
+
+    tx, err := h.Client.Tx(ctx)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create plan"})
+    }
+    p, err := tx.Plan.
+        Create().
+        SetKey(input.Key).
+        SetName(input.Name).
+        SetStripePriceIds(input.StripePriceIDs).
+        SetRank(input.Rank).
+        Save(ctx)
+    if err == nil {
+        for key, e := range input.Entitlements {
+            if err = setEntitlement(ctx, tx.Client(), p.ID, key, e); err != nil {
+                break
+            }
+        }
+    }
+    if err != nil {
+        _ = tx.Rollback()
+        if ent.IsConstraintError(err) {
+            return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Plan with this key already exists"})
+        }
+        if ent.IsValidationError(err) {
+            return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+        }
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create plan"})
+    }
+    if err := tx.Commit(); err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create plan"})
+    }
+
+    return h.respondWithPlan(c, fiber.StatusCreated, p.Key)
+}
+
+// UpdatePlan handles PUT requests to rename a plan, change its Stripe prices
+// or its rank. A new rank applies to the users with several subscriptions on
+// their next subscription change, or the next reconcile.
+func (h *PlanHandler) UpdatePlan(c *fiber.Ctx) error {
+    type UpdatePlanInput struct {
+        Name           *string  `json:"name"`
+        StripePriceIDs []string `json:"stripe_price_ids"` // replaces the prices when set
+        Rank           *int     `json:"rank"`
+    }
+
+    input := new(UpdatePlanInput)
+    if err := c.BodyParser(input); err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+    }
+
+    key := c.Params("key")
+    if !h.checkPrices(c, key, input.StripePriceIDs) {
+        return nil
+    }
+
+    ctx := c.UserContext()
+
+    updater := h.Client.Plan.
+        Update().
+        Where(plan.Key(key)).
+        SetNillableName(input.Name).
+        SetNillableRank(input.Rank)
+    if input.StripePriceIDs != nil {
+        updater.SetStripePriceIds(input.StripePriceIDs)
+    }
+
+    n, err := updater.Save(ctx)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update plan"})
+    }
+    if n == 0 {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Plan not found"})
+    }
+
+    return h.respondWithPlan(c, fiber.StatusOK, key)
+}
+
+// SetEntitlement handles PUT requests to grant or change an entitlement of a
+// plan. It applies to every user of the plan on their next request.
+func (h *PlanHandler) SetEntitlement(c *fiber.Ctx) error {
+    input := new(EntitlementInput)
+    if err := c.BodyParser(input); err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+    }
+
+    ctx := c.UserContext()
+    p, err := h.Client.Plan.Query().Where(plan.Key(c.Params("key"))).Only(ctx)
+    if ent.IsNotFound(err) {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Plan not found"})
+    }
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update entitlement"})
+    }
+
+    err = setEntitlement(ctx, h.Client, p.ID, c.Params("entitlement"), *input)
+    if ent.IsValidationError(err) {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update entitlement"})
+    }
+
+    return h.respondWithPlan(c, fiber.StatusOK, p.Key)
+}
+
+// DeleteEntitlement handles DELETE requests to revoke an entitlement of a plan.
+func (h *PlanHandler) DeleteEntitlement(c *fiber.Ctx) error {
+    n, err := h.Client.Entitlement.
+        Delete().
+        Where(
+            entitlement.Key(c.Params("entitlement")),
+            entitlement.HasPlanWith(plan.Key(c.Params("key"))),
+        ).
+        Exec(c.UserContext())
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete entitlement"})
+    }
+    if n == 0 {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Entitlement not found"})
+    }
+
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// setEntitlement creates or replaces the entitlement key of a plan.
+func setEntitlement(ctx context.Context, client *ent.Client, planID int, key string, input EntitlementInput) error {
+    enabled := true
+    if input.Enabled != nil {
+        enabled = *input.Enabled
+    }
+
+    return client.Entitlement.
+        Create().
+        SetPlanID(planID).
+        SetKey(key).
+        SetEnabled(enabled).
+        SetNillableLimit(input.Limit).
+        OnConflictColumns(entitlement.FieldKey, entitlement.PlanColumn).
+        Update(func(u *ent.EntitlementUpsert) {
+            u.UpdateEnabled()
+            if input.Limit != nil {
+                u.UpdateLimit()
+            } else {
+                u.ClearLimit()
+            }
+        }).
+        Exec(ctx)
+}
+
+// priceConflict returns a Stripe price of priceIDs already selling another
+// plan, and that plan's key: each price must map to a single tier.
+func (h *PlanHandler) priceConflict(ctx context.Context, key string, priceIDs []string) (string, string, error) {
+    for _, priceID := range priceIDs {
+        p, err := plans.ByPriceID(ctx, h.Client, priceID)
+        if ent.IsNotFound(err) {
+            continue
+        }
+        if err != nil {
+            return "", "", err
+        }
+        if p.Key != key {
+            return priceID, p.Key, nil
+        }
+    }
+    return "", "", nil
+}
+
+// checkPrices writes a 409 response when priceIDs sell another plan. It
+// returns false when the request was answered.
+func (h *PlanHandler) checkPrices(c *fiber.Ctx, key string, priceIDs []string) bool {
+    priceID, other, err := h.priceConflict(c.UserContext(), key, priceIDs)
+    if err != nil {
+        _ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to check Stripe prices"})
+        return false
+    }
+    if priceID != "" {
+        _ = c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": fmt.Sprintf("Stripe price %s already sells the %s plan", priceID, other)})
+        return false
+    }
+    return true
+}
+
+// respondWithPlan reloads a plan with its entitlements and writes it.
+func (h *PlanHandler) respondWithPlan(c *fiber.Ctx, status int, key string) error {
+    p, err := plans.Get(c.UserContext(), h.Client, key)
+    if ent.IsNotFound(err) {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Plan not found"})
+    }
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve plan"})
+    }
+
+    return c.Status(status).JSON(p)
+}
+
+// SetupRoutes registers the plan and entitlement routes.
+func SetupRoutes(app *fiber.App, client *ent.Client) {
+    planHandler := NewPlanHandler(client)
+
+    // The catalog is readable by every user (pricing page) and edited by
+    // admins; limit changes apply without a deploy.
+    planGroup := app.Group("/plans")
+    planGroup.Get("/", planHandler.ListPlans)
+    planGroup.Get("/:key", planHandler.GetPlan)
+    planGroup.Post("/", middleware.RequireAdmin(), planHandler.CreatePlan)
+    planGroup.Put("/:key", middleware.RequireAdmin(), planHandler.UpdatePlan)
+    planGroup.Put("/:key/entitlements/:entitlement", middleware.RequireAdmin(), planHandler.SetEntitlement)
+    planGroup.Delete("/:key/entitlements/:entitlement", middleware.RequireAdmin(), planHandler.DeleteEntitlement)
+
+    entitlementGroup := app.Group("/entitlements")
+    entitlementGroup.Get("/", planHandler.GetEntitlements)
+    entitlementGroup.Get("/user/:user_id", middleware.RequireSelfOrAdmin("user_id"), planHandler.GetUserEntitlements)
+}
@@ -0,0 +1,307 @@
+package subscription
+
+import (
+    "errors"
+    "strconv"
+    "time"
+
+    "github.com/gofiber/fiber/v2"
+
+    "db-service/ent"
+    "db-service/ent/predicate"
+    "db-service/ent/subscription"
+    "db-service/ent/subscriptionevent"
+    "db-service/ent/user"
+    "db-service/lifecycle"
+    "db-service/middleware"
+    "db-service/pagination"
+)
+
+// SubscriptionHandler holds the ent client.
+type SubscriptionHandler struct {
+    Client *ent.Client
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler.
+func NewSubscriptionHandler(client *ent.Client) *SubscriptionHandler {
+    return &SubscriptionHandler{Client: client}
+}
+
+// CreateSubscription handles POST requests to attach a Stripe subscription to a user.
+func (h *SubscriptionHandler) CreateSubscription(c *fiber.Ctx) error {
+    type CreateSubscriptionInput struct {
+        UserID               int        `json:"user_id"`
+        StripeCustomerID     string     `json:"stripe_customer_id"`
+        StripeSubscriptionID string     `json:"stripe_subscription_id"`
+        Status               string     `json:"status"`
+        Tier                 string     `json:"tier"`
+        CurrentPeriodEnd     *time.Time `json:"current_period_end"`
+        Source               string     `json:"source"` // webhook, admin (default) or scheduler
+        Reason               string     `json:"reason"`
+    }
+
+    input := new(CreateSubscriptionInput)
+    if err := c.BodyParser(input); err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+    }
+    if input.UserID == 0 || input.StripeCustomerID == "" || input.StripeSubscriptionID == "" || input.Status == "" || input.Tier == "" {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "user_id, stripe_customer_id, stripe_subscription_id, status and tier are required"})
+    }
+    status, err := lifecycle.ParseStatus(input.Status)
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+    source, err := lifecycle.ParseSource(input.Source)
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+
+    ctx := lifecycle.WithSource(c.UserContext(), source, input.Reason)
+
+    exists, err := h.Client.User.Query().Where(user.ID(input.UserID)).Exist(ctx)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create subscription"})
+    }
+    if !exists {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+    }
+
+    // The subscription and its first lifecycle event are written together
+    var created *ent.Subscription
+    err = lifecycle.WithTx(ctx, h.Client, func(tx *ent.Tx) error {
+        var err error
+        created, err = tx.Subscription.
+            Create().
+            SetUserID(input.UserID).
+            SetStripeCustomerID(input.StripeCustomerID).
+            SetStripeSubscriptionID(input.StripeSubscriptionID).
+            SetStatus(status).
+            SetTier(input.Tier).
+            SetNillableCurrentPeriodEnd(input.CurrentPeriodEnd).
+            Save(ctx)
+        return err
+    })
+
+    if err != nil {
+        // Stripe subscription IDs are unique
+        if ent.IsConstraintError(err) {
+            return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Subscription with this Stripe subscription ID already exists"})
+        }
+        if ent.IsValidationError(err) {
+            return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+        }
+        // log.Printf("Error creating subscription: %v", err)
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create subscription"})
+    }
+
+    return h.respondWithSubscription(c, fiber.StatusCreated, created.ID)
+}
+
+// GetUserSubscriptions handles GET requests to retrieve the subscriptions of a user.
+func (h *SubscriptionHandler) GetUserSubscriptions(c *fiber.Ctx) error {
+    userID, err := strconv.Atoi(c.Params("user_id"))
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID format"})
+    }
+
+    ctx := c.UserContext()
+
+    exists, err := h.Client.User.Query().Where(user.ID(userID)).Exist(ctx)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscriptions"})
+    }
+    if !exists {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+    }
+
+    subs, err := h.Client.Subscription.
+        Query().
+        Where(subscription.HasUserWith(user.ID(userID))).
+        WithUser().
+        Order(ent.Desc(subscription.FieldID)).
+        All(ctx)
+
+    if err != nil {
+        // log.Printf("Error fetching subscriptions of user %d: %v", userID, err)
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscriptions"})
+    }
+
+    return c.Status(fiber.StatusOK).JSON(subs)
+}
+
+// UpdateSubscriptionStatus handles PATCH requests to change the status
+// (and optionally the period end and tier) of a subscription. Illegal transitions
+// (see package lifecycle) are rejected with 409.
+func (h *SubscriptionHandler) UpdateSubscriptionStatus(c *fiber.Ctx) error {
+    id, err := strconv.Atoi(c.Params("id"))
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid subscription ID format"})
+    }
+
+    type UpdateStatusInput struct {
+        Status           string     `json:"status"`
+        CurrentPeriodEnd *time.Time `json:"current_period_end"`
+        Tier             *string    `json:"tier"`
+        Source           string     `json:"source"` // webhook, admin (default) or scheduler
+        Reason           string     `json:"reason"`
+    }
+
+    input := new(UpdateStatusInput)
+    if err := c.BodyParser(input); err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+    }
+    if input.Status == "" {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "status is required"})
+    }
+    status, err := lifecycle.ParseStatus(input.Status)
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+    source, err := lifecycle.ParseSource(input.Source)
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+
+    ctx := lifecycle.WithSource(c.UserContext(), source, input.Reason)
+    err = lifecycle.WithTx(ctx, h.Client, func(tx *ent.Tx) error {
+        updater := tx.Subscription.
+            UpdateOneID(id).
+            SetStatus(status)
+        if input.CurrentPeriodEnd != nil {
+            updater.SetCurrentPeriodEnd(*input.CurrentPeriodEnd)
+        }
+        if input.Tier != nil {
+            updater.SetTier(*input.Tier)
+        }
+        return updater.Exec(ctx)
+    })
+
+    if err != nil {
+        if ent.IsNotFound(err) {
+            return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Subscription not found"})
+        }
+        if lifecycle.IsTransitionError(err) || errors.Is(err, lifecycle.ErrConflict) {
+            return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+        }
+        // log.Printf("Error updating subscription %d: %v", id, err)
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update subscription"})
+    }
+
+    return h.respondWithSubscription(c, fiber.StatusOK, id)
+}
+
+// ListSubscriptions handles GET requests to list subscriptions one page at a time
+// (sorted by ID, ?order=asc by default), filtered by ?status= when provided.
+func (h *SubscriptionHandler) ListSubscriptions(c *fiber.Ctx) error {
+    page, err := pagination.ParseParams(c, false)
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+
+    query := h.Client.Subscription.
+        Query().
+        WithUser().
+        Where(predicate.Subscription(page.Predicate("", subscription.FieldID))).
+        Order(subscription.ByID(page.OrderTerm())).
+        Limit(page.Limit + 1)
+
+    if v := c.Query("status"); v != "" {
+        status, err := lifecycle.ParseStatus(v)
+        if err != nil {
+            return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+        }
+        query.Where(subscription.StatusEQ(status))
+    }
+
+    subs, err := query.All(c.UserContext())
+    if err != nil {
+        // log.Printf("Error listing subscriptions: %v", err)
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscriptions"})
+    }
+
+    return c.Status(fiber.StatusOK).JSON(pagination.NewPage(subs, page, func(s *ent.Subscription) pagination.Cursor {
+        return pagination.Cursor{ID: s.ID}
+    }))
+}
+
+// GetSubscriptionByStripeID handles GET requests to resolve a subscription
+// from its Stripe subscription ID.
+func (h *SubscriptionHandler) GetSubscriptionByStripeID(c *fiber.Ctx) error {
+    s, err := h.Client.Subscription.
+        Query().
+        Where(subscription.StripeSubscriptionID(c.Params("stripe_subscription_id"))).
+        WithUser().
+        Only(c.UserContext())
+
+    if err != nil {
+        if ent.IsNotFound(err) {
+            return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Subscription not found"})
+        }
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscription"})
+    }
+
+    return c.Status(fiber.StatusOK).JSON(s)
+}
+
+// GetUserSubscriptionEvents handles GET requests to retrieve the status history
+// of every subscription of a user, most recent first.
+func (h *SubscriptionHandler) GetUserSubscriptionEvents(c *fiber.Ctx) error {
+    userID, err := strconv.Atoi(c.Params("user_id"))
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID format"})
+    }
+
+    ctx := c.UserContext()
+
+    exists, err := h.Client.User.Query().Where(user.ID(userID)).Exist(ctx)
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscription events"})
+    }
+    if !exists {
+        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+    }
+
+    events, err := h.Client.SubscriptionEvent.
+        Query().
+        Where(subscriptionevent.HasSubscriptionWith(subscription.HasUserWith(user.ID(userID)))).
+        WithSubscription().
+        Order(ent.Desc(subscriptionevent.FieldCreatedAt), ent.Desc(subscriptionevent.FieldID)).
+        All(ctx)
+
+    if err != nil {
+        // log.Printf("Error fetching subscription events of user %d: %v", userID, err)
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscription events"})
+    }
+
+    return c.Status(fiber.StatusOK).JSON(events)
+}
+
+// respondWithSubscription reloads a subscription with its user edge and writes it.
+func (h *SubscriptionHandler) respondWithSubscription(c *fiber.Ctx, status int, id int) error {
+    s, err := h.Client.Subscription.
+        Query().
+        Where(subscription.ID(id)).
+        WithUser().
+        Only(c.UserContext())
+
+    if err != nil {
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve subscription"})
+    }
+
+    return c.Status(status).JSON(s)
+}
+
+func SetupRoutes(app *fiber.App, client *ent.Client) {
+    subscriptionHandler := NewSubscriptionHandler(client)
+
+    subscriptionGroup := app.Group("/subscriptions")
+
+    // Subscription state is written by admins (payment tooling) only;
+    // users can read their own subscriptions.
+    subscriptionGroup.Post("/", middleware.RequireAdmin(), subscriptionHandler.CreateSubscription)
+    subscriptionGroup.Get("/", middleware.RequireAdmin(), subscriptionHandler.ListSubscriptions)
+    subscriptionGroup.Get("/stripe/:stripe_subscription_id", middleware.RequireAdmin(), subscriptionHandler.GetSubscriptionByStripeID)
+    subscriptionGroup.Get("/user/:user_id", middleware.RequireSelfOrAdmin("user_id"), subscriptionHandler.GetUserSubscriptions)
+    subscriptionGroup.Get("/user/:user_id/events", middleware.RequireSelfOrAdmin("user_id"), subscriptionHandler.GetUserSubscriptionEvents)
+    subscriptionGroup.Patch("/:id/status", middleware.RequireAdmin(), subscriptionHandler.UpdateSubscriptionStatus)
+}
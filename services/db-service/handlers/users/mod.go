@@ -3,14 +3,22 @@ package user
 import (
     "context"
     "strconv"
+    "time"
 
     "github.com/gofiber/fiber/v2"
     // Replace with the actual path to your generated ent client
     "db-service/ent"
     // Replace with the actual path to your generated user package
+    "db-service/ent/predicate"
     "db-service/ent/user"
+    "db-service/middleware"
+    "db-service/pagination"
 )
 
+// errDerivedFields is returned when a request tries to write the subscription state,
+// which is computed from the user's subscriptions (see package tiers).
+const errDerivedFields = "is_subscribed and subscription_tier are derived from subscriptions and cannot be set"
+
 // UserHandler holds the ent client.
 type UserHandler struct {
     Client *ent.Client
@@ -22,14 +30,14 @@ func NewUserHandler(client *ent.Client) *UserHandler {
 }
 
 // CreateUser handles POST requests to create a new user.
-// Note: In a real app, you'd likely get the clerk_user_id from auth middleware,
-// and other fields might be set differently. This is a basic example.
+// Normal users can only register their own Clerk ID with the default role;
+// admins can create any user. New users start on the free tier.
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
     type CreateUserInput struct {
-        ClerkUserID      string `json:"clerk_user_id" validate:"required"`
-        Role             string `json:"role"`
-        IsSubscribed     bool   `json:"is_subscribed"`
-        SubscriptionTier string `json:"subscription_tier"`
+        ClerkUserID      string  `json:"clerk_user_id" validate:"required"`
+        Role             string  `json:"role"`
+        IsSubscribed     *bool   `json:"is_subscribed"`     // Read-only, rejected when provided
+        SubscriptionTier *string `json:"subscription_tier"` // Read-only, rejected when provided
     }
 
     input := new(CreateUserInput)
@@ -38,13 +46,24 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
     }
 
     // Add validation logic here if needed (e.g., using a validation library)
+    if input.IsSubscribed != nil || input.SubscriptionTier != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errDerivedFields})
+    }
+
+    viewer := middleware.ViewerFrom(c)
+    if !viewer.IsAdmin() {
+        if viewer == nil || input.ClerkUserID != viewer.ClerkUserID {
+            return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Cannot create another user's record"})
+        }
+        if input.Role != "" {
+            return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Only admins can set the role"})
+        }
+    }
 
     newUser, err := h.Client.User.
         Create().
         SetClerkUserID(input.ClerkUserID).
         SetNillableRole(optionalString(input.Role, "user")). // Use default if empty
-        SetIsSubscribed(input.IsSubscribed).
-        SetNillableSubscriptionTier(optionalString(input.SubscriptionTier, "free")). // Use default if empty
         // created_at and updated_at are set by default hooks
         Save(context.Background()) // Use request context in real app
 
@@ -86,21 +105,90 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
     return c.Status(fiber.StatusOK).JSON(u)
 }
 
-// ListUsers handles GET requests to retrieve all users (add pagination in real app).
+// GetUserByClerkID handles GET requests to resolve a user from their Clerk ID.
+// Callers may only resolve their own record (admins may resolve any): the
+// extension uses it to fetch the public UUID it stores in its settings table.
+func (h *UserHandler) GetUserByClerkID(c *fiber.Ctx) error {
+    clerkID := c.Params("clerk_id")
+    if clerkID == "" {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Missing Clerk ID"})
+    }
+
+    viewer := middleware.ViewerFrom(c)
+    if !viewer.IsAdmin() && (viewer == nil || viewer.ClerkUserID != clerkID) {
+        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Cannot access another user's record"})
+    }
+
+    u, err := h.Client.User.
+        Query().
+        Where(user.ClerkUserID(clerkID)).
+        Only(c.UserContext())
+
+    if err != nil {
+        if ent.IsNotFound(err) {
+            return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+        }
+        // Log the error internally
+        // log.Printf("Error fetching user by Clerk ID %s: %v", clerkID, err)
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve user"})
+    }
+
+    return c.Status(fiber.StatusOK).JSON(u)
+}
+
+// ListUsers handles GET requests to list users, one page at a time.
+// Results are sorted by created_at (?order=desc by default, or asc) and can be
+// filtered with ?role=, ?is_subscribed=, ?subscription_tier=, ?created_after= and ?created_before= (RFC 3339).
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
-    // Add pagination parameters (e.g., ?page=1&limit=20) in a real application
-    users, err := h.Client.User.
+    page, err := pagination.ParseParams(c, true)
+    if err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+    }
+
+    query := h.Client.User.
         Query().
-        // Add Order(), Limit(), Offset() for pagination and sorting
-        All(context.Background()) // Use request context in real app
+        Where(predicate.User(page.Predicate(user.FieldCreatedAt, user.FieldID))).
+        Order(user.ByCreatedAt(page.OrderTerm()), user.ByID(page.OrderTerm())).
+        Limit(page.Limit + 1)
+
+    if role := c.Query("role"); role != "" {
+        query.Where(user.Role(role))
+    }
+    if tier := c.Query("subscription_tier"); tier != "" {
+        query.Where(user.SubscriptionTier(tier))
+    }
+    if v := c.Query("is_subscribed"); v != "" {
+        subscribed, err := strconv.ParseBool(v)
+        if err != nil {
+            return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "is_subscribed must be true or false"})
+        }
+        query.Where(user.IsSubscribed(subscribed))
+    }
+    if v := c.Query("created_after"); v != "" {
+        after, err := time.Parse(time.RFC3339, v)
+        if err != nil {
+            return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "created_after must be an RFC 3339 timestamp"})
+        }
+        query.Where(user.CreatedAtGTE(after))
+    }
+    if v := c.Query("created_before"); v != "" {
+        before, err := time.Parse(time.RFC3339, v)
+        if err != nil {
+            return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "created_before must be an RFC 3339 timestamp"})
+        }
+        query.Where(user.CreatedAtLT(before))
+    }
 
+    users, err := query.All(c.UserContext())
     if err != nil {
         // Log the error internally
         // log.Printf("Error listing users: %v", err)
         return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve users"})
     }
 
-    return c.Status(fiber.StatusOK).JSON(users)
+    return c.Status(fiber.StatusOK).JSON(pagination.NewPage(users, page, func(u *ent.User) pagination.Cursor {
+        return pagination.Cursor{Time: u.CreatedAt, ID: u.ID}
+    }))
 }
 
 // UpdateUser handles PUT/PATCH requests to update a user by ID.
@@ -113,8 +201,8 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 
     type UpdateUserInput struct {
         Role             *string `json:"role"`              // Use pointers to distinguish between empty and not provided
-        IsSubscribed     *bool   `json:"is_subscribed"`
-        SubscriptionTier *string `json:"subscription_tier"`
+        IsSubscribed     *bool   `json:"is_subscribed"`     // Read-only, rejected when provided
+        SubscriptionTier *string `json:"subscription_tier"` // Read-only, rejected when provided
         // clerk_user_id is likely immutable or managed elsewhere
     }
 
@@ -123,17 +211,20 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
         return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
     }
 
+    // Subscription state follows the user's subscriptions, even for admins
+    if input.IsSubscribed != nil || input.SubscriptionTier != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errDerivedFields})
+    }
+    // The role is never editable by the user themselves
+    if !middleware.ViewerFrom(c).IsAdmin() && input.Role != nil {
+        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Only admins can change the role"})
+    }
+
     updater := h.Client.User.UpdateOneID(id)
 
     if input.Role != nil {
         updater.SetRole(*input.Role)
     }
-    if input.IsSubscribed != nil {
-        updater.SetIsSubscribed(*input.IsSubscribed)
-    }
-    if input.SubscriptionTier != nil {
-        updater.SetSubscriptionTier(*input.SubscriptionTier)
-    }
     // updated_at is handled by the UpdateDefault hook
 
     updatedUser, err := updater.Save(context.Background()) // Use request context in real app
@@ -188,10 +279,11 @@ func SetupRoutes(app *fiber.App, client *ent.Client) {
     userGroup := app.Group("/users") // Example base path
 
     userGroup.Post("/", userHandler.CreateUser)
-    userGroup.Get("/", userHandler.ListUsers)
-    userGroup.Get("/:id", userHandler.GetUser)
-    userGroup.Put("/:id", userHandler.UpdateUser)    // Or Patch
-    userGroup.Delete("/:id", userHandler.DeleteUser)
+    userGroup.Get("/", middleware.RequireAdmin(), userHandler.ListUsers)
+    userGroup.Get("/clerk/:clerk_id", userHandler.GetUserByClerkID)
+    userGroup.Get("/:id", middleware.RequireSelfOrAdmin("id"), userHandler.GetUser)
+    userGroup.Put("/:id", middleware.RequireSelfOrAdmin("id"), userHandler.UpdateUser)    // Or Patch
+    userGroup.Delete("/:id", middleware.RequireAdmin(), userHandler.DeleteUser)
 }
 
 
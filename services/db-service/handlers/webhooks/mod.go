@@ -0,0 +1,153 @@
+package webhook
+
+import (
+    "context"
+    "database/sql"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log"
+    "time"
+
+    "github.com/gofiber/fiber/v2"
+
+    "db-service/ent"
+    "db-service/ent/subscription"
+    "db-service/ent/user"
+    "db-service/events"
+    "db-service/svix"
+)
+
+// ClerkEvent is the envelope of a Clerk webhook. Only the user ID is used.
+type ClerkEvent struct {
+    Type string `json:"type"`
+    // Timestamp is the creation time of the event, in milliseconds
+    Timestamp int64 `json:"timestamp"`
+    Data      struct {
+        ID string `json:"id"`
+    } `json:"data"`
+}
+
+// WebhookHandler holds the ent client and the Clerk signature verifier.
+type WebhookHandler struct {
+    Client   *ent.Client
+    Verifier *svix.Verifier
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(client *ent.Client, verifier *svix.Verifier) *WebhookHandler {
+    return &WebhookHandler{Client: client, Verifier: verifier}
+}
+
+// Clerk handles POST requests sent by Clerk when users are created, updated or deleted.
+func (h *WebhookHandler) Clerk(c *fiber.Ctx) error {
+    msgID := c.Get("svix-id")
+    err := h.Verifier.Verify(msgID, c.Get("svix-timestamp"), c.Get("svix-signature"), c.Body())
+    if errors.Is(err, svix.ErrReplayed) {
+        // Already handled: acknowledge so the sender stops retrying
+        return c.SendStatus(fiber.StatusNoContent)
+    }
+    if err != nil {
+        return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_signature"})
+    }
+
+    var event ClerkEvent
+    if err := json.Unmarshal(c.Body(), &event); err != nil {
+        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse JSON"})
+    }
+
+    ref := events.Event{
+        Provider:   events.ProviderClerk,
+        ID:         msgID,
+        Type:       event.Type,
+        ResourceID: event.Data.ID,
+    }
+    if event.Timestamp > 0 {
+        ref.CreatedAt = time.UnixMilli(event.Timestamp)
+    }
+
+    // Deliveries already handled (e.g. by another instance) are skipped, as
+    // are events older than the last one applied to the same user
+    outcome, err := events.Process(c.UserContext(), h.Client, ref, func(ctx context.Context, tx *ent.Tx) error {
+        return HandleClerkEvent(ctx, tx.Client(), event)
+    })
+    if err != nil {
+        log.Printf("Error handling Clerk event %s (%s): %v", msgID, event.Type, err)
+        // A non-2xx status makes Clerk retry the delivery
+        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to handle event"})
+    }
+    if outcome != events.Processed {
+        log.Printf("Skipped Clerk event %s (%s): %s", msgID, event.Type, outcome)
+    }
+
+    h.Verifier.MarkProcessed(msgID)
+    return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HandleClerkEvent applies a Clerk user event to the ent User table.
+// Unknown event types are ignored. client should be transactional (see
+// ent.Tx.Client), so that a deletion is applied as a whole.
+func HandleClerkEvent(ctx context.Context, client *ent.Client, event ClerkEvent) error {
+    switch event.Type {
+    case "user.created", "user.updated":
+        if event.Data.ID == "" {
+            return fmt.Errorf("missing user ID")
+        }
+        return upsertUser(ctx, client, event.Data.ID)
+    case "user.deleted":
+        if event.Data.ID == "" {
+            return fmt.Errorf("missing user ID")
+        }
+        return deleteUser(ctx, client, event.Data.ID)
+    default:
+        return nil
+    }
+}
+
+// upsertUser creates the user if missing. Role and subscription fields are
+// owned by db-service, so an existing user is left untouched.
+func upsertUser(ctx context.Context, client *ent.Client, clerkID string) error {
+    exists, err := client.User.Query().Where(user.ClerkUserID(clerkID)).Exist(ctx)
+    if err != nil || exists {
+        return err
+    }
+
+    // A concurrent delivery may have created it first. A constraint error
+    // would abort the surrounding transaction, hence ON CONFLICT DO NOTHING,
+    // which returns no row in that case.
+    err = client.User.Create().
+        SetClerkUserID(clerkID).
+        OnConflictColumns(user.FieldClerkUserID).
+        DoNothing().
+        Exec(ctx)
+    if errors.Is(err, sql.ErrNoRows) {
+        return nil
+    }
+    return err
+}
+
+// deleteUser removes the user and their subscriptions. Deleting an unknown user is a no-op.
+func deleteUser(ctx context.Context, client *ent.Client, clerkID string) error {
+    u, err := client.User.Query().Where(user.ClerkUserID(clerkID)).Only(ctx)
+    if ent.IsNotFound(err) {
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+
+    if _, err := client.Subscription.Delete().Where(subscription.HasUserWith(user.ID(u.ID))).Exec(ctx); err != nil {
+        return err
+    }
+    return client.User.DeleteOne(u).Exec(ctx)
+}
+
+// SetupRoutes registers the webhook routes. They are authenticated by their
+// signature, so they must be registered before the JWT middleware.
+func SetupRoutes(app *fiber.App, client *ent.Client, verifier *svix.Verifier) {
+    webhookHandler := NewWebhookHandler(client, verifier)
+
+    webhookGroup := app.Group("/webhooks")
+
+    webhookGroup.Post("/clerk", webhookHandler.Clerk)
+}
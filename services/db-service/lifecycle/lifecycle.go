@@ -0,0 +1,269 @@
+// Package lifecycle enforces the subscription state machine and records every
+// status change as a SubscriptionEvent.
+//
+// Allowed transitions:
+//
+//	trialing -> active, past_due, paused, canceled, expired
+//	active   -> past_due, paused, canceled, expired
+//	past_due -> active, canceled, expired
+//	paused   -> active, canceled, expired
+//	canceled -> active, expired
+//
+// A canceled subscription stays paid until the end of its period and can be
+// resumed before then; expired is final. Setting the current status again is
+// always allowed and not recorded.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"db-service/ent"
+	"db-service/ent/hook"
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+)
+
+// Source identifies what triggered a transition.
+type Source = subscriptionevent.Source
+
+const (
+	SourceWebhook   = subscriptionevent.SourceWebhook
+	SourceAdmin     = subscriptionevent.SourceAdmin
+	SourceScheduler = subscriptionevent.SourceScheduler
+)
+
+// transitions lists the states reachable from each state.
+var transitions = map[subscription.Status][]subscription.Status{
+	subscription.StatusTrialing: {subscription.StatusActive, subscription.StatusPastDue, subscription.StatusPaused, subscription.StatusCanceled, subscription.StatusExpired},
+	subscription.StatusActive:   {subscription.StatusPastDue, subscription.StatusPaused, subscription.StatusCanceled, subscription.StatusExpired},
+	subscription.StatusPastDue:  {subscription.StatusActive, subscription.StatusCanceled, subscription.StatusExpired},
+	subscription.StatusPaused:   {subscription.StatusActive, subscription.StatusCanceled, subscription.StatusExpired},
+	subscription.StatusCanceled: {subscription.StatusActive, subscription.StatusExpired},
+	subscription.StatusExpired:  {},
+}
+
+// CanTransition reports whether a subscription may go from one status to another.
+func CanTransition(from, to subscription.Status) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// predecessors returns the states from which to can be reached, including to itself.
+func predecessors(to subscription.Status) []subscription.Status {
+	from := []subscription.Status{to}
+	for s, next := range transitions {
+		for _, n := range next {
+			if n == to {
+				from = append(from, s)
+			}
+		}
+	}
+	return from
+}
+
+// ParseStatus validates a subscription status received from an API.
+func ParseStatus(s string) (subscription.Status, error) {
+	status := subscription.Status(s)
+	if err := subscription.StatusValidator(status); err != nil {
+		return "", fmt.Errorf("invalid status %q: must be one of trialing, active, past_due, canceled, expired, paused", s)
+	}
+	return status, nil
+}
+
+// ParseSource validates a transition source received from an API; an empty
+// string stands for SourceAdmin.
+func ParseSource(s string) (Source, error) {
+	if s == "" {
+		return SourceAdmin, nil
+	}
+	source := Source(s)
+	if err := subscriptionevent.SourceValidator(source); err != nil {
+		return "", fmt.Errorf("invalid source %q: must be one of webhook, admin, scheduler", s)
+	}
+	return source, nil
+}
+
+// TransitionError is returned when a mutation would make an illegal transition.
+type TransitionError struct {
+	SubscriptionID int
+	From, To       subscription.Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("subscription %d cannot go from %s to %s", e.SubscriptionID, e.From, e.To)
+}
+
+// IsTransitionError reports whether err is (or wraps) a TransitionError.
+func IsTransitionError(err error) bool {
+	var te *TransitionError
+	return errors.As(err, &te)
+}
+
+// ErrNoSource is returned when a status changes without WithSource on the context.
+var ErrNoSource = errors.New("lifecycle: subscription status changed without a source")
+
+// ErrNoTx is returned when a status changes outside a transaction (see WithTx).
+var ErrNoTx = errors.New("lifecycle: subscription status changed outside a transaction")
+
+// ErrConflict is returned when a subscription changed between the check of
+// its transition and its update.
+var ErrConflict = errors.New("lifecycle: subscription changed concurrently")
+
+type originKey struct{}
+
+type origin struct {
+	source Source
+	reason string
+}
+
+// WithSource attaches the source (and an optional free-form reason, such as the
+// Stripe event type) of the status changes made with ctx.
+func WithSource(ctx context.Context, source Source, reason string) context.Context {
+	return context.WithValue(ctx, originKey{}, origin{source: source, reason: reason})
+}
+
+// WithTx runs fn in a transaction, so that status changes and their events
+// are committed together.
+func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w: rolling back: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Hook rejects illegal transitions and records a SubscriptionEvent for every
+// status change. Register it with client.Subscription.Use(lifecycle.Hook()) and
+// run the mutations in a transaction (see WithTx): a status update reads the
+// subscriptions FOR UPDATE and fails with ErrNoTx outside of one.
+func Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.SubscriptionFunc(func(ctx context.Context, m *ent.SubscriptionMutation) (ent.Value, error) {
+			switch {
+			case m.Op().Is(ent.OpCreate):
+				return create(ctx, next, m)
+			case m.Op().Is(ent.OpUpdate | ent.OpUpdateOne):
+				if _, ok := m.Status(); ok {
+					return update(ctx, next, m)
+				}
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+func create(ctx context.Context, next ent.Mutator, m *ent.SubscriptionMutation) (ent.Value, error) {
+	o, ok := ctx.Value(originKey{}).(origin)
+	if !ok {
+		return nil, ErrNoSource
+	}
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	sub := v.(*ent.Subscription)
+	err = m.Client().SubscriptionEvent.Create().
+		SetSubscriptionID(sub.ID).
+		SetStatus(subscriptionevent.Status(sub.Status)).
+		SetSource(o.source).
+		SetNillableReason(nillable(o.reason)).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recording subscription event: %w", err)
+	}
+	return v, nil
+}
+
+func update(ctx context.Context, next ent.Mutator, m *ent.SubscriptionMutation) (ent.Value, error) {
+	to, _ := m.Status()
+	o, ok := ctx.Value(originKey{}).(origin)
+	if !ok {
+		return nil, ErrNoSource
+	}
+
+	if _, err := m.Tx(); err != nil {
+		return nil, ErrNoTx
+	}
+
+	ids, err := m.IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := m.Client().Subscription.Query().
+		Where(subscription.IDIn(ids...), forUpdate).
+		Select(subscription.FieldID, subscription.FieldStatus).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range current {
+		if !CanTransition(sub.Status, to) {
+			return nil, &TransitionError{SubscriptionID: sub.ID, From: sub.Status, To: to}
+		}
+	}
+
+	// The rows are locked until the end of the transaction; this only
+	// guards against a database without row locks: rows that moved to a
+	// state from which `to` is not reachable are left untouched.
+	m.Where(subscription.StatusIn(predecessors(to)...))
+	v, err := next.Mutate(ctx, m)
+	if ent.IsNotFound(err) && len(current) > 0 {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*ent.SubscriptionEventCreate
+	for _, sub := range current {
+		if sub.Status == to {
+			continue
+		}
+		events = append(events, m.Client().SubscriptionEvent.Create().
+			SetSubscriptionID(sub.ID).
+			SetPreviousStatus(subscriptionevent.PreviousStatus(sub.Status)).
+			SetStatus(subscriptionevent.Status(to)).
+			SetSource(o.source).
+			SetNillableReason(nillable(o.reason)))
+	}
+	if len(events) > 0 {
+		if err := m.Client().SubscriptionEvent.CreateBulk(events...).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("recording subscription events: %w", err)
+		}
+	}
+	return v, nil
+}
+
+// forUpdate locks the subscriptions read, on Postgres, until the end of the
+// transaction. SQLite (used by the tests) has no row locks nor FOR UPDATE,
+// hence a predicate rather than the query's ForUpdate.
+func forUpdate(s *sql.Selector) {
+	if s.Dialect() == dialect.Postgres {
+		s.ForUpdate()
+	}
+}
+
+func nillable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
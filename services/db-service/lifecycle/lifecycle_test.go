@@ -0,0 +1,188 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"db-service/dbtest"
+	"db-service/ent"
+	"db-service/ent/hook"
+	"db-service/ent/subscription"
+	"db-service/ent/subscriptionevent"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to subscription.Status
+		want     bool
+	}{
+		{subscription.StatusTrialing, subscription.StatusActive, true},
+		{subscription.StatusActive, subscription.StatusPastDue, true},
+		{subscription.StatusPastDue, subscription.StatusActive, true},
+		{subscription.StatusPaused, subscription.StatusActive, true},
+		{subscription.StatusCanceled, subscription.StatusActive, true},
+		{subscription.StatusCanceled, subscription.StatusExpired, true},
+		{subscription.StatusActive, subscription.StatusActive, true},
+		{subscription.StatusExpired, subscription.StatusExpired, true},
+		{subscription.StatusActive, subscription.StatusTrialing, false},
+		{subscription.StatusPastDue, subscription.StatusPaused, false},
+		{subscription.StatusCanceled, subscription.StatusPaused, false},
+		{subscription.StatusExpired, subscription.StatusActive, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestHook(t *testing.T) {
+	ctx := WithSource(context.Background(), SourceWebhook, "customer.subscription.created")
+	client := dbtest.Open(t)
+	client.Subscription.Use(Hook())
+	u := client.User.Create().SetClerkUserID("user_1").SaveX(ctx)
+
+	var sub *ent.Subscription
+	err := WithTx(ctx, client, func(tx *ent.Tx) (err error) {
+		sub, err = tx.Subscription.Create().
+			SetUserID(u.ID).
+			SetStripeCustomerID("cus_1").
+			SetStripeSubscriptionID("sub_1").
+			SetStatus(subscription.StatusTrialing).
+			SetTier("premium").
+			SetCurrentPeriodEnd(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("creating subscription: %v", err)
+	}
+
+	setStatus := func(ctx context.Context, to subscription.Status) error {
+		return WithTx(ctx, client, func(tx *ent.Tx) error {
+			return tx.Subscription.UpdateOneID(sub.ID).SetStatus(to).Exec(ctx)
+		})
+	}
+	admin := WithSource(context.Background(), SourceAdmin, "")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		to     subscription.Status
+		err    error
+		status subscription.Status
+		events int
+	}{
+		{name: "legal", ctx: admin, to: subscription.StatusActive, status: subscription.StatusActive, events: 2},
+		{name: "same status", ctx: admin, to: subscription.StatusActive, status: subscription.StatusActive, events: 2},
+		{name: "illegal", ctx: admin, to: subscription.StatusTrialing, err: &TransitionError{}, status: subscription.StatusActive, events: 2},
+		{name: "no source", ctx: context.Background(), to: subscription.StatusPastDue, err: ErrNoSource, status: subscription.StatusActive, events: 2},
+		{name: "to canceled", ctx: admin, to: subscription.StatusCanceled, status: subscription.StatusCanceled, events: 3},
+		{name: "resumed", ctx: admin, to: subscription.StatusActive, status: subscription.StatusActive, events: 4},
+		{name: "to expired", ctx: admin, to: subscription.StatusExpired, status: subscription.StatusExpired, events: 5},
+		{name: "expired is final", ctx: admin, to: subscription.StatusActive, err: &TransitionError{}, status: subscription.StatusExpired, events: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := setStatus(tt.ctx, tt.to)
+			switch want := tt.err.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("setting %s: %v", tt.to, err)
+				}
+			case *TransitionError:
+				if !IsTransitionError(err) {
+					t.Fatalf("setting %s = %v, want a TransitionError", tt.to, err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("setting %s = %v, want %v", tt.to, err, want)
+				}
+			}
+			if got := client.Subscription.GetX(ctx, sub.ID).Status; got != tt.status {
+				t.Errorf("status = %s, want %s", got, tt.status)
+			}
+			if n := client.Subscription.QueryEvents(sub).CountX(ctx); n != tt.events {
+				t.Errorf("%d event(s), want %d", n, tt.events)
+			}
+		})
+	}
+
+	events := client.Subscription.QueryEvents(sub).Order(subscriptionevent.ByID()).AllX(ctx)
+	first, expired := events[0], events[len(events)-1]
+	if first.PreviousStatus != nil || first.Status != subscriptionevent.StatusTrialing || first.Source != SourceWebhook || first.Reason != "customer.subscription.created" {
+		t.Errorf("creation event = %+v", first)
+	}
+	if expired.PreviousStatus == nil || *expired.PreviousStatus != subscriptionevent.PreviousStatusActive || expired.Status != subscriptionevent.StatusExpired || expired.Source != SourceAdmin {
+		t.Errorf("last event = %+v", expired)
+	}
+}
+
+func TestHookOutsideTx(t *testing.T) {
+	ctx := WithSource(context.Background(), SourceAdmin, "")
+	client := dbtest.Open(t)
+	client.Subscription.Use(Hook())
+	u := client.User.Create().SetClerkUserID("user_1").SaveX(ctx)
+	sub := client.Subscription.Create().
+		SetUserID(u.ID).
+		SetStripeCustomerID("cus_1").
+		SetStripeSubscriptionID("sub_1").
+		SetStatus(subscription.StatusActive).
+		SetTier("premium").
+		SetCurrentPeriodEnd(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)).
+		SaveX(ctx)
+
+	if err := client.Subscription.UpdateOne(sub).SetStatus(subscription.StatusCanceled).Exec(ctx); !errors.Is(err, ErrNoTx) {
+		t.Errorf("status update outside a transaction = %v, want ErrNoTx", err)
+	}
+	// Other fields need no transaction
+	if err := client.Subscription.UpdateOne(sub).SetTier("basic").Exec(ctx); err != nil {
+		t.Errorf("tier update outside a transaction: %v", err)
+	}
+}
+
+func TestHookConflict(t *testing.T) {
+	ctx := WithSource(context.Background(), SourceAdmin, "")
+	client := dbtest.Open(t)
+
+	// Stands for a writer expiring the subscription between the check of
+	// the transition and the update, which row locks prevent on Postgres
+	type raceKey struct{}
+	client.Subscription.Use(Hook(), func(next ent.Mutator) ent.Mutator {
+		return hook.SubscriptionFunc(func(ctx context.Context, m *ent.SubscriptionMutation) (ent.Value, error) {
+			if id, ok := ctx.Value(raceKey{}).(int); ok {
+				if _, err := m.Client().ExecContext(ctx, "UPDATE subscriptions SET status = 'expired' WHERE id = ?", id); err != nil {
+					return nil, err
+				}
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	u := client.User.Create().SetClerkUserID("user_1").SaveX(ctx)
+	sub := client.Subscription.Create().
+		SetUserID(u.ID).
+		SetStripeCustomerID("cus_1").
+		SetStripeSubscriptionID("sub_1").
+		SetStatus(subscription.StatusCanceled).
+		SetTier("premium").
+		SetCurrentPeriodEnd(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)).
+		SaveX(ctx)
+
+	err := WithTx(ctx, client, func(tx *ent.Tx) error {
+		return tx.Subscription.UpdateOne(sub).
+			SetStatus(subscription.StatusActive).
+			Exec(context.WithValue(ctx, raceKey{}, sub.ID))
+	})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("update of a subscription changed meanwhile = %v, want ErrConflict", err)
+	}
+	if got := client.Subscription.GetX(ctx, sub.ID).Status; got != subscription.StatusCanceled {
+		t.Errorf("status = %s, want canceled (rolled back)", got)
+	}
+	if n := client.Subscription.QueryEvents(sub).CountX(ctx); n != 1 {
+		t.Errorf("%d event(s), want only the creation", n)
+	}
+}
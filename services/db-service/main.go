@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log"
+	"net"
 	"os"
+	"time"
 
 	"db-service/ent"
+	"db-service/expiry"
+	"db-service/grpcapi"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
@@ -13,32 +18,131 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	//dbservice "db-service/handlers"
+	plans "db-service/handlers/plans"
+	subscriptions "db-service/handlers/subscriptions"
 	users "db-service/handlers/users"
+	webhooks "db-service/handlers/webhooks"
+	"db-service/lifecycle"
 	"db-service/middleware"
+	"db-service/migrations"
+	"db-service/svix"
+	"db-service/tiers"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"shared/jwtauth"
 )
 
 func main() {
 
 	_ = godotenv.Load()
 
+	// Access lasts until the end of the paid period plus this grace period
+	policy := tiers.Policy{GracePeriod: durationEnv("SUBSCRIPTION_GRACE_PERIOD", 24*time.Hour)}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcile(policy)
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "expire" {
+		runExpire(policy)
+		return
+	}
+
 	dsn := os.Getenv("DATABASE_URL")
 
-	client, err := ent.Open("postgres", dsn)
+	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		log.Fatalf("failed opening connection to db: %v", err)
 	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB("postgres", db)))
 	defer client.Close()
 
-	// Run migrations (optional, good for dev)
-	if err := client.Schema.Create(context.Background()); err != nil {
-		log.Fatalf("failed creating schema resources: %v", err)
+	// Status changes follow the subscription state machine and are recorded;
+	// is_subscribed and subscription_tier follow the user's subscriptions
+	client.Subscription.Use(lifecycle.Hook(), policy.Hook())
+
+	// Migrations are applied with `db-service migrate apply`; refuse to serve
+	// against a database that is not at the revision this binary expects.
+	m, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("failed initializing migrations: %v", err)
+	}
+	if err := m.Check(context.Background()); err != nil {
+		log.Fatalf("database schema check failed: %v", err)
 	}
 
+	// Clerk session tokens are verified locally against the cached JWKS
+	verifier, err := jwtauth.NewVerifier(context.Background(), jwtauth.Config{
+		JWKSURL: os.Getenv("CLERK_JWKS_URL"),
+		Issuer:  os.Getenv("CLERK_ISSUER"),
+	})
+	if err != nil {
+		log.Fatalf("failed initializing token verifier: %v", err)
+	}
+	defer verifier.Close()
+
 	app := fiber.New()
 
-	app.Use(middleware.AuthMiddleware())
+	// Webhooks are authenticated by their signature, not by a session token
+	clerkWebhooks, err := svix.NewVerifier(os.Getenv("CLERK_WEBHOOK_SECRET"))
+	if err != nil {
+		log.Fatalf("invalid CLERK_WEBHOOK_SECRET: %v", err)
+	}
+	webhooks.SetupRoutes(app, client, clerkWebhooks)
+
+	app.Use(jwtauth.Middleware(verifier))
+	app.Use(middleware.ViewerMiddleware(client))
 
 	users.SetupRoutes(app, client) // Register the routes
+	subscriptions.SetupRoutes(app, client)
+	plans.SetupRoutes(app, client)
+
+	// The gRPC API runs alongside Fiber, sharing the ent client and the verifier
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("failed listening on gRPC port: %v", err)
+	}
+	apiKeys, err := grpcapi.ParseAPIKeys(os.Getenv("SERVICE_API_KEYS"))
+	if err != nil {
+		log.Fatalf("invalid SERVICE_API_KEYS: %v", err)
+	}
+	grpcServer := grpcapi.NewServer(client, verifier, apiKeys)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("gRPC server stopped: %v", err)
+		}
+	}()
+	defer grpcServer.GracefulStop()
+
+	// Subscriptions whose period lapsed without a webhook are expired in the
+	// background; concurrent instances coordinate through an advisory lock
+	if interval := durationEnv("SUBSCRIPTION_EXPIRY_INTERVAL", expiry.DefaultInterval); interval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		job := &expiry.Job{Client: client, Interval: interval, Policy: policy}
+		go job.Run(ctx)
+	}
 
 	log.Fatal(app.Listen(":8080"))
 }
+
+// durationEnv parses a Go duration (e.g. "36h") from the environment.
+func durationEnv(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Fatalf("invalid %s: %q", name, v)
+	}
+	return d
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+
+	"db-service/ent"
+	"db-service/ent/user"
+
+	"github.com/gofiber/fiber/v2"
+	"shared/jwtauth"
+)
+
+// RoleAdmin is the ent User role allowed to manage every record.
+const RoleAdmin = "admin"
+
+// Viewer is the authenticated caller of a request.
+// User is nil when the Clerk account has no record in the database yet.
+// Service is set instead of ClerkUserID for internal services authenticated
+// with an API key (gRPC only).
+type Viewer struct {
+	ClerkUserID string
+	User        *ent.User
+	Service     string
+}
+
+// IsAdmin reports whether the caller has the admin role. Internal services
+// are admins.
+func (v *Viewer) IsAdmin() bool {
+	return v != nil && (v.Service != "" || v.User != nil && v.User.Role == RoleAdmin)
+}
+
+// Owns reports whether the ent User with the given ID is the caller.
+func (v *Viewer) Owns(userID int) bool {
+	return v != nil && v.User != nil && v.User.ID == userID
+}
+
+// CanAccess reports whether the caller may read or update the given user.
+func (v *Viewer) CanAccess(userID int) bool {
+	return v.IsAdmin() || v.Owns(userID)
+}
+
+// ViewerMiddleware maps the verified Clerk user ID to the ent User and
+// stores the result in the context. It must run after jwtauth.Middleware.
+func ViewerMiddleware(client *ent.Client) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := jwtauth.PrincipalFrom(c)
+		if principal == nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_token"})
+		}
+
+		viewer, err := LoadViewer(c.UserContext(), client, principal.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal_error"})
+		}
+
+		c.Locals("viewer", viewer)
+		return c.Next()
+	}
+}
+
+// LoadViewer resolves the ent User of a verified Clerk user ID.
+func LoadViewer(ctx context.Context, client *ent.Client, clerkID string) (*Viewer, error) {
+	u, err := client.User.
+		Query().
+		Where(user.ClerkUserID(clerkID)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	return &Viewer{ClerkUserID: clerkID, User: u}, nil
+}
+
+type viewerKey struct{}
+
+// WithViewer returns a context carrying the caller, for non-Fiber transports.
+func WithViewer(ctx context.Context, v *Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, v)
+}
+
+// ViewerFromContext returns the caller stored by WithViewer, or nil.
+func ViewerFromContext(ctx context.Context) *Viewer {
+	v, _ := ctx.Value(viewerKey{}).(*Viewer)
+	return v
+}
+
+// ViewerFrom returns the caller stored by ViewerMiddleware, or nil.
+func ViewerFrom(c *fiber.Ctx) *Viewer {
+	v, _ := c.Locals("viewer").(*Viewer)
+	return v
+}
+
+// RequireAdmin restricts a route to admins.
+func RequireAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !ViewerFrom(c).IsAdmin() {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+		}
+		return c.Next()
+	}
+}
+
+// RequireSelfOrAdmin restricts a route to admins and to the user whose
+// internal ID is in the given route parameter.
+func RequireSelfOrAdmin(param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.Atoi(c.Params(param))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID format"})
+		}
+		if !ViewerFrom(c).CanAccess(id) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+		}
+		return c.Next()
+	}
+}
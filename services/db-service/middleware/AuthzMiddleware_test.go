@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"db-service/dbtest"
+	"shared/jwtauth"
+)
+
+// issuer signs session tokens with a key published by a JWKS server.
+type issuer struct {
+	key ed25519.PrivateKey
+	url string
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kid": "k1", "kty": "OKP", "crv": "Ed25519", "alg": "EdDSA", "use": "sig", "x": base64.RawURLEncoding.EncodeToString(pub)},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+	return &issuer{key: key, url: srv.URL}
+}
+
+// token returns a session token of the Clerk user sub.
+func (i *issuer) token(sub string) string {
+	enc := base64.RawURLEncoding
+	header, _ := json.Marshal(map[string]string{"alg": "EdDSA", "kid": "k1", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{"sub": sub, "sid": "sess_1", "exp": time.Now().Add(time.Minute).Unix()})
+	input := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	return input + "." + enc.EncodeToString(ed25519.Sign(i.key, []byte(input)))
+}
+
+func TestAuthz(t *testing.T) {
+	ctx := context.Background()
+	client := dbtest.Open(t)
+	admin := client.User.Create().SetClerkUserID("user_admin").SetRole(RoleAdmin).SaveX(ctx)
+	alice := client.User.Create().SetClerkUserID("user_alice").SaveX(ctx)
+	bob := client.User.Create().SetClerkUserID("user_bob").SaveX(ctx)
+
+	iss := newIssuer(t)
+	verifier, err := jwtauth.NewVerifier(ctx, jwtauth.Config{JWKSURL: iss.url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer verifier.Close()
+
+	app := fiber.New()
+	app.Use(jwtauth.Middleware(verifier))
+	app.Use(ViewerMiddleware(client))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/admin", RequireAdmin(), ok)
+	app.Get("/users/:id", RequireSelfOrAdmin("id"), ok)
+	app.Get("/viewer", func(c *fiber.Ctx) error { return c.JSON(ViewerFrom(c)) })
+
+	users := func(id int) string { return "/users/" + strconv.Itoa(id) }
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{name: "no token", path: "/admin", want: fiber.StatusUnauthorized},
+		{name: "bad token", token: "not.a.token", path: users(alice.ID), want: fiber.StatusUnauthorized},
+		{name: "admin route, admin", token: iss.token("user_admin"), path: "/admin", want: fiber.StatusOK},
+		{name: "admin route, user", token: iss.token("user_alice"), path: "/admin", want: fiber.StatusForbidden},
+		{name: "admin route, no record", token: iss.token("user_new"), path: "/admin", want: fiber.StatusForbidden},
+		{name: "self", token: iss.token("user_alice"), path: users(alice.ID), want: fiber.StatusOK},
+		{name: "other user", token: iss.token("user_alice"), path: users(bob.ID), want: fiber.StatusForbidden},
+		{name: "other user, admin", token: iss.token("user_admin"), path: users(bob.ID), want: fiber.StatusOK},
+		{name: "missing user, admin", token: iss.token("user_admin"), path: users(999), want: fiber.StatusOK},
+		{name: "no record", token: iss.token("user_new"), path: users(alice.ID), want: fiber.StatusForbidden},
+		{name: "invalid ID", token: iss.token("user_alice"), path: "/users/alice", want: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("GET %s = %d, want %d", tt.path, resp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	req := httptest.NewRequest("GET", "/viewer", nil)
+	req.Header.Set("Authorization", "Bearer "+iss.token("user_admin"))
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v Viewer
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ClerkUserID != "user_admin" || v.User == nil || v.User.ID != admin.ID {
+		t.Errorf("viewer = %+v, want the admin's record", v)
+	}
+}
+
+func TestViewer(t *testing.T) {
+	var none *Viewer
+	service := &Viewer{Service: "storage-service"}
+	newUser := &Viewer{ClerkUserID: "user_new"}
+
+	tests := []struct {
+		name   string
+		viewer *Viewer
+		admin  bool
+		access map[int]bool
+	}{
+		{name: "none", viewer: none, access: map[int]bool{1: false}},
+		{name: "service", viewer: service, admin: true, access: map[int]bool{1: true, 2: true}},
+		{name: "no record", viewer: newUser, access: map[int]bool{0: false, 1: false}},
+	}
+	for _, tt := range tests {
+		if got := tt.viewer.IsAdmin(); got != tt.admin {
+			t.Errorf("%s: IsAdmin = %v, want %v", tt.name, got, tt.admin)
+		}
+		for id, want := range tt.access {
+			if got := tt.viewer.CanAccess(id); got != want {
+				t.Errorf("%s: CanAccess(%d) = %v, want %v", tt.name, id, got, want)
+			}
+		}
+	}
+
+	ctx := WithViewer(context.Background(), service)
+	if ViewerFromContext(ctx) != service || ViewerFromContext(context.Background()) != nil {
+		t.Error("ViewerFromContext does not return the viewer of WithViewer")
+	}
+}
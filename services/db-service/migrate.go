@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+
+	"db-service/ent/migrate"
+	"db-service/migrations"
+)
+
+const migrateUsage = `Usage: db-service migrate <command>
+
+Commands:
+  status                        Show the current revision and pending migrations
+  apply [-baseline V] [-n N]    Apply pending migrations (N at most, all by default).
+                                -baseline marks an existing database as being at version V
+  diff <name>                   Write a new migration from the ent schema (needs DEV_DATABASE_URL)
+  hash                          Recompute migrations/atlas.sum after editing files by hand
+`
+
+// runMigrate implements the `migrate` subcommand.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "status":
+		m := openMigrator()
+		st, err := m.Status(ctx)
+		if err != nil {
+			log.Fatalf("reading migration status: %v", err)
+		}
+		current := st.Current
+		if current == "" {
+			current = "none"
+		}
+		fmt.Printf("Current revision: %s\nLatest revision:  %s\n", current, st.Latest)
+		for _, p := range st.Pending {
+			fmt.Printf("Pending: %s\n", p)
+		}
+		if !st.UpToDate() {
+			os.Exit(1)
+		}
+
+	case "apply":
+		fs := flag.NewFlagSet("apply", flag.ExitOnError)
+		baseline := fs.String("baseline", "", "version an existing database is already at")
+		n := fs.Int("n", 0, "maximum number of migrations to apply")
+		_ = fs.Parse(args[1:])
+
+		m := openMigrator()
+		if err := m.Apply(ctx, *n, *baseline, migrateLogger{}); err != nil {
+			log.Fatalf("applying migrations: %v", err)
+		}
+
+	case "diff":
+		if len(args) < 2 {
+			log.Fatal("migrate diff: missing migration name")
+		}
+		devURL := os.Getenv("DEV_DATABASE_URL")
+		if devURL == "" {
+			log.Fatal("migrate diff: DEV_DATABASE_URL is not set (an empty database used to compute the diff)")
+		}
+		dir, err := atlas.NewLocalDir("migrations")
+		if err != nil {
+			log.Fatalf("opening migrations directory: %v", err)
+		}
+		err = migrate.NamedDiff(ctx, devURL, args[1],
+			schema.WithDir(dir),
+			schema.WithMigrationMode(schema.ModeReplay),
+			schema.WithDialect(dialect.Postgres),
+			schema.WithFormatter(atlas.DefaultFormatter),
+			schema.WithDropColumn(true),
+			schema.WithDropIndex(true),
+		)
+		if err != nil {
+			log.Fatalf("generating migration: %v", err)
+		}
+
+	case "hash":
+		if err := migrations.Hash("migrations"); err != nil {
+			log.Fatalf("hashing migrations: %v", err)
+		}
+
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
+
+func openMigrator() *migrations.Migrator {
+	db, err := sql.Open("postgres", os.Getenv("DATABASE_URL"))
+	if err != nil {
+		log.Fatalf("failed opening connection to db: %v", err)
+	}
+	m, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("failed initializing migrations: %v", err)
+	}
+	return m
+}
+
+// migrateLogger prints the progress of `migrate apply`.
+type migrateLogger struct{}
+
+func (migrateLogger) Log(e atlas.LogEntry) {
+	switch e := e.(type) {
+	case atlas.LogExecution:
+		if len(e.Files) == 0 {
+			log.Println("No pending migrations")
+		}
+	case atlas.LogFile:
+		log.Printf("Applying %s", e.File.Name())
+	case atlas.LogStmt:
+		log.Printf("  %s", e.SQL)
+	case atlas.LogError:
+		log.Printf("Error: %v (statement: %s)", e.Error, e.SQL)
+	case atlas.LogDone:
+		log.Println("Migrations applied")
+	}
+}
@@ -0,0 +1,10 @@
+-- Create "users" table
+CREATE TABLE "users" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "clerk_user_id" character varying NOT NULL, "role" character varying NOT NULL DEFAULT 'user', "is_subscribed" boolean NOT NULL DEFAULT false, "subscription_tier" character varying NOT NULL DEFAULT 'free', "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- Create index "users_clerk_user_id_key" to table: "users"
+CREATE UNIQUE INDEX "users_clerk_user_id_key" ON "users" ("clerk_user_id");
+-- Create "subscriptions" table
+CREATE TABLE "subscriptions" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "stripe_customer_id" character varying NOT NULL, "stripe_subscription_id" character varying NOT NULL, "status" character varying NOT NULL DEFAULT 'inactive', "current_period_end" timestamptz NULL, "user_subscription" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "subscriptions_users_subscription" FOREIGN KEY ("user_subscription") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "subscriptions_stripe_customer_id_key" to table: "subscriptions"
+CREATE UNIQUE INDEX "subscriptions_stripe_customer_id_key" ON "subscriptions" ("stripe_customer_id");
+-- Create index "subscriptions_stripe_subscription_id_key" to table: "subscriptions"
+CREATE UNIQUE INDEX "subscriptions_stripe_subscription_id_key" ON "subscriptions" ("stripe_subscription_id");
@@ -0,0 +1,4 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "uuid" uuid NOT NULL DEFAULT gen_random_uuid();
+-- Create index "users_uuid_key" to table: "users"
+CREATE UNIQUE INDEX "users_uuid_key" ON "users" ("uuid");
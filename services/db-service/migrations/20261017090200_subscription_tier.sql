@@ -0,0 +1,8 @@
+-- Modify "subscriptions" table
+ALTER TABLE "subscriptions" ADD COLUMN "tier" character varying NULL;
+-- Backfill the tier of existing subscriptions from the one stored on their user
+UPDATE "subscriptions" AS s SET "tier" = u."subscription_tier" FROM "users" AS u WHERE u."id" = s."user_subscription" AND u."subscription_tier" NOT IN ('free', '');
+-- Users without a paid tier (lapsed or never active) tell nothing: premium, the
+-- default of the schema, until a Stripe event sets the tier from the price
+UPDATE "subscriptions" SET "tier" = 'premium' WHERE "tier" IS NULL;
+ALTER TABLE "subscriptions" ALTER COLUMN "tier" SET NOT NULL, ALTER COLUMN "tier" SET DEFAULT 'premium';
@@ -0,0 +1,8 @@
+-- Map legacy free-form statuses onto the lifecycle states
+UPDATE "subscriptions" SET "status" = 'expired' WHERE "status" NOT IN ('trialing', 'active', 'past_due', 'canceled', 'expired', 'paused');
+-- Modify "subscriptions" table
+ALTER TABLE "subscriptions" ALTER COLUMN "status" SET DEFAULT 'active';
+-- Create "subscription_events" table
+CREATE TABLE "subscription_events" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "previous_status" character varying NULL, "status" character varying NOT NULL, "source" character varying NOT NULL, "reason" character varying NULL, "created_at" timestamptz NOT NULL, "subscription_events" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "subscription_events_subscriptions_events" FOREIGN KEY ("subscription_events") REFERENCES "subscriptions" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "subscriptionevent_subscription_events" to table: "subscription_events"
+CREATE INDEX "subscriptionevent_subscription_events" ON "subscription_events" ("subscription_events");
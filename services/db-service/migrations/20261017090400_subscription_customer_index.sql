@@ -0,0 +1,4 @@
+-- Drop index "subscriptions_stripe_customer_id_key" from table: "subscriptions"
+DROP INDEX "subscriptions_stripe_customer_id_key";
+-- Create index "subscription_stripe_customer_id" to table: "subscriptions"
+CREATE INDEX "subscription_stripe_customer_id" ON "subscriptions" ("stripe_customer_id");
@@ -0,0 +1,6 @@
+-- Create "processed_events" table
+CREATE TABLE "processed_events" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "provider" character varying NOT NULL, "event_id" character varying NOT NULL, "event_type" character varying NULL, "resource_id" character varying NULL, "event_created_at" timestamptz NULL, "received_at" timestamptz NOT NULL, "processed_at" timestamptz NULL, "outcome" character varying NOT NULL, "error" character varying NULL, "attempts" bigint NOT NULL DEFAULT 1, PRIMARY KEY ("id"));
+-- Create index "processedevent_provider_event_id" to table: "processed_events"
+CREATE UNIQUE INDEX "processedevent_provider_event_id" ON "processed_events" ("provider", "event_id");
+-- Create index "processedevent_provider_resource_id_event_created_at" to table: "processed_events"
+CREATE INDEX "processedevent_provider_resource_id_event_created_at" ON "processed_events" ("provider", "resource_id", "event_created_at");
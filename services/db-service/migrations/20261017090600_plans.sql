@@ -0,0 +1,27 @@
+-- Create "plans" table
+CREATE TABLE "plans" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "key" character varying NOT NULL, "name" character varying NULL, "stripe_price_ids" jsonb NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- Create index "plans_key_key" to table: "plans"
+CREATE UNIQUE INDEX "plans_key_key" ON "plans" ("key");
+-- Create "entitlements" table
+CREATE TABLE "entitlements" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "key" character varying NOT NULL, "enabled" boolean NOT NULL DEFAULT true, "limit" bigint NULL, "plan_entitlements" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "entitlements_plans_entitlements" FOREIGN KEY ("plan_entitlements") REFERENCES "plans" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "entitlement_key_plan_entitlements" to table: "entitlements"
+CREATE UNIQUE INDEX "entitlement_key_plan_entitlements" ON "entitlements" ("key", "plan_entitlements");
+-- Seed the catalog matching the existing tiers; limits are edited afterwards through /plans
+INSERT INTO "plans" ("key", "name", "created_at", "updated_at") VALUES ('free', 'Free', now(), now()), ('basic', 'Basic', now(), now()), ('premium', 'Premium', now(), now());
+INSERT INTO "entitlements" ("key", "enabled", "limit", "plan_entitlements")
+SELECT e."key", e."enabled", e."limit", p."id"
+FROM (VALUES
+  ('free', 'sync', false, NULL::bigint),
+  ('free', 'max_cloud_backups', true, 0),
+  ('free', 'backup_retention_days', true, 0),
+  ('free', 'max_devices', true, 1),
+  ('basic', 'sync', true, NULL),
+  ('basic', 'max_cloud_backups', true, 5),
+  ('basic', 'backup_retention_days', true, 30),
+  ('basic', 'max_devices', true, 2),
+  ('premium', 'sync', true, NULL),
+  ('premium', 'max_cloud_backups', true, 50),
+  ('premium', 'backup_retention_days', true, 365),
+  ('premium', 'max_devices', true, 5)
+) AS e ("plan", "key", "enabled", "limit")
+JOIN "plans" p ON p."key" = e."plan";
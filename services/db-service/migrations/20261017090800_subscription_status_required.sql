@@ -0,0 +1,2 @@
+-- Modify "subscriptions" table
+ALTER TABLE "subscriptions" ALTER COLUMN "status" DROP DEFAULT;
@@ -0,0 +1,4 @@
+-- Modify "plans" table
+ALTER TABLE "plans" ADD COLUMN "rank" bigint NOT NULL DEFAULT 0;
+-- Rank the seeded plans as the tiers were ordered before: free, basic, premium
+UPDATE "plans" SET "rank" = CASE "key" WHEN 'basic' THEN 1 WHEN 'premium' THEN 2 ELSE 0 END;
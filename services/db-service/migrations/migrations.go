@@ -0,0 +1,199 @@
+// Package migrations holds the versioned SQL migrations of db-service and
+// applies them with Atlas. The files are embedded in the binary.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+)
+
+//go:embed *.sql atlas.sum
+var files embed.FS
+
+// lockName is the advisory lock held while applying migrations, so several
+// instances running `migrate apply` cannot interleave.
+const lockName = "db-service_migrate"
+
+// Dir returns the embedded migration directory.
+func Dir() (migrate.Dir, error) {
+	dir := migrate.OpenMemDir("db-service")
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		b, err := files.ReadFile(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if err := dir.WriteFile(e.Name(), b); err != nil {
+			return nil, err
+		}
+	}
+	return dir, nil
+}
+
+// LatestVersion returns the version of the last migration file.
+func LatestVersion() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	fs, err := dir.Files()
+	if err != nil {
+		return "", err
+	}
+	if len(fs) == 0 {
+		return "", errors.New("no migration files")
+	}
+	return fs[len(fs)-1].Version(), nil
+}
+
+// Status describes the migration state of a database.
+type Status struct {
+	Current string   // last applied version, empty for a fresh database
+	Latest  string   // version of the last migration file
+	Pending []string // migration files not applied yet
+	Applied []*migrate.Revision
+}
+
+// UpToDate reports whether the database is exactly at the latest revision.
+func (s *Status) UpToDate() bool {
+	return len(s.Pending) == 0 && s.Current == s.Latest
+}
+
+// Migrator applies the embedded migrations to a Postgres database.
+type Migrator struct {
+	db  *sql.DB
+	drv migrate.Driver
+	dir migrate.Dir
+	rrw *revisions
+}
+
+// New returns a Migrator for the database.
+func New(db *sql.DB) (*Migrator, error) {
+	drv, err := postgres.Open(db)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, drv: drv, dir: dir, rrw: &revisions{db: db}}, nil
+}
+
+func (m *Migrator) executor(opts ...migrate.ExecutorOption) (*migrate.Executor, error) {
+	return migrate.NewExecutor(m.drv, m.dir, m.rrw, opts...)
+}
+
+// Status reads the applied revisions and lists the pending migrations.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	latest, err := LatestVersion()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.rrw.ReadRevisions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &Status{Latest: latest, Applied: applied}
+	if len(applied) > 0 {
+		st.Current = applied[len(applied)-1].Version
+	}
+
+	ex, err := m.executor()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := ex.Pending(ctx)
+	switch {
+	case errors.Is(err, migrate.ErrNoPendingFiles):
+	case err != nil:
+		return nil, err
+	default:
+		for _, f := range pending {
+			st.Pending = append(st.Pending, f.Name())
+		}
+	}
+	return st, nil
+}
+
+// Apply runs the pending migrations (at most n when n > 0). baseline, when set,
+// marks an existing database as already being at that version on the first run.
+func (m *Migrator) Apply(ctx context.Context, n int, baseline string, log migrate.Logger) error {
+	if err := m.rrw.init(ctx); err != nil {
+		return err
+	}
+
+	locker, ok := m.drv.(schema.Locker)
+	if !ok {
+		return errors.New("driver does not support locking")
+	}
+	unlock, err := locker.Lock(ctx, lockName, time.Minute)
+	if err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer unlock()
+
+	opts := []migrate.ExecutorOption{migrate.WithLogger(log), migrate.WithOperatorVersion("db-service")}
+	if baseline != "" {
+		opts = append(opts, migrate.WithBaselineVersion(baseline))
+	}
+	ex, err := m.executor(opts...)
+	if err != nil {
+		return err
+	}
+
+	err = ex.ExecuteN(ctx, n)
+	if errors.Is(err, migrate.ErrNoPendingFiles) {
+		return nil
+	}
+	return err
+}
+
+// Check returns an error unless the database is exactly at the latest revision.
+// It never modifies the database, so it is safe to run on every start.
+func (m *Migrator) Check(ctx context.Context) error {
+	st, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if !st.UpToDate() {
+		current := st.Current
+		if current == "" {
+			current = "none"
+		}
+		return fmt.Errorf("database is at revision %s, expected %s (%d pending): run `db-service migrate apply`",
+			current, st.Latest, len(st.Pending))
+	}
+	return nil
+}
+
+// Hash recomputes the atlas.sum file of a migration directory on disk.
+// It must be run after editing migration files by hand.
+func Hash(path string) error {
+	dir, err := migrate.NewLocalDir(path)
+	if err != nil {
+		return err
+	}
+	fs, err := dir.Files()
+	if err != nil {
+		return err
+	}
+	sum, err := migrate.NewHashFile(fs)
+	if err != nil {
+		return err
+	}
+	return migrate.WriteSumFile(dir, sum)
+}
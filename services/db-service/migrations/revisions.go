@@ -0,0 +1,147 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+)
+
+// revisionsTable is compatible with the history table of the Atlas CLI, so
+// either can be used against the same database.
+const revisionsTable = "atlas_schema_revisions"
+
+// revisions stores the applied migrations in revisionsTable.
+type revisions struct {
+	db *sql.DB
+}
+
+var _ migrate.RevisionReadWriter = (*revisions)(nil)
+
+func (r *revisions) init(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS "public"."`+revisionsTable+`" (
+		"version" character varying NOT NULL,
+		"description" character varying NOT NULL,
+		"type" bigint NOT NULL DEFAULT 2,
+		"applied" bigint NOT NULL DEFAULT 0,
+		"total" bigint NOT NULL DEFAULT 0,
+		"executed_at" timestamptz NOT NULL,
+		"execution_time" bigint NOT NULL,
+		"error" text NULL,
+		"error_stmt" text NULL,
+		"hash" character varying NOT NULL,
+		"partial_hashes" jsonb NULL,
+		"operator_version" character varying NOT NULL,
+		PRIMARY KEY ("version")
+	)`)
+	return err
+}
+
+// exists reports whether the history table was created, so read-only
+// commands do not have to create it.
+func (r *revisions) exists(ctx context.Context) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx, `SELECT to_regclass('"public"."`+revisionsTable+`"') IS NOT NULL`).Scan(&ok)
+	return ok, err
+}
+
+// Ident implements migrate.RevisionReadWriter.
+func (r *revisions) Ident() *migrate.TableIdent {
+	return &migrate.TableIdent{Name: revisionsTable, Schema: "public"}
+}
+
+const revisionColumns = `"version", "description", "type", "applied", "total", "executed_at",
+	"execution_time", "error", "error_stmt", "hash", "partial_hashes", "operator_version"`
+
+// ReadRevisions implements migrate.RevisionReadWriter.
+func (r *revisions) ReadRevisions(ctx context.Context) ([]*migrate.Revision, error) {
+	if ok, err := r.exists(ctx); err != nil || !ok {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT `+revisionColumns+` FROM "public"."`+revisionsTable+`" ORDER BY "version"`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revs []*migrate.Revision
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revs = append(revs, rev)
+	}
+	return revs, rows.Err()
+}
+
+// ReadRevision implements migrate.RevisionReadWriter.
+func (r *revisions) ReadRevision(ctx context.Context, version string) (*migrate.Revision, error) {
+	if ok, err := r.exists(ctx); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, migrate.ErrRevisionNotExist
+	}
+	row := r.db.QueryRowContext(ctx, `SELECT `+revisionColumns+` FROM "public"."`+revisionsTable+`" WHERE "version" = $1`, version)
+	rev, err := scanRevision(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, migrate.ErrRevisionNotExist
+	}
+	return rev, err
+}
+
+// WriteRevision implements migrate.RevisionReadWriter.
+func (r *revisions) WriteRevision(ctx context.Context, rev *migrate.Revision) error {
+	partial, err := json.Marshal(rev.PartialHashes)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `INSERT INTO "public"."`+revisionsTable+`" (`+revisionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT ("version") DO UPDATE SET
+			"description" = EXCLUDED."description", "type" = EXCLUDED."type",
+			"applied" = EXCLUDED."applied", "total" = EXCLUDED."total",
+			"executed_at" = EXCLUDED."executed_at", "execution_time" = EXCLUDED."execution_time",
+			"error" = EXCLUDED."error", "error_stmt" = EXCLUDED."error_stmt",
+			"hash" = EXCLUDED."hash", "partial_hashes" = EXCLUDED."partial_hashes",
+			"operator_version" = EXCLUDED."operator_version"`,
+		rev.Version, rev.Description, rev.Type, rev.Applied, rev.Total, rev.ExecutedAt,
+		int64(rev.ExecutionTime), nullString(rev.Error), nullString(rev.ErrorStmt), rev.Hash, partial, rev.OperatorVersion,
+	)
+	return err
+}
+
+// DeleteRevision implements migrate.RevisionReadWriter.
+func (r *revisions) DeleteRevision(ctx context.Context, version string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM "public"."`+revisionsTable+`" WHERE "version" = $1`, version)
+	return err
+}
+
+func scanRevision(row interface{ Scan(...any) error }) (*migrate.Revision, error) {
+	var (
+		rev             migrate.Revision
+		executionTime   int64
+		errMsg, errStmt sql.NullString
+		partial         []byte
+	)
+	if err := row.Scan(&rev.Version, &rev.Description, &rev.Type, &rev.Applied, &rev.Total, &rev.ExecutedAt,
+		&executionTime, &errMsg, &errStmt, &rev.Hash, &partial, &rev.OperatorVersion); err != nil {
+		return nil, err
+	}
+	rev.ExecutionTime = time.Duration(executionTime)
+	rev.Error = errMsg.String
+	rev.ErrorStmt = errStmt.String
+	if len(partial) > 0 {
+		if err := json.Unmarshal(partial, &rev.PartialHashes); err != nil {
+			return nil, err
+		}
+	}
+	return &rev, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,179 @@
+// Package pagination implements opaque keyset (cursor) pagination for list
+// endpoints, ordered by a timestamp column with the ID as tie-breaker.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// DefaultLimit is the page size when ?limit= is not set.
+	DefaultLimit = 20
+	// MaxLimit is the largest accepted page size.
+	MaxLimit = 100
+)
+
+var (
+	ErrInvalidLimit  = errors.New("limit must be between 1 and 100")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidOrder  = errors.New("order must be asc or desc")
+)
+
+// Cursor is the position after the last item of a page.
+type Cursor struct {
+	Time time.Time `json:"t,omitempty"`
+	ID   int       `json:"i"`
+	Desc bool      `json:"d,omitempty"`
+}
+
+// Encode returns the opaque form of the cursor.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor returned by Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Params are the pagination query parameters of a request.
+type Params struct {
+	Limit int
+	After *Cursor
+	Desc  bool
+}
+
+// ParseParams reads ?limit=, ?cursor= and ?order=asc|desc (defaultDesc when unset).
+func ParseParams(c *fiber.Ctx, defaultDesc bool) (Params, error) {
+	p := Params{Limit: DefaultLimit, Desc: defaultDesc}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return p, ErrInvalidLimit
+		}
+		p.Limit = limit
+	}
+
+	switch c.Query("order") {
+	case "":
+	case "asc":
+		p.Desc = false
+	case "desc":
+		p.Desc = true
+	default:
+		return p, ErrInvalidOrder
+	}
+
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := DecodeCursor(v)
+		if err != nil {
+			return p, err
+		}
+		// A cursor only makes sense in the order it was created with
+		if c.Query("order") != "" && cursor.Desc != p.Desc {
+			return p, ErrInvalidCursor
+		}
+		p.Desc = cursor.Desc
+		p.After = cursor
+	}
+
+	return p, nil
+}
+
+// NewParams is ParseParams for calls other than HTTP requests, e.g. gRPC:
+// limit is DefaultLimit when zero, and a cursor keeps the order it was
+// created with.
+func NewParams(limit int, cursor string, defaultDesc bool) (Params, error) {
+	p := Params{Limit: DefaultLimit, Desc: defaultDesc}
+
+	if limit != 0 {
+		if limit < 1 || limit > MaxLimit {
+			return p, ErrInvalidLimit
+		}
+		p.Limit = limit
+	}
+
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return p, err
+		}
+		p.Desc = c.Desc
+		p.After = c
+	}
+
+	return p, nil
+}
+
+// OrderTerm returns the ent order option matching the requested direction.
+func (p Params) OrderTerm() sql.OrderTermOption {
+	if p.Desc {
+		return sql.OrderDesc()
+	}
+	return sql.OrderAsc()
+}
+
+// Predicate restricts a query to the items after the cursor. timeColumn may
+// be empty for tables paginated by ID only. Convert it to the entity
+// predicate type, e.g. predicate.User(p.Predicate(user.FieldCreatedAt, user.FieldID)).
+func (p Params) Predicate(timeColumn, idColumn string) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if p.After == nil {
+			return
+		}
+		cmp := sql.GT
+		if p.Desc {
+			cmp = sql.LT
+		}
+		id := s.C(idColumn)
+		if timeColumn == "" {
+			s.Where(cmp(id, p.After.ID))
+			return
+		}
+		t := s.C(timeColumn)
+		s.Where(sql.Or(
+			cmp(t, p.After.Time),
+			sql.And(sql.EQ(t, p.After.Time), cmp(id, p.After.ID)),
+		))
+	}
+}
+
+// Page is the response envelope of list endpoints. NextCursor is null on the last page.
+type Page[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// NewPage builds a page from items queried with a limit of p.Limit+1: the
+// extra item only tells whether there is a next page.
+func NewPage[T any](items []T, p Params, cursor func(T) Cursor) Page[T] {
+	page := Page[T]{Data: items}
+	if len(items) > p.Limit {
+		page.Data = items[:p.Limit]
+		next := cursor(page.Data[p.Limit-1])
+		next.Desc = p.Desc
+		encoded := next.Encode()
+		page.NextCursor = &encoded
+	}
+	if page.Data == nil {
+		page.Data = []T{}
+	}
+	return page
+}
@@ -0,0 +1,194 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"db-service/dbtest"
+	"db-service/ent"
+	"db-service/ent/predicate"
+	"db-service/ent/user"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c, err := DecodeCursor(Cursor{Time: at, ID: 7, Desc: true}.Encode())
+	if err != nil || !c.Time.Equal(at) || c.ID != 7 || !c.Desc {
+		t.Errorf("DecodeCursor(Encode) = %+v, %v", c, err)
+	}
+
+	for _, s := range []string{"not base64!", "bm90IGpzb24", Cursor{ID: 0}.Encode(), Cursor{ID: -1}.Encode()} {
+		if _, err := DecodeCursor(s); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	asc, desc := Cursor{ID: 3}.Encode(), Cursor{ID: 3, Desc: true}.Encode()
+
+	tests := []struct {
+		name        string
+		query       string
+		defaultDesc bool
+		want        Params
+		err         error
+	}{
+		{name: "defaults", want: Params{Limit: DefaultLimit}},
+		{name: "default desc", defaultDesc: true, want: Params{Limit: DefaultLimit, Desc: true}},
+		{name: "limit", query: "limit=5", want: Params{Limit: 5}},
+		{name: "max limit", query: "limit=100", want: Params{Limit: MaxLimit}},
+		{name: "zero limit", query: "limit=0", err: ErrInvalidLimit},
+		{name: "limit too large", query: "limit=101", err: ErrInvalidLimit},
+		{name: "limit not a number", query: "limit=ten", err: ErrInvalidLimit},
+		{name: "order asc", query: "order=asc", defaultDesc: true, want: Params{Limit: DefaultLimit}},
+		{name: "order desc", query: "order=desc", want: Params{Limit: DefaultLimit, Desc: true}},
+		{name: "bad order", query: "order=up", err: ErrInvalidOrder},
+		{name: "cursor", query: "cursor=" + asc, want: Params{Limit: DefaultLimit, After: &Cursor{ID: 3}}},
+		{name: "cursor keeps its order", query: "cursor=" + desc, want: Params{Limit: DefaultLimit, Desc: true, After: &Cursor{ID: 3, Desc: true}}},
+		{name: "cursor of the order", query: "order=desc&cursor=" + desc, want: Params{Limit: DefaultLimit, Desc: true, After: &Cursor{ID: 3, Desc: true}}},
+		{name: "cursor of another order", query: "order=asc&cursor=" + desc, err: ErrInvalidCursor},
+		{name: "bad cursor", query: "cursor=garbage", err: ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Params
+			var err error
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				got, err = ParseParams(c, tt.defaultDesc)
+				return nil
+			})
+			if _, terr := app.Test(httptest.NewRequest("GET", "/?"+tt.query, nil)); terr != nil {
+				t.Fatal(terr)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseParams = %v, want %v", err, tt.err)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseParams = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewParams(t *testing.T) {
+	desc := Cursor{ID: 3, Desc: true}.Encode()
+
+	tests := []struct {
+		name   string
+		limit  int
+		cursor string
+		want   Params
+		err    error
+	}{
+		{name: "defaults", want: Params{Limit: DefaultLimit}},
+		{name: "limit", limit: 5, want: Params{Limit: 5}},
+		{name: "negative limit", limit: -1, err: ErrInvalidLimit},
+		{name: "limit too large", limit: MaxLimit + 1, err: ErrInvalidLimit},
+		{name: "cursor keeps its order", cursor: desc, want: Params{Limit: DefaultLimit, Desc: true, After: &Cursor{ID: 3, Desc: true}}},
+		{name: "bad cursor", cursor: "garbage", err: ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParams(tt.limit, tt.cursor, false)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NewParams = %v, want %v", err, tt.err)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewParams = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPages(t *testing.T) {
+	ctx := context.Background()
+	client := dbtest.Open(t)
+
+	// Several users per timestamp: the ID breaks the ties
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var all []*ent.User
+	for i := range 7 {
+		u := client.User.Create().
+			SetClerkUserID(string(rune('a' + i))).
+			SetCreatedAt(start.Add(time.Duration(i/3) * time.Minute)).
+			SaveX(ctx)
+		all = append(all, u)
+	}
+	byTime := func(desc bool) []int {
+		sorted := slices.Clone(all)
+		slices.SortFunc(sorted, func(a, b *ent.User) int {
+			c := a.CreatedAt.Compare(b.CreatedAt)
+			if c == 0 {
+				c = a.ID - b.ID
+			}
+			if desc {
+				c = -c
+			}
+			return c
+		})
+		var ids []int
+		for _, u := range sorted {
+			ids = append(ids, u.ID)
+		}
+		return ids
+	}
+
+	for _, desc := range []bool{false, true} {
+		var ids []int
+		var cursor string
+		pages := 0
+		for {
+			p, err := NewParams(3, cursor, desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			users := client.User.Query().
+				Where(predicate.User(p.Predicate(user.FieldCreatedAt, user.FieldID))).
+				Order(user.ByCreatedAt(p.OrderTerm()), user.ByID(p.OrderTerm())).
+				Limit(p.Limit + 1).
+				AllX(ctx)
+			page := NewPage(users, p, func(u *ent.User) Cursor {
+				return Cursor{Time: u.CreatedAt, ID: u.ID}
+			})
+			pages++
+			for _, u := range page.Data {
+				ids = append(ids, u.ID)
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			cursor = *page.NextCursor
+		}
+		if want := byTime(desc); !slices.Equal(ids, want) || pages != 3 {
+			t.Errorf("desc=%v: %d pages of %v, want 3 pages of %v", desc, pages, ids, want)
+		}
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	p := Params{Limit: 2, Desc: true}
+	id := func(i int) Cursor { return Cursor{ID: i} }
+
+	if page := NewPage([]int(nil), p, id); page.Data == nil || len(page.Data) != 0 || page.NextCursor != nil {
+		t.Errorf("empty page = %+v, want no data and no next cursor", page)
+	}
+	if page := NewPage([]int{1, 2}, p, id); len(page.Data) != 2 || page.NextCursor != nil {
+		t.Errorf("last page = %+v, want no next cursor", page)
+	}
+	page := NewPage([]int{1, 2, 3}, p, id)
+	if !slices.Equal(page.Data, []int{1, 2}) || page.NextCursor == nil {
+		t.Fatalf("page = %+v, want [1 2] and a next cursor", page)
+	}
+	if c, err := DecodeCursor(*page.NextCursor); err != nil || c.ID != 2 || !c.Desc {
+		t.Errorf("next cursor = %+v, %v, want after 2 in desc order", c, err)
+	}
+}
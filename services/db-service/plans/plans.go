@@ -0,0 +1,77 @@
+// Package plans reads the plan catalog: which Stripe prices sell a plan and
+// the entitlements it grants (see shared/entitlements).
+//
+// A plan's key is the tier of the subscriptions selling it, so a user's plan
+// is the one matching their derived subscription_tier (package tiers).
+package plans
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+
+	"db-service/ent"
+	"db-service/ent/entitlement"
+	"db-service/ent/plan"
+	"db-service/tiers"
+	"shared/entitlements"
+)
+
+// List returns every plan with its entitlements.
+func List(ctx context.Context, client *ent.Client) ([]*ent.Plan, error) {
+	return client.Plan.Query().
+		WithEntitlements(byKey).
+		Order(ent.Asc(plan.FieldID)).
+		All(ctx)
+}
+
+// Get returns the plan with the given key and its entitlements.
+func Get(ctx context.Context, client *ent.Client, key string) (*ent.Plan, error) {
+	return client.Plan.Query().
+		Where(plan.Key(key)).
+		WithEntitlements(byKey).
+		Only(ctx)
+}
+
+// ByPriceID returns the plan sold at a Stripe price, with its entitlements.
+func ByPriceID(ctx context.Context, client *ent.Client, priceID string) (*ent.Plan, error) {
+	return client.Plan.Query().
+		Where(func(s *sql.Selector) {
+			s.Where(sqljson.ValueContains(plan.FieldStripePriceIds, priceID))
+		}).
+		WithEntitlements(byKey).
+		First(ctx)
+}
+
+func byKey(q *ent.EntitlementQuery) {
+	q.Order(ent.Asc(entitlement.FieldKey))
+}
+
+// ForUser returns the entitlements of u. A tier without a plan in the
+// catalog falls back to the free plan, and to no entitlement at all when
+// there is no free plan either.
+func ForUser(ctx context.Context, client *ent.Client, u *ent.User) (*entitlements.Set, error) {
+	p, err := Get(ctx, client, u.SubscriptionTier)
+	if ent.IsNotFound(err) && u.SubscriptionTier != tiers.Free {
+		p, err = Get(ctx, client, tiers.Free)
+	}
+	if ent.IsNotFound(err) {
+		return &entitlements.Set{UserID: int64(u.ID), Plan: u.SubscriptionTier, Entitlements: map[string]entitlements.Entitlement{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entitlements.Set{UserID: int64(u.ID), Plan: p.Key, Entitlements: Entitlements(p)}, nil
+}
+
+// Entitlements returns the entitlements of p, which must have been loaded
+// with its entitlements edge.
+func Entitlements(p *ent.Plan) map[string]entitlements.Entitlement {
+	m := make(map[string]entitlements.Entitlement, len(p.Edges.Entitlements))
+	for _, e := range p.Edges.Entitlements {
+		m[e.Key] = entitlements.Entitlement{Enabled: e.Enabled, Limit: e.Limit}
+	}
+	return m
+}
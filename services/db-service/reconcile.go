@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+
+	entsql "entgo.io/ent/dialect/sql"
+
+	"db-service/ent"
+	"db-service/expiry"
+	"db-service/lifecycle"
+	"db-service/migrations"
+	"db-service/tiers"
+)
+
+// runReconcile implements the `reconcile` subcommand: it recomputes
+// is_subscribed and subscription_tier of every user from their subscriptions.
+func runReconcile(policy tiers.Policy) {
+	client := openChecked()
+	defer client.Close()
+
+	fixed, err := policy.Reconcile(context.Background(), client, policy.Now())
+	if err != nil {
+		log.Fatalf("reconciling subscription tiers: %v", err)
+	}
+	log.Printf("Reconciled subscription tiers: %d user(s) updated", fixed)
+}
+
+// runExpire implements the `expire` subcommand: a single run of the job that
+// expires the subscriptions whose period lapsed (see expiry.Job).
+func runExpire(policy tiers.Policy) {
+	client := openChecked()
+	defer client.Close()
+	client.Subscription.Use(lifecycle.Hook(), policy.Hook())
+
+	job := &expiry.Job{Client: client, Policy: policy}
+	res, err := job.RunOnce(context.Background())
+	if err != nil {
+		log.Fatalf("expiring subscriptions: %v", err)
+	}
+	if res.Skipped {
+		log.Printf("Another instance is expiring subscriptions, nothing done")
+		return
+	}
+	log.Printf("Expired %d subscription(s), %d other user(s) downgraded", res.Expired, res.Recomputed)
+}
+
+// openChecked opens the database and checks it is at the expected migration
+// revision.
+func openChecked() *ent.Client {
+	db, err := sql.Open("postgres", os.Getenv("DATABASE_URL"))
+	if err != nil {
+		log.Fatalf("failed opening connection to db: %v", err)
+	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB("postgres", db)))
+
+	m, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("failed initializing migrations: %v", err)
+	}
+	if err := m.Check(context.Background()); err != nil {
+		log.Fatalf("database schema check failed: %v", err)
+	}
+	return client
+}
@@ -0,0 +1,121 @@
+package svix
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("test signing secret"))
+	other := "whsec_" + base64.StdEncoding.EncodeToString([]byte("another secret"))
+	now := time.Unix(1_760_000_000, 0)
+	body := []byte(`{"type":"user.created"}`)
+
+	signed := func(t *testing.T, secret, id string, ts time.Time) string {
+		t.Helper()
+		sig, err := Sign(secret, id, ts, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		timestamp  string
+		signatures func(t *testing.T) string
+		body       string
+		processed  string // message already marked as processed
+		want       error
+	}{
+		{name: "valid", id: "msg_1", signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now) }},
+		{
+			name: "rotated secret",
+			id:   "msg_1",
+			signatures: func(t *testing.T) string {
+				return signed(t, other, "msg_1", now) + " " + signed(t, secret, "msg_1", now)
+			},
+		},
+		{name: "missing headers", id: "msg_1", signatures: func(*testing.T) string { return "" }, want: ErrMissingHeaders},
+		{name: "other secret", id: "msg_1", signatures: func(t *testing.T) string { return signed(t, other, "msg_1", now) }, want: ErrInvalidSignature},
+		{name: "other message", id: "msg_2", signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now) }, want: ErrInvalidSignature},
+		{name: "tampered body", id: "msg_1", body: `{"type":"user.deleted"}`, signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now) }, want: ErrInvalidSignature},
+		{name: "other version", id: "msg_1", signatures: func(t *testing.T) string { return "v2" + signed(t, secret, "msg_1", now)[2:] }, want: ErrInvalidSignature},
+		{
+			name:       "stale timestamp",
+			id:         "msg_1",
+			timestamp:  "1759999600",
+			signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now.Add(-400*time.Second)) },
+			want:       ErrInvalidTimestamp,
+		},
+		{
+			name:       "future timestamp",
+			id:         "msg_1",
+			timestamp:  "1760000400",
+			signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now.Add(400*time.Second)) },
+			want:       ErrInvalidTimestamp,
+		},
+		{name: "malformed timestamp", id: "msg_1", timestamp: "yesterday", signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now) }, want: ErrInvalidTimestamp},
+		{name: "replay", id: "msg_1", processed: "msg_1", signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now) }, want: ErrReplayed},
+		{name: "other message processed", id: "msg_1", processed: "msg_0", signatures: func(t *testing.T) string { return signed(t, secret, "msg_1", now) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewVerifier(secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Now = func() time.Time { return now }
+			if tt.processed != "" {
+				v.MarkProcessed(tt.processed)
+			}
+			timestamp := tt.timestamp
+			if timestamp == "" {
+				timestamp = "1760000000"
+			}
+			b := body
+			if tt.body != "" {
+				b = []byte(tt.body)
+			}
+			if err := v.Verify(tt.id, timestamp, tt.signatures(t), b); !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayWindow(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("test signing secret"))
+	v, err := NewVerifier(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_760_000_000, 0)
+	v.Now = func() time.Time { return now }
+	v.MarkProcessed("msg_1")
+
+	// Forgotten once its timestamp fails anyway; a fresh retry with the same ID
+	// (as providers do) goes through
+	now = now.Add(2*DefaultTolerance + time.Second)
+	sig, err := Sign(secret, "msg_1", now, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Verify("msg_1", "1760000601", sig, nil); err != nil {
+		t.Errorf("Verify after the window = %v, want nil", err)
+	}
+}
+
+func TestInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "whsec_", "whsec_not base64!"} {
+		if _, err := NewVerifier(secret); err == nil {
+			t.Errorf("NewVerifier(%q) succeeded", secret)
+		}
+		if _, err := Sign(secret, "msg_1", time.Now(), nil); err == nil {
+			t.Errorf("Sign(%q) succeeded", secret)
+		}
+	}
+}
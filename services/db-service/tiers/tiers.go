@@ -0,0 +1,245 @@
+// Package tiers derives a user's subscription state (is_subscribed and
+// subscription_tier) from their Subscription rows.
+//
+// The user fields are a cache: they are recomputed by an ent hook whenever a
+// subscription is created, updated or deleted, and by Reconcile for the
+// subscriptions whose period ended without any write. Both follow a Policy,
+// shared with the expiry job.
+package tiers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"db-service/ent"
+	"db-service/ent/hook"
+	"db-service/ent/plan"
+	"db-service/ent/subscription"
+	"db-service/ent/user"
+)
+
+// Free is the tier of users without an entitling subscription.
+const Free = "free"
+
+// entitling lists the subscription statuses that grant their tier.
+// past_due keeps access while Stripe retries the payment, and canceled until
+// the end of the period that was paid for.
+var entitling = map[subscription.Status]bool{
+	subscription.StatusTrialing: true,
+	subscription.StatusActive:   true,
+	subscription.StatusPastDue:  true,
+	subscription.StatusCanceled: true,
+}
+
+// Ranks orders the tiers when a user has several entitling subscriptions:
+// the rank of the plan of each tier (a plan's key is its tier). A tier
+// without a plan ranks as the free one.
+type Ranks map[string]int
+
+// LoadRanks reads the rank of every plan of the catalog.
+func LoadRanks(ctx context.Context, client *ent.Client) (Ranks, error) {
+	all, err := client.Plan.Query().Select(plan.FieldKey, plan.FieldRank).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading plan ranks: %w", err)
+	}
+	ranks := make(Ranks, len(all))
+	for _, p := range all {
+		ranks[p.Key] = p.Rank
+	}
+	return ranks, nil
+}
+
+func (r Ranks) of(tier string) int {
+	if rank, ok := r[tier]; ok {
+		return rank
+	}
+	return r[Free]
+}
+
+// Policy decides until when a subscription grants its tier. The zero Policy
+// has no grace period and reads time.Now.
+type Policy struct {
+	// GracePeriod extends the access granted by a subscription past its
+	// period end, e.g. while a late renewal webhook is delivered.
+	GracePeriod time.Duration
+	// Clock returns the current time; time.Now when nil.
+	Clock func() time.Time
+}
+
+// Now returns the current time of the Policy's clock.
+func (p Policy) Now() time.Time {
+	if p.Clock != nil {
+		return p.Clock()
+	}
+	return time.Now()
+}
+
+// Lapsed reports whether a period that ended at end is over at now, grace
+// period included.
+func (p Policy) Lapsed(end, now time.Time) bool {
+	return !now.Before(end.Add(p.GracePeriod))
+}
+
+// State is the derived subscription state of a user.
+type State struct {
+	IsSubscribed     bool
+	SubscriptionTier string
+}
+
+// Entitles reports whether sub grants its tier at now.
+// A subscription without a period end is entitled for as long as its status
+// is, except a canceled one which has nothing left to use. Otherwise it is
+// entitled until the end of its period plus the grace period.
+func (p Policy) Entitles(sub *ent.Subscription, now time.Time) bool {
+	if !entitling[sub.Status] {
+		return false
+	}
+	if sub.CurrentPeriodEnd.IsZero() {
+		return sub.Status != subscription.StatusCanceled
+	}
+	return !p.Lapsed(sub.CurrentPeriodEnd, now)
+}
+
+// Effective returns the state granted by subs at now: the highest ranked
+// tier among the entitling subscriptions (the first one on a tie), or free
+// when there is none.
+func (p Policy) Effective(subs []*ent.Subscription, now time.Time, ranks Ranks) State {
+	st := State{SubscriptionTier: Free}
+	for _, sub := range subs {
+		if !p.Entitles(sub, now) {
+			continue
+		}
+		if !st.IsSubscribed || ranks.of(sub.Tier) > ranks.of(st.SubscriptionTier) {
+			st.SubscriptionTier = sub.Tier
+		}
+		st.IsSubscribed = true
+	}
+	return st
+}
+
+// Recompute stores the effective state of a user and reports whether it
+// changed. A missing user (e.g. deleted in the same transaction) is ignored.
+func (p Policy) Recompute(ctx context.Context, client *ent.Client, userID int, now time.Time) (bool, error) {
+	u, err := client.User.Query().
+		Where(user.ID(userID)).
+		WithSubscription().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	ranks, err := LoadRanks(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	return p.apply(ctx, client, u, now, ranks)
+}
+
+func (p Policy) apply(ctx context.Context, client *ent.Client, u *ent.User, now time.Time, ranks Ranks) (bool, error) {
+	st := p.Effective(u.Edges.Subscription, now, ranks)
+	if st.IsSubscribed == u.IsSubscribed && st.SubscriptionTier == u.SubscriptionTier {
+		return false, nil
+	}
+	err := client.User.UpdateOneID(u.ID).
+		SetIsSubscribed(st.IsSubscribed).
+		SetSubscriptionTier(st.SubscriptionTier).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("updating user %d: %w", u.ID, err)
+	}
+	return true, nil
+}
+
+// Reconcile recomputes the state of every user and returns the number of
+// users that were out of sync. It fixes drift left by direct SQL writes,
+// expires the subscriptions whose period ended since their last update and
+// applies the changes of the plan ranks.
+func (p Policy) Reconcile(ctx context.Context, client *ent.Client, now time.Time) (int, error) {
+	const batch = 500
+
+	ranks, err := LoadRanks(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := 0
+	lastID := 0
+	for {
+		users, err := client.User.Query().
+			Where(user.IDGT(lastID)).
+			WithSubscription().
+			Order(ent.Asc(user.FieldID)).
+			Limit(batch).
+			All(ctx)
+		if err != nil {
+			return fixed, err
+		}
+		for _, u := range users {
+			changed, err := p.apply(ctx, client, u, now, ranks)
+			if err != nil {
+				return fixed, err
+			}
+			if changed {
+				fixed++
+			}
+		}
+		if len(users) < batch {
+			return fixed, nil
+		}
+		lastID = users[len(users)-1].ID
+	}
+}
+
+// Hook keeps the user fields in sync with every Subscription mutation.
+// Register it with client.Subscription.Use(policy.Hook()); it runs on the
+// mutation's client, so inside a transaction the user update is part of it.
+func (p Policy) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.SubscriptionFunc(func(ctx context.Context, m *ent.SubscriptionMutation) (ent.Value, error) {
+			// Affected users are collected before the mutation: once deleted
+			// (or moved to another user) the rows no longer point to them.
+			userIDs, err := affectedUsers(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			if id, ok := m.UserID(); ok {
+				userIDs = append(userIDs, id)
+			}
+
+			now := p.Now()
+			seen := make(map[int]bool, len(userIDs))
+			for _, id := range userIDs {
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				if _, err := p.Recompute(ctx, m.Client(), id, now); err != nil {
+					return nil, fmt.Errorf("syncing subscription tier: %w", err)
+				}
+			}
+			return v, nil
+		})
+	}
+}
+
+func affectedUsers(ctx context.Context, m *ent.SubscriptionMutation) ([]int, error) {
+	if m.Op().Is(ent.OpCreate) {
+		return nil, nil
+	}
+	ids, err := m.IDs(ctx)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return m.Client().User.Query().
+		Where(user.HasSubscriptionWith(subscription.IDIn(ids...))).
+		IDs(ctx)
+}
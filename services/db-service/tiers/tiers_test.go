@@ -0,0 +1,60 @@
+package tiers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"db-service/dbtest"
+	"db-service/ent"
+	"db-service/ent/subscription"
+)
+
+func TestEffective(t *testing.T) {
+	ctx := context.Background()
+	client := dbtest.Open(t)
+	for key, rank := range map[string]int{Free: 0, "basic": 1, "premium": 2, "team": 3} {
+		client.Plan.Create().SetKey(key).SetRank(rank).ExecX(ctx)
+	}
+	ranks, err := LoadRanks(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	sub := func(tier string, status subscription.Status) *ent.Subscription {
+		return &ent.Subscription{Tier: tier, Status: status, CurrentPeriodEnd: now.Add(24 * time.Hour)}
+	}
+
+	tests := []struct {
+		name string
+		subs []*ent.Subscription
+		want State
+	}{
+		{name: "none", want: State{SubscriptionTier: Free}},
+		{name: "single", subs: []*ent.Subscription{sub("basic", subscription.StatusActive)}, want: State{true, "basic"}},
+		{
+			name: "highest rank",
+			subs: []*ent.Subscription{sub("basic", subscription.StatusActive), sub("team", subscription.StatusActive), sub("premium", subscription.StatusActive)},
+			want: State{true, "team"},
+		},
+		{
+			name: "not entitling",
+			subs: []*ent.Subscription{sub("premium", subscription.StatusExpired), sub("basic", subscription.StatusActive)},
+			want: State{true, "basic"},
+		},
+		{
+			name: "tier without a plan ranks as free",
+			subs: []*ent.Subscription{sub("legacy", subscription.StatusActive), sub("basic", subscription.StatusActive)},
+			want: State{true, "basic"},
+		},
+		{name: "only a tier without a plan", subs: []*ent.Subscription{sub("legacy", subscription.StatusActive)}, want: State{true, "legacy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Policy{}).Effective(tt.subs, now, ranks); got != tt.want {
+				t.Errorf("Effective = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package billing wraps the Stripe API calls of payment-service behind the
+// Client interface, so that billingtest.Fake can stand in for Stripe locally
+// and in tests.
+package billing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Client is the subset of the Stripe API used by payment-service.
+type Client interface {
+	// CreateCheckoutSession starts a Checkout session in subscription mode.
+	CreateCheckoutSession(ctx context.Context, params CheckoutParams) (*Session, error)
+	// CreatePortalSession opens the Billing Portal of a customer.
+	CreatePortalSession(ctx context.Context, customerID, returnURL string) (*Session, error)
+	// GetSubscription returns the current state of a subscription.
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+}
+
+// CheckoutParams describes a Checkout session for one price.
+type CheckoutParams struct {
+	PriceID string
+	// CustomerID reuses an existing Stripe customer; a new one is created when empty.
+	CustomerID string
+	// ClientReferenceID is echoed back in checkout.session.completed.
+	ClientReferenceID string
+	SuccessURL        string
+	CancelURL         string
+	// Metadata is copied to the session and to the created subscription.
+	Metadata map[string]string
+}
+
+// Session is a Checkout or Billing Portal session the user is redirected to.
+type Session struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Subscription is the state of a Stripe subscription.
+type Subscription struct {
+	ID                string
+	CustomerID        string
+	Status            string // Stripe status: trialing, active, past_due, unpaid, canceled, ...
+	PriceID           string
+	CurrentPeriodEnd  time.Time
+	CancelAtPeriodEnd bool
+	Metadata          map[string]string
+}
+
+// LifecycleStatus maps the Stripe status onto db-service's subscription
+// lifecycle. It returns "" for incomplete subscriptions (the first payment
+// has not succeeded yet), which are not stored.
+func (s *Subscription) LifecycleStatus() string {
+	switch s.Status {
+	case "trialing", "past_due", "paused":
+		return s.Status
+	case "active":
+		// Canceled from the portal: still paid until the end of the period
+		if s.CancelAtPeriodEnd {
+			return "canceled"
+		}
+		return "active"
+	case "unpaid":
+		return "past_due"
+	case "canceled", "incomplete_expired":
+		// Stripe's canceled is final, i.e. db-service's expired
+		return "expired"
+	default:
+		return ""
+	}
+}
+
+// Prices maps subscription tiers to Stripe price IDs.
+type Prices map[string]string
+
+// ParsePrices parses the STRIPE_PRICES setting: a comma-separated list of
+// tier=price_id pairs (e.g. "basic=price_123,premium=price_456").
+func ParsePrices(s string) (Prices, error) {
+	prices := make(Prices)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		tier, price, ok := strings.Cut(pair, "=")
+		if !ok || tier == "" || price == "" {
+			return nil, fmt.Errorf("invalid price entry %q: expected tier=price_id", pair)
+		}
+		prices[tier] = price
+	}
+	return prices, nil
+}
+
+// Tier returns the tier sold at priceID, or "" when it is unknown.
+func (p Prices) Tier(priceID string) string {
+	for tier, id := range p {
+		if id == priceID {
+			return tier
+		}
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+// Package billingtest provides an in-memory billing.Client and signed Stripe
+// webhook payloads, to run payment-service without Stripe.
+package billingtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-go/v82/webhook"
+
+	"payment-service/billing"
+)
+
+// ErrNotFound is returned for unknown sessions and subscriptions.
+var ErrNotFound = errors.New("billingtest: not found")
+
+// Fake is an in-memory billing.Client. Checkout sessions do nothing until
+// CompleteCheckout is called, as when the user pays on Stripe.
+type Fake struct {
+	// Now is used for event timestamps and period ends; time.Now when nil.
+	Now func() time.Time
+
+	mu            sync.Mutex
+	seq           int
+	sessions      map[string]billing.CheckoutParams
+	subscriptions map[string]*billing.Subscription
+}
+
+var _ billing.Client = (*Fake)(nil)
+
+// NewFake returns an empty Fake.
+func NewFake() *Fake {
+	return &Fake{
+		sessions:      make(map[string]billing.CheckoutParams),
+		subscriptions: make(map[string]*billing.Subscription),
+	}
+}
+
+func (f *Fake) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+// newID returns a unique Stripe-like ID; f.mu must be held.
+func (f *Fake) newID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s_test_%d", prefix, f.seq)
+}
+
+func (f *Fake) CreateCheckoutSession(_ context.Context, params billing.CheckoutParams) (*billing.Session, error) {
+	if params.PriceID == "" {
+		return nil, errors.New("billingtest: missing price")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("cs")
+	f.sessions[id] = params
+	return &billing.Session{ID: id, URL: "https://checkout.stripe.test/" + id}, nil
+}
+
+func (f *Fake) CreatePortalSession(_ context.Context, customerID, _ string) (*billing.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("bps")
+	return &billing.Session{ID: id, URL: "https://billing.stripe.test/" + customerID + "/" + id}, nil
+}
+
+func (f *Fake) GetSubscription(_ context.Context, id string) (*billing.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, ok := f.subscriptions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *sub
+	return &cp, nil
+}
+
+// Session returns the parameters a Checkout session was created with.
+func (f *Fake) Session(id string) (billing.CheckoutParams, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.sessions[id]
+	return p, ok
+}
+
+// CompleteCheckout simulates a successful payment: it creates an active
+// subscription for the session, and returns it with the matching
+// checkout.session.completed event object.
+func (f *Fake) CompleteCheckout(sessionID string) (*billing.Subscription, map[string]any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	params, ok := f.sessions[sessionID]
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	customer := params.CustomerID
+	if customer == "" {
+		customer = f.newID("cus")
+	}
+	sub := &billing.Subscription{
+		ID:               f.newID("sub"),
+		CustomerID:       customer,
+		Status:           "active",
+		PriceID:          params.PriceID,
+		CurrentPeriodEnd: f.now().Add(30 * 24 * time.Hour).Truncate(time.Second),
+		Metadata:         params.Metadata,
+	}
+	f.subscriptions[sub.ID] = sub
+
+	session := map[string]any{
+		"id":                  sessionID,
+		"object":              "checkout.session",
+		"mode":                "subscription",
+		"client_reference_id": params.ClientReferenceID,
+		"customer":            customer,
+		"subscription":        sub.ID,
+		"metadata":            params.Metadata,
+	}
+	cp := *sub
+	return &cp, session, nil
+}
+
+// UpdateSubscription changes a subscription with fn and returns its new
+// state, e.g. to cancel it or mark it past_due before sending the event.
+func (f *Fake) UpdateSubscription(id string, fn func(*billing.Subscription)) (*billing.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, ok := f.subscriptions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	fn(sub)
+	cp := *sub
+	return &cp, nil
+}
+
+// SubscriptionObject renders a subscription as in customer.subscription.* events.
+func SubscriptionObject(sub *billing.Subscription) map[string]any {
+	return map[string]any{
+		"id":                   sub.ID,
+		"object":               "subscription",
+		"customer":             sub.CustomerID,
+		"status":               sub.Status,
+		"cancel_at_period_end": sub.CancelAtPeriodEnd,
+		"metadata":             sub.Metadata,
+		"items": map[string]any{
+			"object": "list",
+			"data": []map[string]any{{
+				"id":                 "si_" + sub.ID,
+				"object":             "subscription_item",
+				"price":              map[string]any{"id": sub.PriceID, "object": "price"},
+				"current_period_end": sub.CurrentPeriodEnd.Unix(),
+			}},
+		},
+	}
+}
+
+// InvoiceObject renders a failed invoice of a subscription, as in invoice.payment_failed.
+func InvoiceObject(invoiceID string, sub *billing.Subscription) map[string]any {
+	return map[string]any{
+		"id":       invoiceID,
+		"object":   "invoice",
+		"customer": sub.CustomerID,
+		"status":   "open",
+		"parent": map[string]any{
+			"type": "subscription_details",
+			"subscription_details": map[string]any{
+				"subscription": sub.ID,
+				"metadata":     sub.Metadata,
+			},
+		},
+	}
+}
+
+// Event builds the payload of a webhook event and its Stripe-Signature header,
+// signed with secret.
+func (f *Fake) Event(secret, eventType string, object map[string]any) (payload []byte, header string, err error) {
+	f.mu.Lock()
+	id := f.newID("evt")
+	f.mu.Unlock()
+
+	now := f.now()
+	payload, err = json.Marshal(map[string]any{
+		"id":          id,
+		"object":      "event",
+		"type":        eventType,
+		"created":     now.Unix(),
+		"api_version": "2025-03-31.basil",
+		"data":        map[string]any{"object": object},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload:   payload,
+		Secret:    secret,
+		Timestamp: now,
+	})
+	return payload, signed.Header, nil
+}
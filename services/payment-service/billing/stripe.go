@@ -0,0 +1,86 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+)
+
+// StripeClient implements Client with the Stripe API.
+type StripeClient struct {
+	sc *stripe.Client
+}
+
+// NewStripeClient returns a Client using the given secret key.
+func NewStripeClient(secretKey string) *StripeClient {
+	return &StripeClient{sc: stripe.NewClient(secretKey)}
+}
+
+func (c *StripeClient) CreateCheckoutSession(ctx context.Context, p CheckoutParams) (*Session, error) {
+	params := &stripe.CheckoutSessionCreateParams{
+		Mode: stripe.String(string(stripe.CheckoutSessionModeSubscription)),
+		LineItems: []*stripe.CheckoutSessionCreateLineItemParams{
+			{Price: stripe.String(p.PriceID), Quantity: stripe.Int64(1)},
+		},
+		ClientReferenceID: stripe.String(p.ClientReferenceID),
+		SuccessURL:        stripe.String(p.SuccessURL),
+		CancelURL:         stripe.String(p.CancelURL),
+		Metadata:          p.Metadata,
+		SubscriptionData: &stripe.CheckoutSessionCreateSubscriptionDataParams{
+			Metadata: p.Metadata,
+		},
+	}
+	if p.CustomerID != "" {
+		params.Customer = stripe.String(p.CustomerID)
+	}
+
+	s, err := c.sc.V1CheckoutSessions.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{ID: s.ID, URL: s.URL}, nil
+}
+
+func (c *StripeClient) CreatePortalSession(ctx context.Context, customerID, returnURL string) (*Session, error) {
+	s, err := c.sc.V1BillingPortalSessions.Create(ctx, &stripe.BillingPortalSessionCreateParams{
+		Customer:  stripe.String(customerID),
+		ReturnURL: stripe.String(returnURL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Session{ID: s.ID, URL: s.URL}, nil
+}
+
+func (c *StripeClient) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	s, err := c.sc.V1Subscriptions.Retrieve(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	return FromStripe(s), nil
+}
+
+// FromStripe converts a subscription received from the API or in a webhook.
+// The price and the period end are read from the first item.
+func FromStripe(s *stripe.Subscription) *Subscription {
+	sub := &Subscription{
+		ID:                s.ID,
+		Status:            string(s.Status),
+		CancelAtPeriodEnd: s.CancelAtPeriodEnd,
+		Metadata:          s.Metadata,
+	}
+	if s.Customer != nil {
+		sub.CustomerID = s.Customer.ID
+	}
+	if s.Items != nil && len(s.Items.Data) > 0 {
+		item := s.Items.Data[0]
+		if item.Price != nil {
+			sub.PriceID = item.Price.ID
+		}
+		if item.CurrentPeriodEnd != 0 {
+			sub.CurrentPeriodEnd = time.Unix(item.CurrentPeriodEnd, 0)
+		}
+	}
+	return sub
+}
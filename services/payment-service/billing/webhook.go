@@ -0,0 +1,27 @@
+package billing
+
+import (
+	"errors"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/webhook"
+)
+
+// ErrInvalidSignature is returned by VerifyWebhook for payloads that were not
+// signed with the endpoint secret, or whose timestamp is too old.
+var ErrInvalidSignature = errors.New("billing: invalid Stripe-Signature")
+
+// VerifyWebhook checks the Stripe-Signature header of a webhook payload and
+// decodes the event. Events of another API version are accepted: only the
+// fields read by FromStripe are used.
+func VerifyWebhook(payload []byte, header, secret string) (stripe.Event, error) {
+	event, err := webhook.ConstructEventWithOptions(payload, header, secret, webhook.ConstructEventOptions{
+		IgnoreAPIVersionMismatch: true,
+	})
+	switch {
+	case errors.Is(err, webhook.ErrNotSigned), errors.Is(err, webhook.ErrInvalidHeader),
+		errors.Is(err, webhook.ErrNoValidSignature), errors.Is(err, webhook.ErrTooOld):
+		return event, ErrInvalidSignature
+	}
+	return event, err
+}
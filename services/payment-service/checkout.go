@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"payment-service/billing"
+	"shared/dbservicepb"
+	"shared/jwtauth"
+)
+
+// checkoutHandler gère POST /checkout : crée une session Stripe Checkout pour
+// le niveau demandé ({"tier": "premium"}) et renvoie son URL.
+func (s *server) checkoutHandler(c *fiber.Ctx) error {
+	var req struct {
+		Tier string `json:"tier"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	ctx := userContext(c)
+	price, err := s.priceFor(ctx, req.Tier)
+	if err != nil {
+		return dbError(c, err)
+	}
+	if price == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Unknown tier"})
+	}
+
+	u, err := s.currentUser(ctx, c)
+	if err != nil {
+		return dbError(c, err)
+	}
+	if u.GetIsSubscribed() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Already subscribed, use the billing portal to change plan"})
+	}
+	customer, err := s.customerID(ctx, u.GetId())
+	if err != nil {
+		return dbError(c, err)
+	}
+
+	userID := strconv.FormatInt(u.GetId(), 10)
+	session, err := s.stripe.CreateCheckoutSession(c.UserContext(), billing.CheckoutParams{
+		PriceID:           price,
+		CustomerID:        customer,
+		ClientReferenceID: userID,
+		SuccessURL:        s.successURL,
+		CancelURL:         s.cancelURL,
+		// Recopié sur l'abonnement : permet de retrouver l'utilisateur depuis les webhooks
+		Metadata: map[string]string{"user_id": userID, "tier": req.Tier},
+	})
+	if err != nil {
+		log.Printf("Stripe checkout session error for user %s: %v", userID, err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "stripe_error"})
+	}
+
+	return c.JSON(session)
+}
+
+// portalHandler gère POST /portal : ouvre le Billing Portal Stripe du client
+// de l'utilisateur (changement de carte, de formule, résiliation).
+func (s *server) portalHandler(c *fiber.Ctx) error {
+	ctx := userContext(c)
+	u, err := s.currentUser(ctx, c)
+	if err != nil {
+		return dbError(c, err)
+	}
+	customer, err := s.customerID(ctx, u.GetId())
+	if err != nil {
+		return dbError(c, err)
+	}
+	if customer == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No Stripe customer for this user"})
+	}
+
+	session, err := s.stripe.CreatePortalSession(c.UserContext(), customer, s.portalReturnURL)
+	if err != nil {
+		log.Printf("Stripe portal session error for customer %s: %v", customer, err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "stripe_error"})
+	}
+
+	return c.JSON(session)
+}
+
+// userContext returns a context calling db-service on behalf of the caller,
+// with their own session token.
+func userContext(c *fiber.Ctx) context.Context {
+	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	return dbservicepb.WithToken(c.UserContext(), token)
+}
+
+// currentUser returns the db-service record of the authenticated caller.
+func (s *server) currentUser(ctx context.Context, c *fiber.Ctx) (*dbservicepb.User, error) {
+	principal := jwtauth.PrincipalFrom(c)
+	if principal == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid_token")
+	}
+	return s.users.GetUserByClerkID(ctx, &dbservicepb.GetUserByClerkIDRequest{ClerkUserId: principal.UserID})
+}
+
+// customerID returns the Stripe customer of the user's most recent
+// subscription, or "" if they never subscribed.
+func (s *server) customerID(ctx context.Context, userID int64) (string, error) {
+	resp, err := s.subscriptions.GetUserSubscriptions(ctx, &dbservicepb.GetUserSubscriptionsRequest{UserId: userID})
+	if err != nil {
+		return "", err
+	}
+	for _, sub := range resp.GetSubscriptions() {
+		if sub.GetStripeCustomerId() != "" {
+			return sub.GetStripeCustomerId(), nil
+		}
+	}
+	return "", nil
+}
+
+// dbError writes the response for a failed db-service call.
+func dbError(c *fiber.Ctx, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	case codes.Unauthenticated:
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_token"})
+	case codes.PermissionDenied:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+	default:
+		log.Printf("db-service error: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "db_service_error"})
+	}
+}
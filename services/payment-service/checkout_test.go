@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"payment-service/billing"
+	"shared/dbservicepb"
+	"shared/jwtauth"
+)
+
+// testAuth returns jwtauth.Middleware trusting a key served by a test JWKS
+// endpoint, and a function minting session tokens of a Clerk user with it.
+func testAuth(t *testing.T) (fiber.Handler, func(clerkID string) string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kid": "test", "kty": "OKP", "crv": "Ed25519", "alg": "EdDSA", "x": b64(pub)},
+		}})
+	}))
+	t.Cleanup(jwks.Close)
+
+	verifier, err := jwtauth.NewVerifier(context.Background(), jwtauth.Config{JWKSURL: jwks.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(verifier.Close)
+
+	segment := func(v any) string {
+		b, _ := json.Marshal(v)
+		return b64(b)
+	}
+	sign := func(clerkID string) string {
+		input := segment(map[string]string{"alg": "EdDSA", "kid": "test", "typ": "JWT"}) + "." +
+			segment(map[string]any{"sub": clerkID, "exp": time.Now().Add(time.Hour).Unix()})
+		return input + "." + b64(ed25519.Sign(priv, []byte(input)))
+	}
+	return jwtauth.Middleware(verifier), sign
+}
+
+func TestCheckout(t *testing.T) {
+	db := newFakeDB(
+		&dbservicepb.User{Id: 7, ClerkUserId: "user_new"},
+		&dbservicepb.User{Id: 8, ClerkUserId: "user_subscribed", IsSubscribed: true, SubscriptionTier: "premium"},
+		&dbservicepb.User{Id: 9, ClerkUserId: "user_back"},
+	)
+	db.subs = append(db.subs, &dbservicepb.Subscription{
+		Id:                   1,
+		StripeCustomerId:     "cus_back",
+		StripeSubscriptionId: "sub_old",
+		Status:               "expired",
+		User:                 &dbservicepb.User{Id: 9},
+	})
+	srv, stripe := newTestServer(db)
+	auth, sign := testAuth(t)
+	app := newApp(srv, auth)
+
+	tests := []struct {
+		name     string
+		user     string
+		body     string
+		code     int
+		customer string
+	}{
+		{name: "no token", body: `{"tier":"premium"}`, code: http.StatusUnauthorized},
+		{name: "unknown tier", user: "user_new", body: `{"tier":"gold"}`, code: http.StatusBadRequest},
+		{name: "not sold", user: "user_new", body: `{"tier":"free"}`, code: http.StatusBadRequest},
+		{name: "unknown user", user: "user_other", body: `{"tier":"premium"}`, code: http.StatusNotFound},
+		{name: "already subscribed", user: "user_subscribed", body: `{"tier":"premium"}`, code: http.StatusConflict},
+		{name: "new customer", user: "user_new", body: `{"tier":"premium"}`, code: http.StatusOK},
+		{name: "returning customer", user: "user_back", body: `{"tier":"premium"}`, code: http.StatusOK, customer: "cus_back"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/checkout", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.user != "" {
+				req.Header.Set("Authorization", "Bearer "+sign(tt.user))
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.code {
+				t.Fatalf("status = %d (%s), want %d", resp.StatusCode, body, tt.code)
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			var session billing.Session
+			if err := json.Unmarshal(body, &session); err != nil || session.URL == "" {
+				t.Fatalf("body = %s, want a session", body)
+			}
+			params, ok := stripe.Session(session.ID)
+			if !ok {
+				t.Fatalf("session %s was not created", session.ID)
+			}
+			userID := strconv.FormatInt(db.users[tt.user].GetId(), 10)
+			if params.PriceID != "price_premium" || params.CustomerID != tt.customer ||
+				params.ClientReferenceID != userID || params.Metadata["user_id"] != userID {
+				t.Errorf("session params = %+v, want price_premium for user %s, customer %q", params, userID, tt.customer)
+			}
+		})
+	}
+}
+
+func TestPortal(t *testing.T) {
+	db := newFakeDB(
+		&dbservicepb.User{Id: 7, ClerkUserId: "user_new"},
+		&dbservicepb.User{Id: 9, ClerkUserId: "user_back"},
+	)
+	db.subs = append(db.subs, &dbservicepb.Subscription{Id: 1, StripeCustomerId: "cus_back", User: &dbservicepb.User{Id: 9}})
+	srv, _ := newTestServer(db)
+	auth, sign := testAuth(t)
+	app := newApp(srv, auth)
+
+	tests := []struct {
+		user string
+		code int
+	}{
+		{user: "user_new", code: http.StatusNotFound},
+		{user: "user_back", code: http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/portal", nil)
+		req.Header.Set("Authorization", "Bearer "+sign(tt.user))
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.code {
+			t.Errorf("%s: status = %d (%s), want %d", tt.user, resp.StatusCode, body, tt.code)
+		}
+		if tt.code == http.StatusOK && !strings.Contains(string(body), "cus_back") {
+			t.Errorf("%s: body = %s, want the portal of cus_back", tt.user, body)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"payment-service/billing"
+	"shared/dbservicepb"
+	"shared/jwtauth"
+)
+
+func main() {
+	// 1) Tarifs Stripe par niveau d'abonnement : le catalogue de plans de
+	// db-service, que STRIPE_PRICES (optionnel) peut surcharger
+	prices, err := billing.ParsePrices(os.Getenv("STRIPE_PRICES"))
+	if err != nil {
+		log.Fatalf("invalid STRIPE_PRICES: %v", err)
+	}
+
+	webhookSecret := os.Getenv("STRIPE_WEBHOOK_SECRET")
+	if webhookSecret == "" {
+		log.Fatal("STRIPE_WEBHOOK_SECRET is not set")
+	}
+
+	// 2) Client gRPC de db-service : les webhooks s'authentifient avec une clé de service
+	apiKey := os.Getenv("DB_SERVICE_API_KEY")
+	if apiKey == "" {
+		log.Fatal("DB_SERVICE_API_KEY is not set")
+	}
+	db, err := dbservicepb.NewClient(os.Getenv("DB_SERVICE_GRPC_ADDR"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed connecting to db-service: %v", err)
+	}
+	defer db.Close()
+
+	// 3) Jetons de session Clerk vérifiés localement, comme dans db-service
+	verifier, err := jwtauth.NewVerifier(context.Background(), jwtauth.Config{
+		JWKSURL: os.Getenv("CLERK_JWKS_URL"),
+		Issuer:  os.Getenv("CLERK_ISSUER"),
+	})
+	if err != nil {
+		log.Fatalf("failed initializing token verifier: %v", err)
+	}
+	defer verifier.Close()
+
+	srv := &server{
+		stripe:          billing.NewStripeClient(os.Getenv("STRIPE_SECRET_KEY")),
+		prices:          prices,
+		users:           db.Users,
+		subscriptions:   db.Subscriptions,
+		plans:           db.Plans,
+		apiKey:          apiKey,
+		webhookSecret:   webhookSecret,
+		successURL:      os.Getenv("CHECKOUT_SUCCESS_URL"),
+		cancelURL:       os.Getenv("CHECKOUT_CANCEL_URL"),
+		portalReturnURL: os.Getenv("PORTAL_RETURN_URL"),
+	}
+	app := newApp(srv, jwtauth.Middleware(verifier))
+
+	// 4) Lancement du serveur
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	log.Printf("Starting payment-service on port %s...", port)
+	log.Fatal(app.Listen(":" + port))
+}
+
+// server holds the dependencies of the handlers. stripe is a billing.Client
+// so that billingtest.Fake can replace Stripe.
+type server struct {
+	stripe        billing.Client
+	prices        billing.Prices
+	users         dbservicepb.UserServiceClient
+	subscriptions dbservicepb.SubscriptionServiceClient
+	plans         dbservicepb.PlanServiceClient
+
+	apiKey        string // db-service API key, for calls not made on behalf of a user
+	webhookSecret string // signing secret (whsec_...) of the Stripe webhook endpoint
+
+	successURL      string
+	cancelURL       string
+	portalReturnURL string
+}
+
+// newApp déclare les routes. auth authentifie l'utilisateur (jwtauth.Middleware
+// en production) ; le webhook Stripe est authentifié par sa signature.
+func newApp(s *server, auth fiber.Handler) *fiber.App {
+	app := fiber.New()
+
+	app.Post("/webhooks/stripe", s.stripeWebhookHandler)
+
+	app.Post("/checkout", auth, s.checkoutHandler)
+	app.Post("/portal", auth, s.portalHandler)
+
+	return app
+}
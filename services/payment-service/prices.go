@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"shared/dbservicepb"
+)
+
+// priceFor returns the Stripe price selling tier, or "" when there is none.
+// STRIPE_PRICES takes precedence over the plan catalog of db-service.
+func (s *server) priceFor(ctx context.Context, tier string) (string, error) {
+	if price, ok := s.prices[tier]; ok {
+		return price, nil
+	}
+	if tier == "" {
+		return "", nil
+	}
+
+	plan, err := s.plans.GetPlan(ctx, &dbservicepb.GetPlanRequest{Key: tier})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(plan.GetStripePriceIds()) == 0 {
+		return "", nil // Not sold, e.g. free
+	}
+	return plan.GetStripePriceIds()[0], nil
+}
+
+// tierFor returns the tier sold at a Stripe price, or "" when it is unknown.
+func (s *server) tierFor(ctx context.Context, priceID string) (string, error) {
+	if tier := s.prices.Tier(priceID); tier != "" || priceID == "" {
+		return tier, nil
+	}
+
+	plan, err := s.plans.GetPlanByPriceID(ctx, &dbservicepb.GetPlanByPriceIDRequest{StripePriceId: priceID})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return plan.GetKey(), nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stripe/stripe-go/v82"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payment-service/billing"
+	"shared/dbservicepb"
+)
+
+// stripeWebhookHandler gère POST /webhooks/stripe. Les événements dont le
+// traitement échoue reçoivent une 500 pour que Stripe les renvoie.
+func (s *server) stripeWebhookHandler(c *fiber.Ctx) error {
+	event, err := billing.VerifyWebhook(c.Body(), c.Get("Stripe-Signature"), s.webhookSecret)
+	if errors.Is(err, billing.ErrInvalidSignature) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid_signature"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+
+	ctx := dbservicepb.WithAPIKey(c.UserContext(), s.apiKey)
+	if err := s.handleEvent(ctx, event); err != nil {
+		log.Printf("Stripe event %s (%s) failed: %v", event.ID, event.Type, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "processing_failed"})
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// handleEvent maps a Stripe event onto db-service subscriptions. Other event
+// types are acknowledged and ignored.
+func (s *server) handleEvent(ctx context.Context, event stripe.Event) error {
+	reason := fmt.Sprintf("%s %s", event.Type, event.ID)
+	ref := &dbservicepb.WebhookEvent{
+		Provider:  "stripe",
+		Id:        event.ID,
+		Type:      string(event.Type),
+		CreatedAt: timestamppb.New(time.Unix(event.Created, 0)),
+	}
+
+	switch event.Type {
+	case stripe.EventTypeCheckoutSessionCompleted:
+		var session stripe.CheckoutSession
+		if err := json.Unmarshal(event.Data.Raw, &session); err != nil {
+			return err
+		}
+		if session.Mode != stripe.CheckoutSessionModeSubscription || session.Subscription == nil {
+			return nil
+		}
+		// The session only carries the subscription ID
+		sub, err := s.stripe.GetSubscription(ctx, session.Subscription.ID)
+		if err != nil {
+			return fmt.Errorf("retrieving subscription %s: %w", session.Subscription.ID, err)
+		}
+		return s.syncSubscription(ctx, sub, session.ClientReferenceID, ref, reason)
+
+	case stripe.EventTypeCustomerSubscriptionCreated,
+		stripe.EventTypeCustomerSubscriptionUpdated,
+		stripe.EventTypeCustomerSubscriptionDeleted:
+		var raw stripe.Subscription
+		if err := json.Unmarshal(event.Data.Raw, &raw); err != nil {
+			return err
+		}
+		sub := billing.FromStripe(&raw)
+		return s.syncSubscription(ctx, sub, sub.Metadata["user_id"], ref, reason)
+
+	case stripe.EventTypeInvoicePaymentFailed:
+		var invoice stripe.Invoice
+		if err := json.Unmarshal(event.Data.Raw, &invoice); err != nil {
+			return err
+		}
+		if invoice.Parent == nil || invoice.Parent.SubscriptionDetails == nil || invoice.Parent.SubscriptionDetails.Subscription == nil {
+			return nil // One-off invoice
+		}
+		return s.markPastDue(ctx, invoice.Parent.SubscriptionDetails.Subscription.ID, ref, reason)
+	}
+
+	return nil
+}
+
+// syncSubscription creates or updates the db-service subscription matching
+// sub. userRef is the db-service user ID (client_reference_id of the Checkout
+// session, or the user_id metadata of the subscription), needed on creation.
+// ref lets db-service skip redelivered events and events older than the last
+// one applied to the subscription.
+func (s *server) syncSubscription(ctx context.Context, sub *billing.Subscription, userRef string, ref *dbservicepb.WebhookEvent, reason string) error {
+	st := sub.LifecycleStatus()
+	if st == "" {
+		return nil // Incomplete: the first payment has not succeeded yet
+	}
+	tier, err := s.tierFor(ctx, sub.PriceID)
+	if err != nil {
+		return fmt.Errorf("resolving the plan of price %s: %w", sub.PriceID, err)
+	}
+	var periodEnd *timestamppb.Timestamp
+	if !sub.CurrentPeriodEnd.IsZero() {
+		periodEnd = timestamppb.New(sub.CurrentPeriodEnd)
+	}
+
+	ref = forResource(ref, sub.ID)
+
+	existing, err := s.subscriptions.GetSubscriptionByStripeID(ctx, &dbservicepb.GetSubscriptionByStripeIDRequest{StripeSubscriptionId: sub.ID})
+	switch status.Code(err) {
+	case codes.OK:
+		req := &dbservicepb.UpdateSubscriptionStatusRequest{
+			Id:               existing.GetId(),
+			Status:           st,
+			CurrentPeriodEnd: periodEnd,
+			Source:           "webhook",
+			Reason:           reason,
+			Event:            ref,
+		}
+		if tier != "" {
+			req.Tier = &tier
+		} else {
+			log.Printf("Stripe subscription %s: unknown price %s, tier left unchanged", sub.ID, sub.PriceID)
+		}
+		_, err = s.subscriptions.UpdateSubscriptionStatus(ctx, req)
+		return ignoreIllegalTransition(err, sub.ID, st)
+
+	case codes.NotFound:
+		userID, perr := strconv.ParseInt(userRef, 10, 64)
+		if perr != nil {
+			// Not created by /checkout: nothing links it to a user, retrying will not help
+			log.Printf("Stripe subscription %s has no user reference, ignored", sub.ID)
+			return nil
+		}
+		// Never guess the tier: the event fails, and Stripe retries it once
+		// the price is in STRIPE_PRICES or the plan catalog
+		if tier == "" {
+			return fmt.Errorf("subscription %s: %w %s", sub.ID, errUnknownPrice, sub.PriceID)
+		}
+		_, err = s.subscriptions.CreateSubscription(ctx, &dbservicepb.CreateSubscriptionRequest{
+			UserId:               userID,
+			StripeCustomerId:     sub.CustomerID,
+			StripeSubscriptionId: sub.ID,
+			Status:               st,
+			Tier:                 tier,
+			CurrentPeriodEnd:     periodEnd,
+			Source:               "webhook",
+			Reason:               reason,
+			Event:                ref,
+		})
+		return err
+
+	default:
+		return err
+	}
+}
+
+// errUnknownPrice is returned for a subscription to a price sold by no tier.
+var errUnknownPrice = errors.New("no tier sells price")
+
+// markPastDue moves a subscription whose invoice payment failed to past_due.
+func (s *server) markPastDue(ctx context.Context, stripeSubscriptionID string, ref *dbservicepb.WebhookEvent, reason string) error {
+	existing, err := s.subscriptions.GetSubscriptionByStripeID(ctx, &dbservicepb.GetSubscriptionByStripeIDRequest{StripeSubscriptionId: stripeSubscriptionID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = s.subscriptions.UpdateSubscriptionStatus(ctx, &dbservicepb.UpdateSubscriptionStatusRequest{
+		Id:     existing.GetId(),
+		Status: "past_due",
+		Source: "webhook",
+		Reason: reason,
+		Event:  forResource(ref, stripeSubscriptionID),
+	})
+	return ignoreIllegalTransition(err, stripeSubscriptionID, "past_due")
+}
+
+// forResource returns a copy of ref about the given Stripe subscription.
+func forResource(ref *dbservicepb.WebhookEvent, stripeSubscriptionID string) *dbservicepb.WebhookEvent {
+	return &dbservicepb.WebhookEvent{
+		Provider:   ref.GetProvider(),
+		Id:         ref.GetId(),
+		Type:       ref.GetType(),
+		ResourceId: stripeSubscriptionID,
+		CreatedAt:  ref.GetCreatedAt(),
+	}
+}
+
+// ignoreIllegalTransition acknowledges events that db-service rejects as an
+// illegal transition (e.g. a late event for an expired subscription):
+// redelivering them would fail the same way.
+func ignoreIllegalTransition(err error, stripeSubscriptionID, to string) error {
+	if status.Code(err) == codes.FailedPrecondition {
+		log.Printf("Stripe subscription %s: transition to %s rejected: %v", stripeSubscriptionID, to, err)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"payment-service/billing"
+	"payment-service/billing/billingtest"
+	"shared/dbservicepb"
+)
+
+const (
+	testSecret = "whsec_test"
+	testAPIKey = "service-key"
+)
+
+// fakeDB is an in-memory db-service. The calls that write are recorded as
+// "CreateSubscription <stripe ID> <status> <tier>" and
+// "UpdateSubscriptionStatus <stripe ID> <status> <tier>", with the API key
+// they were made with. Plans are not in the catalog.
+type fakeDB struct {
+	dbservicepb.UserServiceClient
+	dbservicepb.SubscriptionServiceClient
+	dbservicepb.PlanServiceClient
+
+	mu      sync.Mutex
+	users   map[string]*dbservicepb.User // by Clerk ID
+	subs    []*dbservicepb.Subscription
+	calls   []string
+	apiKeys []string
+}
+
+func newFakeDB(users ...*dbservicepb.User) *fakeDB {
+	db := &fakeDB{users: make(map[string]*dbservicepb.User)}
+	for _, u := range users {
+		db.users[u.GetClerkUserId()] = u
+	}
+	return db
+}
+
+func (db *fakeDB) record(ctx context.Context, call string) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	db.calls = append(db.calls, call)
+	db.apiKeys = append(db.apiKeys, md.Get("x-api-key")...)
+}
+
+func (db *fakeDB) Calls() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]string(nil), db.calls...)
+}
+
+func (db *fakeDB) GetUserByClerkID(_ context.Context, in *dbservicepb.GetUserByClerkIDRequest, _ ...grpc.CallOption) (*dbservicepb.User, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	u, ok := db.users[in.GetClerkUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return u, nil
+}
+
+func (db *fakeDB) GetUserSubscriptions(_ context.Context, in *dbservicepb.GetUserSubscriptionsRequest, _ ...grpc.CallOption) (*dbservicepb.ListSubscriptionsResponse, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	resp := &dbservicepb.ListSubscriptionsResponse{}
+	for _, sub := range db.subs {
+		if sub.GetUser().GetId() == in.GetUserId() {
+			resp.Subscriptions = append(resp.Subscriptions, sub)
+		}
+	}
+	return resp, nil
+}
+
+func (db *fakeDB) GetSubscriptionByStripeID(_ context.Context, in *dbservicepb.GetSubscriptionByStripeIDRequest, _ ...grpc.CallOption) (*dbservicepb.Subscription, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, sub := range db.subs {
+		if sub.GetStripeSubscriptionId() == in.GetStripeSubscriptionId() {
+			return sub, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "subscription not found")
+}
+
+func (db *fakeDB) CreateSubscription(ctx context.Context, in *dbservicepb.CreateSubscriptionRequest, _ ...grpc.CallOption) (*dbservicepb.Subscription, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.record(ctx, fmt.Sprintf("CreateSubscription %s %s %s", in.GetStripeSubscriptionId(), in.GetStatus(), in.GetTier()))
+	sub := &dbservicepb.Subscription{
+		Id:                   int64(len(db.subs) + 1),
+		StripeCustomerId:     in.GetStripeCustomerId(),
+		StripeSubscriptionId: in.GetStripeSubscriptionId(),
+		Status:               in.GetStatus(),
+		Tier:                 in.GetTier(),
+		User:                 &dbservicepb.User{Id: in.GetUserId()},
+	}
+	db.subs = append(db.subs, sub)
+	return sub, nil
+}
+
+func (db *fakeDB) UpdateSubscriptionStatus(ctx context.Context, in *dbservicepb.UpdateSubscriptionStatusRequest, _ ...grpc.CallOption) (*dbservicepb.Subscription, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, sub := range db.subs {
+		if sub.GetId() != in.GetId() {
+			continue
+		}
+		db.record(ctx, fmt.Sprintf("UpdateSubscriptionStatus %s %s %s", sub.GetStripeSubscriptionId(), in.GetStatus(), in.GetTier()))
+		sub.Status = in.GetStatus()
+		if in.Tier != nil {
+			sub.Tier = in.GetTier()
+		}
+		return sub, nil
+	}
+	return nil, status.Error(codes.NotFound, "subscription not found")
+}
+
+func (db *fakeDB) GetPlan(context.Context, *dbservicepb.GetPlanRequest, ...grpc.CallOption) (*dbservicepb.Plan, error) {
+	return nil, status.Error(codes.NotFound, "plan not found")
+}
+
+func (db *fakeDB) GetPlanByPriceID(context.Context, *dbservicepb.GetPlanByPriceIDRequest, ...grpc.CallOption) (*dbservicepb.Plan, error) {
+	return nil, status.Error(codes.NotFound, "plan not found")
+}
+
+// newTestServer returns a server on Stripe and db-service fakes, premium
+// being sold at price_premium.
+func newTestServer(db *fakeDB) (*server, *billingtest.Fake) {
+	stripe := billingtest.NewFake()
+	return &server{
+		stripe:        stripe,
+		prices:        billing.Prices{"premium": "price_premium"},
+		users:         db,
+		subscriptions: db,
+		plans:         db,
+		apiKey:        testAPIKey,
+		webhookSecret: testSecret,
+	}, stripe
+}
+
+// send posts a webhook event signed with secret and returns the status code
+// and the body of the response.
+func send(t *testing.T, app *fiber.App, stripe *billingtest.Fake, secret, eventType string, object map[string]any) (int, string) {
+	t.Helper()
+	payload, header, err := stripe.Event(secret, eventType, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewReader(payload))
+	req.Header.Set("Stripe-Signature", header)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+// subscribe completes a Checkout session of user 7 for price and returns the
+// subscription with the object of checkout.session.completed.
+func subscribe(t *testing.T, stripe *billingtest.Fake, price string) (*billing.Subscription, map[string]any) {
+	t.Helper()
+	session, err := stripe.CreateCheckoutSession(context.Background(), billing.CheckoutParams{
+		PriceID:           price,
+		ClientReferenceID: "7",
+		Metadata:          map[string]string{"user_id": "7"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, object, err := stripe.CompleteCheckout(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sub, object
+}
+
+func noAuth(c *fiber.Ctx) error { return c.Next() }
+
+func TestStripeWebhook(t *testing.T) {
+	db := newFakeDB()
+	srv, stripe := newTestServer(db)
+	app := newApp(srv, noAuth)
+	sub, session := subscribe(t, stripe, "price_premium")
+
+	update := func(fn func(*billing.Subscription)) map[string]any {
+		updated, err := stripe.UpdateSubscription(sub.ID, fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return billingtest.SubscriptionObject(updated)
+	}
+
+	steps := []struct {
+		name      string
+		eventType string
+		object    func() map[string]any
+		want      string
+	}{
+		{
+			name:      "checkout completed",
+			eventType: "checkout.session.completed",
+			object:    func() map[string]any { return session },
+			want:      "CreateSubscription " + sub.ID + " active premium",
+		},
+		{
+			name:      "payment failed",
+			eventType: "invoice.payment_failed",
+			object:    func() map[string]any { return billingtest.InvoiceObject("in_test", sub) },
+			want:      "UpdateSubscriptionStatus " + sub.ID + " past_due ",
+		},
+		{
+			name:      "canceled from the portal",
+			eventType: "customer.subscription.updated",
+			object: func() map[string]any {
+				return update(func(s *billing.Subscription) { s.Status, s.CancelAtPeriodEnd = "active", true })
+			},
+			want: "UpdateSubscriptionStatus " + sub.ID + " canceled premium",
+		},
+		{
+			name:      "deleted",
+			eventType: "customer.subscription.deleted",
+			object:    func() map[string]any { return update(func(s *billing.Subscription) { s.Status = "canceled" }) },
+			want:      "UpdateSubscriptionStatus " + sub.ID + " expired premium",
+		},
+	}
+	for i, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			code, body := send(t, app, stripe, testSecret, step.eventType, step.object())
+			if code != http.StatusOK {
+				t.Fatalf("status = %d (%s), want 200", code, body)
+			}
+			calls := db.Calls()
+			if len(calls) != i+1 || calls[i] != step.want {
+				t.Fatalf("calls = %q, want %q last", calls, step.want)
+			}
+		})
+	}
+
+	for _, key := range db.apiKeys {
+		if key != testAPIKey {
+			t.Errorf("db-service called with API key %q, want %q", key, testAPIKey)
+		}
+	}
+}
+
+func TestStripeWebhookUnknownPrice(t *testing.T) {
+	db := newFakeDB()
+	srv, stripe := newTestServer(db)
+	app := newApp(srv, noAuth)
+	sub, session := subscribe(t, stripe, "price_unknown")
+
+	// Never created with a guessed tier: Stripe retries the event
+	code, _ := send(t, app, stripe, testSecret, "checkout.session.completed", session)
+	if code != http.StatusInternalServerError {
+		t.Errorf("creation: status = %d, want 500", code)
+	}
+	if calls := db.Calls(); len(calls) != 0 {
+		t.Errorf("creation: calls = %q, want none", calls)
+	}
+
+	// Already stored: the status follows, the tier is left as is
+	sub.PriceID = "price_premium"
+	if code, body := send(t, app, stripe, testSecret, "customer.subscription.created", billingtest.SubscriptionObject(sub)); code != http.StatusOK {
+		t.Fatalf("status = %d (%s), want 200", code, body)
+	}
+	sub.PriceID = "price_unknown"
+	sub.Status = "past_due"
+	code, body := send(t, app, stripe, testSecret, "customer.subscription.updated", billingtest.SubscriptionObject(sub))
+	if code != http.StatusOK {
+		t.Fatalf("update: status = %d (%s), want 200", code, body)
+	}
+	want := "UpdateSubscriptionStatus " + sub.ID + " past_due "
+	if calls := db.Calls(); len(calls) != 2 || calls[1] != want {
+		t.Errorf("update: calls = %q, want %q last", calls, want)
+	}
+}
+
+func TestStripeWebhookSignature(t *testing.T) {
+	db := newFakeDB()
+	srv, stripe := newTestServer(db)
+	app := newApp(srv, noAuth)
+	_, session := subscribe(t, stripe, "price_premium")
+
+	code, body := send(t, app, stripe, "whsec_other", "checkout.session.completed", session)
+	if code != http.StatusBadRequest || body != `{"error":"invalid_signature"}` {
+		t.Errorf("response = %d %s, want 400 invalid_signature", code, body)
+	}
+	if calls := db.Calls(); len(calls) != 0 {
+		t.Errorf("calls = %q, want none", calls)
+	}
+}
@@ -0,0 +1,49 @@
+package dbservicepb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client bundles the db-service gRPC clients over a single connection.
+type Client struct {
+	Users         UserServiceClient
+	Subscriptions SubscriptionServiceClient
+	Plans         PlanServiceClient
+
+	conn *grpc.ClientConn
+}
+
+// NewClient connects to db-service's gRPC server. opts must at least set the
+// transport credentials (e.g. grpc.WithTransportCredentials).
+func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Users:         NewUserServiceClient(conn),
+		Subscriptions: NewSubscriptionServiceClient(conn),
+		Plans:         NewPlanServiceClient(conn),
+		conn:          conn,
+	}, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// WithToken returns a context authenticating the calls made with it.
+func WithToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// WithAPIKey returns a context authenticating the calls made with it as an
+// internal service (one of db-service's SERVICE_API_KEYS). Services have admin
+// rights, so only use it for calls that are not made on behalf of a user.
+func WithAPIKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+}
@@ -0,0 +1,1985 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: dbservice.proto
+
+package dbservicepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// is_subscribed and subscription_tier are derived from the user's subscriptions
+// and cannot be set directly.
+type User struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClerkUserId      string                 `protobuf:"bytes,2,opt,name=clerk_user_id,json=clerkUserId,proto3" json:"clerk_user_id,omitempty"`
+	Uuid             string                 `protobuf:"bytes,3,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Role             string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	IsSubscribed     bool                   `protobuf:"varint,5,opt,name=is_subscribed,json=isSubscribed,proto3" json:"is_subscribed,omitempty"`
+	SubscriptionTier string                 `protobuf:"bytes,6,opt,name=subscription_tier,json=subscriptionTier,proto3" json:"subscription_tier,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_dbservice_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetClerkUserId() string {
+	if x != nil {
+		return x.ClerkUserId
+	}
+	return ""
+}
+
+func (x *User) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetIsSubscribed() bool {
+	if x != nil {
+		return x.IsSubscribed
+	}
+	return false
+}
+
+func (x *User) GetSubscriptionTier() string {
+	if x != nil {
+		return x.SubscriptionTier
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Subscription struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StripeCustomerId     string                 `protobuf:"bytes,2,opt,name=stripe_customer_id,json=stripeCustomerId,proto3" json:"stripe_customer_id,omitempty"`
+	StripeSubscriptionId string                 `protobuf:"bytes,3,opt,name=stripe_subscription_id,json=stripeSubscriptionId,proto3" json:"stripe_subscription_id,omitempty"`
+	Status               string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CurrentPeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	User                 *User                  `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	Tier                 string                 `protobuf:"bytes,7,opt,name=tier,proto3" json:"tier,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_dbservice_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Subscription) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subscription) GetStripeCustomerId() string {
+	if x != nil {
+		return x.StripeCustomerId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStripeSubscriptionId() string {
+	if x != nil {
+		return x.StripeSubscriptionId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Subscription) GetCurrentPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return nil
+}
+
+func (x *Subscription) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Subscription) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+// SubscriptionEvent records a status change of a subscription.
+type SubscriptionEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int64                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// Empty for the creation of the subscription.
+	PreviousStatus string `protobuf:"bytes,3,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Status         string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// webhook, admin or scheduler.
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionEvent) Reset() {
+	*x = SubscriptionEvent{}
+	mi := &file_dbservice_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionEvent) ProtoMessage() {}
+
+func (x *SubscriptionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionEvent.ProtoReflect.Descriptor instead.
+func (*SubscriptionEvent) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscriptionEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SubscriptionEvent) GetSubscriptionId() int64 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *SubscriptionEvent) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// WebhookEvent identifies the provider event a write comes from. db-service
+// applies each event at most once, and skips events older than the last one
+// applied to the same resource.
+type WebhookEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// stripe, clerk or mailerlite.
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Id       string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type     string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The object the event is about, e.g. the Stripe subscription ID.
+	ResourceId string `protobuf:"bytes,4,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// Creation time of the event at the provider.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEvent) Reset() {
+	*x = WebhookEvent{}
+	mi := &file_dbservice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEvent) ProtoMessage() {}
+
+func (x *WebhookEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEvent.ProtoReflect.Descriptor instead.
+func (*WebhookEvent) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WebhookEvent) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *WebhookEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClerkUserId   string                 `protobuf:"bytes,1,opt,name=clerk_user_id,json=clerkUserId,proto3" json:"clerk_user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_dbservice_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateUserRequest) GetClerkUserId() string {
+	if x != nil {
+		return x.ClerkUserId
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_dbservice_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUserRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetUserByClerkIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClerkUserId   string                 `protobuf:"bytes,1,opt,name=clerk_user_id,json=clerkUserId,proto3" json:"clerk_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByClerkIDRequest) Reset() {
+	*x = GetUserByClerkIDRequest{}
+	mi := &file_dbservice_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByClerkIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByClerkIDRequest) ProtoMessage() {}
+
+func (x *GetUserByClerkIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByClerkIDRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByClerkIDRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUserByClerkIDRequest) GetClerkUserId() string {
+	if x != nil {
+		return x.ClerkUserId
+	}
+	return ""
+}
+
+// Pagination: limit is the page size (20 when unset, at most 100), cursor the
+// next_cursor of the previous page.
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_dbservice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetUsersByUUIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByUUIDRequest) Reset() {
+	*x = GetUsersByUUIDRequest{}
+	mi := &file_dbservice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByUUIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByUUIDRequest) ProtoMessage() {}
+
+func (x *GetUsersByUUIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByUUIDRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByUUIDRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetUsersByUUIDRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+type ListUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_dbservice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          *string                `protobuf:"bytes,2,opt,name=role,proto3,oneof" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_dbservice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateUserRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateUserRequest) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_dbservice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteUserRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateSubscriptionRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	UserId               int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StripeCustomerId     string                 `protobuf:"bytes,2,opt,name=stripe_customer_id,json=stripeCustomerId,proto3" json:"stripe_customer_id,omitempty"`
+	StripeSubscriptionId string                 `protobuf:"bytes,3,opt,name=stripe_subscription_id,json=stripeSubscriptionId,proto3" json:"stripe_subscription_id,omitempty"`
+	Status               string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CurrentPeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	Tier                 string                 `protobuf:"bytes,6,opt,name=tier,proto3" json:"tier,omitempty"`
+	// webhook, admin or scheduler (admin by default).
+	Source string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// When set, a duplicate or stale event returns the existing subscription
+	// unchanged.
+	Event         *WebhookEvent `protobuf:"bytes,9,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_dbservice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateSubscriptionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateSubscriptionRequest) GetStripeCustomerId() string {
+	if x != nil {
+		return x.StripeCustomerId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetStripeSubscriptionId() string {
+	if x != nil {
+		return x.StripeSubscriptionId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetCurrentPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetEvent() *WebhookEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type GetUserSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSubscriptionsRequest) Reset() {
+	*x = GetUserSubscriptionsRequest{}
+	mi := &file_dbservice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSubscriptionsRequest) ProtoMessage() {}
+
+func (x *GetUserSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserSubscriptionsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UpdateSubscriptionStatusRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CurrentPeriodEnd *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	// webhook, admin or scheduler (admin by default).
+	Source string  `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Reason string  `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Tier   *string `protobuf:"bytes,6,opt,name=tier,proto3,oneof" json:"tier,omitempty"`
+	// When set, a duplicate or stale event returns the subscription unchanged.
+	Event         *WebhookEvent `protobuf:"bytes,7,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriptionStatusRequest) Reset() {
+	*x = UpdateSubscriptionStatusRequest{}
+	mi := &file_dbservice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriptionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriptionStatusRequest) ProtoMessage() {}
+
+func (x *UpdateSubscriptionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriptionStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriptionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetCurrentPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetTier() string {
+	if x != nil && x.Tier != nil {
+		return *x.Tier
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionStatusRequest) GetEvent() *WebhookEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type GetSubscriptionByStripeIDRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	StripeSubscriptionId string                 `protobuf:"bytes,1,opt,name=stripe_subscription_id,json=stripeSubscriptionId,proto3" json:"stripe_subscription_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetSubscriptionByStripeIDRequest) Reset() {
+	*x = GetSubscriptionByStripeIDRequest{}
+	mi := &file_dbservice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubscriptionByStripeIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionByStripeIDRequest) ProtoMessage() {}
+
+func (x *GetSubscriptionByStripeIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionByStripeIDRequest.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionByStripeIDRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSubscriptionByStripeIDRequest) GetStripeSubscriptionId() string {
+	if x != nil {
+		return x.StripeSubscriptionId
+	}
+	return ""
+}
+
+type GetUserSubscriptionEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSubscriptionEventsRequest) Reset() {
+	*x = GetUserSubscriptionEventsRequest{}
+	mi := &file_dbservice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSubscriptionEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSubscriptionEventsRequest) ProtoMessage() {}
+
+func (x *GetUserSubscriptionEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSubscriptionEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSubscriptionEventsRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetUserSubscriptionEventsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListSubscriptionEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SubscriptionEvent   `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionEventsResponse) Reset() {
+	*x = ListSubscriptionEventsResponse{}
+	mi := &file_dbservice_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionEventsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionEventsResponse) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSubscriptionEventsResponse) GetEvents() []*SubscriptionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Pagination as in ListUsersRequest.
+type ListSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsRequest) Reset() {
+	*x = ListSubscriptionsRequest{}
+	mi := &file_dbservice_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListSubscriptionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsResponse) Reset() {
+	*x = ListSubscriptionsResponse{}
+	mi := &file_dbservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Entitlement is a feature (enabled) or a limit granted by a plan.
+type Entitlement struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Key     string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Enabled bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Unset when unlimited or not applicable.
+	Limit         *int64 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entitlement) Reset() {
+	*x = Entitlement{}
+	mi := &file_dbservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entitlement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entitlement) ProtoMessage() {}
+
+func (x *Entitlement) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entitlement.ProtoReflect.Descriptor instead.
+func (*Entitlement) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Entitlement) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Entitlement) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Entitlement) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type Plan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Equal to the tier of the subscriptions selling it.
+	Key            string         `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Name           string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	StripePriceIds []string       `protobuf:"bytes,4,rep,name=stripe_price_ids,json=stripePriceIds,proto3" json:"stripe_price_ids,omitempty"`
+	Entitlements   []*Entitlement `protobuf:"bytes,5,rep,name=entitlements,proto3" json:"entitlements,omitempty"`
+	// Orders the tiers of a user with several subscriptions, highest first.
+	Rank          int64 `protobuf:"varint,6,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plan) Reset() {
+	*x = Plan{}
+	mi := &file_dbservice_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plan) ProtoMessage() {}
+
+func (x *Plan) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plan.ProtoReflect.Descriptor instead.
+func (*Plan) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Plan) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Plan) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Plan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plan) GetStripePriceIds() []string {
+	if x != nil {
+		return x.StripePriceIds
+	}
+	return nil
+}
+
+func (x *Plan) GetEntitlements() []*Entitlement {
+	if x != nil {
+		return x.Entitlements
+	}
+	return nil
+}
+
+func (x *Plan) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// Entitlements are the effective entitlements of a user.
+type Entitlements struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Plan          string                 `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	Entitlements  []*Entitlement         `protobuf:"bytes,3,rep,name=entitlements,proto3" json:"entitlements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entitlements) Reset() {
+	*x = Entitlements{}
+	mi := &file_dbservice_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entitlements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entitlements) ProtoMessage() {}
+
+func (x *Entitlements) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entitlements.ProtoReflect.Descriptor instead.
+func (*Entitlements) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Entitlements) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Entitlements) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *Entitlements) GetEntitlements() []*Entitlement {
+	if x != nil {
+		return x.Entitlements
+	}
+	return nil
+}
+
+type ListPlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansRequest) Reset() {
+	*x = ListPlansRequest{}
+	mi := &file_dbservice_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansRequest) ProtoMessage() {}
+
+func (x *ListPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListPlansRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{23}
+}
+
+type ListPlansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plans         []*Plan                `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansResponse) Reset() {
+	*x = ListPlansResponse{}
+	mi := &file_dbservice_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansResponse) ProtoMessage() {}
+
+func (x *ListPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListPlansResponse) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPlansResponse) GetPlans() []*Plan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+type GetPlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanRequest) Reset() {
+	*x = GetPlanRequest{}
+	mi := &file_dbservice_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanRequest) ProtoMessage() {}
+
+func (x *GetPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetPlanRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetPlanByPriceIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StripePriceId string                 `protobuf:"bytes,1,opt,name=stripe_price_id,json=stripePriceId,proto3" json:"stripe_price_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanByPriceIDRequest) Reset() {
+	*x = GetPlanByPriceIDRequest{}
+	mi := &file_dbservice_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanByPriceIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanByPriceIDRequest) ProtoMessage() {}
+
+func (x *GetPlanByPriceIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanByPriceIDRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanByPriceIDRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetPlanByPriceIDRequest) GetStripePriceId() string {
+	if x != nil {
+		return x.StripePriceId
+	}
+	return ""
+}
+
+// Identifies the user by ID or Clerk ID; both empty means the caller.
+type GetUserEntitlementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ClerkUserId   string                 `protobuf:"bytes,2,opt,name=clerk_user_id,json=clerkUserId,proto3" json:"clerk_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserEntitlementsRequest) Reset() {
+	*x = GetUserEntitlementsRequest{}
+	mi := &file_dbservice_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserEntitlementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserEntitlementsRequest) ProtoMessage() {}
+
+func (x *GetUserEntitlementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbservice_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserEntitlementsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserEntitlementsRequest) Descriptor() ([]byte, []int) {
+	return file_dbservice_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetUserEntitlementsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetUserEntitlementsRequest) GetClerkUserId() string {
+	if x != nil {
+		return x.ClerkUserId
+	}
+	return ""
+}
+
+var File_dbservice_proto protoreflect.FileDescriptor
+
+const file_dbservice_proto_rawDesc = "" +
+	"\n" +
+	"\x0fdbservice.proto\x12\x12leakr.dbservice.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaa\x02\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\"\n" +
+	"\rclerk_user_id\x18\x02 \x01(\tR\vclerkUserId\x12\x12\n" +
+	"\x04uuid\x18\x03 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12#\n" +
+	"\ris_subscribed\x18\x05 \x01(\bR\fisSubscribed\x12+\n" +
+	"\x11subscription_tier\x18\x06 \x01(\tR\x10subscriptionTier\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa6\x02\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12,\n" +
+	"\x12stripe_customer_id\x18\x02 \x01(\tR\x10stripeCustomerId\x124\n" +
+	"\x16stripe_subscription_id\x18\x03 \x01(\tR\x14stripeSubscriptionId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12H\n" +
+	"\x12current_period_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10currentPeriodEnd\x12,\n" +
+	"\x04user\x18\x06 \x01(\v2\x18.leakr.dbservice.v1.UserR\x04user\x12\x12\n" +
+	"\x04tier\x18\a \x01(\tR\x04tier\"\xf8\x01\n" +
+	"\x11SubscriptionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x03R\x0esubscriptionId\x12'\n" +
+	"\x0fprevious_status\x18\x03 \x01(\tR\x0epreviousStatus\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaa\x01\n" +
+	"\fWebhookEvent\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1f\n" +
+	"\vresource_id\x18\x04 \x01(\tR\n" +
+	"resourceId\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"y\n" +
+	"\x11CreateUserRequest\x12\"\n" +
+	"\rclerk_user_id\x18\x01 \x01(\tR\vclerkUserId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04roleJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05R\ris_subscribedR\x11subscription_tier\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"=\n" +
+	"\x17GetUserByClerkIDRequest\x12\"\n" +
+	"\rclerk_user_id\x18\x01 \x01(\tR\vclerkUserId\"@\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"-\n" +
+	"\x15GetUsersByUUIDRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"d\n" +
+	"\x11ListUsersResponse\x12.\n" +
+	"\x05users\x18\x01 \x03(\v2\x18.leakr.dbservice.v1.UserR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"s\n" +
+	"\x11UpdateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04role\x18\x02 \x01(\tH\x00R\x04role\x88\x01\x01B\a\n" +
+	"\x05_roleJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05R\ris_subscribedR\x11subscription_tier\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xf6\x02\n" +
+	"\x19CreateSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12,\n" +
+	"\x12stripe_customer_id\x18\x02 \x01(\tR\x10stripeCustomerId\x124\n" +
+	"\x16stripe_subscription_id\x18\x03 \x01(\tR\x14stripeSubscriptionId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12H\n" +
+	"\x12current_period_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10currentPeriodEnd\x12\x12\n" +
+	"\x04tier\x18\x06 \x01(\tR\x04tier\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x126\n" +
+	"\x05event\x18\t \x01(\v2 .leakr.dbservice.v1.WebhookEventR\x05event\"6\n" +
+	"\x1bGetUserSubscriptionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x9d\x02\n" +
+	"\x1fUpdateSubscriptionStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12H\n" +
+	"\x12current_period_end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x10currentPeriodEnd\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x17\n" +
+	"\x04tier\x18\x06 \x01(\tH\x00R\x04tier\x88\x01\x01\x126\n" +
+	"\x05event\x18\a \x01(\v2 .leakr.dbservice.v1.WebhookEventR\x05eventB\a\n" +
+	"\x05_tier\"X\n" +
+	" GetSubscriptionByStripeIDRequest\x124\n" +
+	"\x16stripe_subscription_id\x18\x01 \x01(\tR\x14stripeSubscriptionId\";\n" +
+	" GetUserSubscriptionEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"_\n" +
+	"\x1eListSubscriptionEventsResponse\x12=\n" +
+	"\x06events\x18\x01 \x03(\v2%.leakr.dbservice.v1.SubscriptionEventR\x06events\"`\n" +
+	"\x18ListSubscriptionsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\x84\x01\n" +
+	"\x19ListSubscriptionsResponse\x12F\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2 .leakr.dbservice.v1.SubscriptionR\rsubscriptions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"^\n" +
+	"\vEntitlement\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x03H\x00R\x05limit\x88\x01\x01B\b\n" +
+	"\x06_limit\"\xbf\x01\n" +
+	"\x04Plan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12(\n" +
+	"\x10stripe_price_ids\x18\x04 \x03(\tR\x0estripePriceIds\x12C\n" +
+	"\fentitlements\x18\x05 \x03(\v2\x1f.leakr.dbservice.v1.EntitlementR\fentitlements\x12\x12\n" +
+	"\x04rank\x18\x06 \x01(\x03R\x04rank\"\x80\x01\n" +
+	"\fEntitlements\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04plan\x18\x02 \x01(\tR\x04plan\x12C\n" +
+	"\fentitlements\x18\x03 \x03(\v2\x1f.leakr.dbservice.v1.EntitlementR\fentitlements\"\x12\n" +
+	"\x10ListPlansRequest\"C\n" +
+	"\x11ListPlansResponse\x12.\n" +
+	"\x05plans\x18\x01 \x03(\v2\x18.leakr.dbservice.v1.PlanR\x05plans\"\"\n" +
+	"\x0eGetPlanRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"A\n" +
+	"\x17GetPlanByPriceIDRequest\x12&\n" +
+	"\x0fstripe_price_id\x18\x01 \x01(\tR\rstripePriceId\"Y\n" +
+	"\x1aGetUserEntitlementsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\"\n" +
+	"\rclerk_user_id\x18\x02 \x01(\tR\vclerkUserId2\xda\x04\n" +
+	"\vUserService\x12M\n" +
+	"\n" +
+	"CreateUser\x12%.leakr.dbservice.v1.CreateUserRequest\x1a\x18.leakr.dbservice.v1.User\x12G\n" +
+	"\aGetUser\x12\".leakr.dbservice.v1.GetUserRequest\x1a\x18.leakr.dbservice.v1.User\x12Y\n" +
+	"\x10GetUserByClerkID\x12+.leakr.dbservice.v1.GetUserByClerkIDRequest\x1a\x18.leakr.dbservice.v1.User\x12X\n" +
+	"\tListUsers\x12$.leakr.dbservice.v1.ListUsersRequest\x1a%.leakr.dbservice.v1.ListUsersResponse\x12b\n" +
+	"\x0eGetUsersByUUID\x12).leakr.dbservice.v1.GetUsersByUUIDRequest\x1a%.leakr.dbservice.v1.ListUsersResponse\x12M\n" +
+	"\n" +
+	"UpdateUser\x12%.leakr.dbservice.v1.UpdateUserRequest\x1a\x18.leakr.dbservice.v1.User\x12K\n" +
+	"\n" +
+	"DeleteUser\x12%.leakr.dbservice.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty2\xd6\x05\n" +
+	"\x13SubscriptionService\x12e\n" +
+	"\x12CreateSubscription\x12-.leakr.dbservice.v1.CreateSubscriptionRequest\x1a .leakr.dbservice.v1.Subscription\x12s\n" +
+	"\x19GetSubscriptionByStripeID\x124.leakr.dbservice.v1.GetSubscriptionByStripeIDRequest\x1a .leakr.dbservice.v1.Subscription\x12v\n" +
+	"\x14GetUserSubscriptions\x12/.leakr.dbservice.v1.GetUserSubscriptionsRequest\x1a-.leakr.dbservice.v1.ListSubscriptionsResponse\x12q\n" +
+	"\x18UpdateSubscriptionStatus\x123.leakr.dbservice.v1.UpdateSubscriptionStatusRequest\x1a .leakr.dbservice.v1.Subscription\x12\x85\x01\n" +
+	"\x19GetUserSubscriptionEvents\x124.leakr.dbservice.v1.GetUserSubscriptionEventsRequest\x1a2.leakr.dbservice.v1.ListSubscriptionEventsResponse\x12p\n" +
+	"\x11ListSubscriptions\x12,.leakr.dbservice.v1.ListSubscriptionsRequest\x1a-.leakr.dbservice.v1.ListSubscriptionsResponse2\xf4\x02\n" +
+	"\vPlanService\x12X\n" +
+	"\tListPlans\x12$.leakr.dbservice.v1.ListPlansRequest\x1a%.leakr.dbservice.v1.ListPlansResponse\x12G\n" +
+	"\aGetPlan\x12\".leakr.dbservice.v1.GetPlanRequest\x1a\x18.leakr.dbservice.v1.Plan\x12Y\n" +
+	"\x10GetPlanByPriceID\x12+.leakr.dbservice.v1.GetPlanByPriceIDRequest\x1a\x18.leakr.dbservice.v1.Plan\x12g\n" +
+	"\x13GetUserEntitlements\x12..leakr.dbservice.v1.GetUserEntitlementsRequest\x1a .leakr.dbservice.v1.EntitlementsB\x14Z\x12shared/dbservicepbb\x06proto3"
+
+var (
+	file_dbservice_proto_rawDescOnce sync.Once
+	file_dbservice_proto_rawDescData []byte
+)
+
+func file_dbservice_proto_rawDescGZIP() []byte {
+	file_dbservice_proto_rawDescOnce.Do(func() {
+		file_dbservice_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dbservice_proto_rawDesc), len(file_dbservice_proto_rawDesc)))
+	})
+	return file_dbservice_proto_rawDescData
+}
+
+var file_dbservice_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_dbservice_proto_goTypes = []any{
+	(*User)(nil),                             // 0: leakr.dbservice.v1.User
+	(*Subscription)(nil),                     // 1: leakr.dbservice.v1.Subscription
+	(*SubscriptionEvent)(nil),                // 2: leakr.dbservice.v1.SubscriptionEvent
+	(*WebhookEvent)(nil),                     // 3: leakr.dbservice.v1.WebhookEvent
+	(*CreateUserRequest)(nil),                // 4: leakr.dbservice.v1.CreateUserRequest
+	(*GetUserRequest)(nil),                   // 5: leakr.dbservice.v1.GetUserRequest
+	(*GetUserByClerkIDRequest)(nil),          // 6: leakr.dbservice.v1.GetUserByClerkIDRequest
+	(*ListUsersRequest)(nil),                 // 7: leakr.dbservice.v1.ListUsersRequest
+	(*GetUsersByUUIDRequest)(nil),            // 8: leakr.dbservice.v1.GetUsersByUUIDRequest
+	(*ListUsersResponse)(nil),                // 9: leakr.dbservice.v1.ListUsersResponse
+	(*UpdateUserRequest)(nil),                // 10: leakr.dbservice.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),                // 11: leakr.dbservice.v1.DeleteUserRequest
+	(*CreateSubscriptionRequest)(nil),        // 12: leakr.dbservice.v1.CreateSubscriptionRequest
+	(*GetUserSubscriptionsRequest)(nil),      // 13: leakr.dbservice.v1.GetUserSubscriptionsRequest
+	(*UpdateSubscriptionStatusRequest)(nil),  // 14: leakr.dbservice.v1.UpdateSubscriptionStatusRequest
+	(*GetSubscriptionByStripeIDRequest)(nil), // 15: leakr.dbservice.v1.GetSubscriptionByStripeIDRequest
+	(*GetUserSubscriptionEventsRequest)(nil), // 16: leakr.dbservice.v1.GetUserSubscriptionEventsRequest
+	(*ListSubscriptionEventsResponse)(nil),   // 17: leakr.dbservice.v1.ListSubscriptionEventsResponse
+	(*ListSubscriptionsRequest)(nil),         // 18: leakr.dbservice.v1.ListSubscriptionsRequest
+	(*ListSubscriptionsResponse)(nil),        // 19: leakr.dbservice.v1.ListSubscriptionsResponse
+	(*Entitlement)(nil),                      // 20: leakr.dbservice.v1.Entitlement
+	(*Plan)(nil),                             // 21: leakr.dbservice.v1.Plan
+	(*Entitlements)(nil),                     // 22: leakr.dbservice.v1.Entitlements
+	(*ListPlansRequest)(nil),                 // 23: leakr.dbservice.v1.ListPlansRequest
+	(*ListPlansResponse)(nil),                // 24: leakr.dbservice.v1.ListPlansResponse
+	(*GetPlanRequest)(nil),                   // 25: leakr.dbservice.v1.GetPlanRequest
+	(*GetPlanByPriceIDRequest)(nil),          // 26: leakr.dbservice.v1.GetPlanByPriceIDRequest
+	(*GetUserEntitlementsRequest)(nil),       // 27: leakr.dbservice.v1.GetUserEntitlementsRequest
+	(*timestamppb.Timestamp)(nil),            // 28: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                    // 29: google.protobuf.Empty
+}
+var file_dbservice_proto_depIdxs = []int32{
+	28, // 0: leakr.dbservice.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	28, // 1: leakr.dbservice.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 2: leakr.dbservice.v1.Subscription.current_period_end:type_name -> google.protobuf.Timestamp
+	0,  // 3: leakr.dbservice.v1.Subscription.user:type_name -> leakr.dbservice.v1.User
+	28, // 4: leakr.dbservice.v1.SubscriptionEvent.created_at:type_name -> google.protobuf.Timestamp
+	28, // 5: leakr.dbservice.v1.WebhookEvent.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: leakr.dbservice.v1.ListUsersResponse.users:type_name -> leakr.dbservice.v1.User
+	28, // 7: leakr.dbservice.v1.CreateSubscriptionRequest.current_period_end:type_name -> google.protobuf.Timestamp
+	3,  // 8: leakr.dbservice.v1.CreateSubscriptionRequest.event:type_name -> leakr.dbservice.v1.WebhookEvent
+	28, // 9: leakr.dbservice.v1.UpdateSubscriptionStatusRequest.current_period_end:type_name -> google.protobuf.Timestamp
+	3,  // 10: leakr.dbservice.v1.UpdateSubscriptionStatusRequest.event:type_name -> leakr.dbservice.v1.WebhookEvent
+	2,  // 11: leakr.dbservice.v1.ListSubscriptionEventsResponse.events:type_name -> leakr.dbservice.v1.SubscriptionEvent
+	1,  // 12: leakr.dbservice.v1.ListSubscriptionsResponse.subscriptions:type_name -> leakr.dbservice.v1.Subscription
+	20, // 13: leakr.dbservice.v1.Plan.entitlements:type_name -> leakr.dbservice.v1.Entitlement
+	20, // 14: leakr.dbservice.v1.Entitlements.entitlements:type_name -> leakr.dbservice.v1.Entitlement
+	21, // 15: leakr.dbservice.v1.ListPlansResponse.plans:type_name -> leakr.dbservice.v1.Plan
+	4,  // 16: leakr.dbservice.v1.UserService.CreateUser:input_type -> leakr.dbservice.v1.CreateUserRequest
+	5,  // 17: leakr.dbservice.v1.UserService.GetUser:input_type -> leakr.dbservice.v1.GetUserRequest
+	6,  // 18: leakr.dbservice.v1.UserService.GetUserByClerkID:input_type -> leakr.dbservice.v1.GetUserByClerkIDRequest
+	7,  // 19: leakr.dbservice.v1.UserService.ListUsers:input_type -> leakr.dbservice.v1.ListUsersRequest
+	8,  // 20: leakr.dbservice.v1.UserService.GetUsersByUUID:input_type -> leakr.dbservice.v1.GetUsersByUUIDRequest
+	10, // 21: leakr.dbservice.v1.UserService.UpdateUser:input_type -> leakr.dbservice.v1.UpdateUserRequest
+	11, // 22: leakr.dbservice.v1.UserService.DeleteUser:input_type -> leakr.dbservice.v1.DeleteUserRequest
+	12, // 23: leakr.dbservice.v1.SubscriptionService.CreateSubscription:input_type -> leakr.dbservice.v1.CreateSubscriptionRequest
+	15, // 24: leakr.dbservice.v1.SubscriptionService.GetSubscriptionByStripeID:input_type -> leakr.dbservice.v1.GetSubscriptionByStripeIDRequest
+	13, // 25: leakr.dbservice.v1.SubscriptionService.GetUserSubscriptions:input_type -> leakr.dbservice.v1.GetUserSubscriptionsRequest
+	14, // 26: leakr.dbservice.v1.SubscriptionService.UpdateSubscriptionStatus:input_type -> leakr.dbservice.v1.UpdateSubscriptionStatusRequest
+	16, // 27: leakr.dbservice.v1.SubscriptionService.GetUserSubscriptionEvents:input_type -> leakr.dbservice.v1.GetUserSubscriptionEventsRequest
+	18, // 28: leakr.dbservice.v1.SubscriptionService.ListSubscriptions:input_type -> leakr.dbservice.v1.ListSubscriptionsRequest
+	23, // 29: leakr.dbservice.v1.PlanService.ListPlans:input_type -> leakr.dbservice.v1.ListPlansRequest
+	25, // 30: leakr.dbservice.v1.PlanService.GetPlan:input_type -> leakr.dbservice.v1.GetPlanRequest
+	26, // 31: leakr.dbservice.v1.PlanService.GetPlanByPriceID:input_type -> leakr.dbservice.v1.GetPlanByPriceIDRequest
+	27, // 32: leakr.dbservice.v1.PlanService.GetUserEntitlements:input_type -> leakr.dbservice.v1.GetUserEntitlementsRequest
+	0,  // 33: leakr.dbservice.v1.UserService.CreateUser:output_type -> leakr.dbservice.v1.User
+	0,  // 34: leakr.dbservice.v1.UserService.GetUser:output_type -> leakr.dbservice.v1.User
+	0,  // 35: leakr.dbservice.v1.UserService.GetUserByClerkID:output_type -> leakr.dbservice.v1.User
+	9,  // 36: leakr.dbservice.v1.UserService.ListUsers:output_type -> leakr.dbservice.v1.ListUsersResponse
+	9,  // 37: leakr.dbservice.v1.UserService.GetUsersByUUID:output_type -> leakr.dbservice.v1.ListUsersResponse
+	0,  // 38: leakr.dbservice.v1.UserService.UpdateUser:output_type -> leakr.dbservice.v1.User
+	29, // 39: leakr.dbservice.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	1,  // 40: leakr.dbservice.v1.SubscriptionService.CreateSubscription:output_type -> leakr.dbservice.v1.Subscription
+	1,  // 41: leakr.dbservice.v1.SubscriptionService.GetSubscriptionByStripeID:output_type -> leakr.dbservice.v1.Subscription
+	19, // 42: leakr.dbservice.v1.SubscriptionService.GetUserSubscriptions:output_type -> leakr.dbservice.v1.ListSubscriptionsResponse
+	1,  // 43: leakr.dbservice.v1.SubscriptionService.UpdateSubscriptionStatus:output_type -> leakr.dbservice.v1.Subscription
+	17, // 44: leakr.dbservice.v1.SubscriptionService.GetUserSubscriptionEvents:output_type -> leakr.dbservice.v1.ListSubscriptionEventsResponse
+	19, // 45: leakr.dbservice.v1.SubscriptionService.ListSubscriptions:output_type -> leakr.dbservice.v1.ListSubscriptionsResponse
+	24, // 46: leakr.dbservice.v1.PlanService.ListPlans:output_type -> leakr.dbservice.v1.ListPlansResponse
+	21, // 47: leakr.dbservice.v1.PlanService.GetPlan:output_type -> leakr.dbservice.v1.Plan
+	21, // 48: leakr.dbservice.v1.PlanService.GetPlanByPriceID:output_type -> leakr.dbservice.v1.Plan
+	22, // 49: leakr.dbservice.v1.PlanService.GetUserEntitlements:output_type -> leakr.dbservice.v1.Entitlements
+	33, // [33:50] is the sub-list for method output_type
+	16, // [16:33] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_dbservice_proto_init() }
+func file_dbservice_proto_init() {
+	if File_dbservice_proto != nil {
+		return
+	}
+	file_dbservice_proto_msgTypes[10].OneofWrappers = []any{}
+	file_dbservice_proto_msgTypes[14].OneofWrappers = []any{}
+	file_dbservice_proto_msgTypes[20].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dbservice_proto_rawDesc), len(file_dbservice_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_dbservice_proto_goTypes,
+		DependencyIndexes: file_dbservice_proto_depIdxs,
+		MessageInfos:      file_dbservice_proto_msgTypes,
+	}.Build()
+	File_dbservice_proto = out.File
+	file_dbservice_proto_goTypes = nil
+	file_dbservice_proto_depIdxs = nil
+}
@@ -0,0 +1,5 @@
+// Package dbservicepb contains the gRPC API of db-service and its generated
+// Go client and server stubs.
+package dbservicepb
+
+//go:generate protoc -I . --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative dbservice.proto
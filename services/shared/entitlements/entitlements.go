@@ -0,0 +1,155 @@
+// Package entitlements describes what a user's plan allows, and helps
+// services enforce it.
+//
+// Plans and their entitlements are stored by db-service and can change at any
+// time; services fetch the entitlements of the caller with Fetch (or read them
+// from a signed entitlement token) and check them before acting:
+//
+//	set, err := entitlements.Fetch(dbservicepb.WithToken(ctx, token), client.Plans, nil)
+//	if err != nil {
+//		return err
+//	}
+//	if err := set.Check(entitlements.MaxCloudBackups, int64(count)); err != nil {
+//		return err // *entitlements.Error
+//	}
+package entitlements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"shared/dbservicepb"
+)
+
+// Known entitlement keys. A plan may define others; a key missing from a
+// plan is not granted.
+const (
+	// Sync enables the synchronization of the extension's data.
+	Sync = "sync"
+	// MaxCloudBackups limits the number of backups stored in the cloud.
+	MaxCloudBackups = "max_cloud_backups"
+	// BackupRetentionDays limits how long backups are kept.
+	BackupRetentionDays = "backup_retention_days"
+	// MaxDevices limits the number of devices syncing the same account.
+	MaxDevices = "max_devices"
+)
+
+// ErrNotEntitled is matched (errors.Is) by every *Error.
+var ErrNotEntitled = errors.New("entitlements: not entitled")
+
+// Entitlement is a feature or a limit granted by a plan.
+type Entitlement struct {
+	Enabled bool `json:"enabled"`
+	// Limit is nil when unlimited or not applicable.
+	Limit *int64 `json:"limit,omitempty"`
+}
+
+// Set is the effective entitlements of a user.
+type Set struct {
+	UserID       int64                  `json:"user_id,omitempty"`
+	Plan         string                 `json:"plan"`
+	Entitlements map[string]Entitlement `json:"entitlements"`
+}
+
+// Error reports an action denied by the entitlements.
+type Error struct {
+	Plan string
+	Key  string
+	// Limit is the reached limit; meaningless when Disabled.
+	Limit int64
+	// Disabled is set when the plan does not grant the entitlement at all.
+	Disabled bool
+}
+
+func (e *Error) Error() string {
+	if e.Disabled {
+		return fmt.Sprintf("entitlements: %s is not included in the %s plan", e.Key, e.Plan)
+	}
+	return fmt.Sprintf("entitlements: %s limit of %d reached on the %s plan", e.Key, e.Limit, e.Plan)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrNotEntitled
+}
+
+// Enabled reports whether the plan grants key.
+func (s *Set) Enabled(key string) bool {
+	if s == nil {
+		return false
+	}
+	e, ok := s.Entitlements[key]
+	return ok && e.Enabled
+}
+
+// Limit returns the limit of key. limited is false when the entitlement is
+// granted without a limit; a missing or disabled entitlement has a limit of 0.
+func (s *Set) Limit(key string) (limit int64, limited bool) {
+	if !s.Enabled(key) {
+		return 0, true
+	}
+	if l := s.Entitlements[key].Limit; l != nil {
+		return *l, true
+	}
+	return 0, false
+}
+
+// Check returns an *Error unless one more use of key is allowed, used being
+// the current usage (e.g. the number of backups already stored).
+func (s *Set) Check(key string, used int64) error {
+	plan := ""
+	if s != nil {
+		plan = s.Plan
+	}
+	if !s.Enabled(key) {
+		return &Error{Plan: plan, Key: key, Disabled: true}
+	}
+	if limit, limited := s.Limit(key); limited && used >= limit {
+		return &Error{Plan: plan, Key: key, Limit: limit}
+	}
+	return nil
+}
+
+// FromProto converts entitlements returned by db-service.
+func FromProto(pb *dbservicepb.Entitlements) *Set {
+	s := &Set{
+		UserID:       pb.GetUserId(),
+		Plan:         pb.GetPlan(),
+		Entitlements: make(map[string]Entitlement, len(pb.GetEntitlements())),
+	}
+	for _, e := range pb.GetEntitlements() {
+		s.Entitlements[e.GetKey()] = Entitlement{Enabled: e.GetEnabled(), Limit: e.Limit}
+	}
+	return s
+}
+
+// Proto converts s for the gRPC API.
+func (s *Set) Proto() *dbservicepb.Entitlements {
+	pb := &dbservicepb.Entitlements{UserId: s.UserID, Plan: s.Plan}
+	keys := make([]string, 0, len(s.Entitlements))
+	for key := range s.Entitlements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		e := s.Entitlements[key]
+		pb.Entitlements = append(pb.Entitlements, &dbservicepb.Entitlement{Key: key, Enabled: e.Enabled, Limit: e.Limit})
+	}
+	return pb
+}
+
+// Fetch returns the entitlements of a user from db-service. A nil req (or an
+// empty one) returns those of the caller authenticated by ctx (see
+// dbservicepb.WithToken). The result is not cached: plan changes apply to the
+// next call.
+func Fetch(ctx context.Context, plans dbservicepb.PlanServiceClient, req *dbservicepb.GetUserEntitlementsRequest) (*Set, error) {
+	if req == nil {
+		req = &dbservicepb.GetUserEntitlementsRequest{}
+	}
+	pb, err := plans.GetUserEntitlements(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return FromProto(pb), nil
+}
@@ -0,0 +1,92 @@
+package entitlements
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"shared/jwtauth"
+)
+
+// DefaultTokenIssuer is the `iss` claim of the entitlement tokens issued by
+// auth-service.
+const DefaultTokenIssuer = "leakr-auth"
+
+// TokenType is the `typ` header of entitlement tokens, so that a session
+// token is never taken for one.
+const TokenType = "entitlements+jwt"
+
+// Claims are the claims of an entitlement token: a short-lived JWT signed by
+// auth-service (POST /entitlements/token) that the extension and the services
+// verify offline against auth-service's entitlement JWKS.
+type Claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"` // public UUID of the user
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	Expiry    int64  `json:"exp"`
+
+	UserID       int64                  `json:"uid,omitempty"` // db-service user ID
+	Tier         string                 `json:"tier"`
+	Plan         string                 `json:"plan"`
+	Entitlements map[string]Entitlement `json:"ent"`
+}
+
+// Set returns the entitlements granted by the token.
+func (c *Claims) Set() *Set {
+	return &Set{UserID: c.UserID, Plan: c.Plan, Entitlements: c.Entitlements}
+}
+
+// ExpiresAt returns the expiry of the token.
+func (c *Claims) ExpiresAt() time.Time {
+	return time.Unix(c.Expiry, 0)
+}
+
+// TokenVerifier verifies entitlement tokens against the entitlement JWKS of
+// auth-service, cached and refreshed like Clerk's by jwtauth.
+type TokenVerifier struct {
+	v *jwtauth.Verifier
+}
+
+// NewTokenVerifier fetches the JWKS at cfg.JWKSURL (auth-service's
+// /.well-known/entitlements-jwks.json). cfg.Issuer defaults to
+// DefaultTokenIssuer, and tokens whose `typ` is not TokenType are rejected
+// with jwtauth.ErrWrongType. Call Close to stop the background refresh.
+func NewTokenVerifier(ctx context.Context, cfg jwtauth.Config) (*TokenVerifier, error) {
+	if cfg.Issuer == "" {
+		cfg.Issuer = DefaultTokenIssuer
+	}
+	cfg.Type = TokenType
+	v, err := jwtauth.NewVerifier(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenVerifier{v: v}, nil
+}
+
+// Verify checks the signature, type, issuer and validity period of an
+// entitlement token and returns its claims. The errors are those of jwtauth
+// (e.g. jwtauth.ErrExpired).
+func (t *TokenVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	p, err := t.v.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-decode the verified claims into their typed form
+	raw, err := json.Marshal(p.Claims)
+	if err != nil {
+		return nil, err
+	}
+	var claims Claims
+	if err := json.Unmarshal(raw, &claims); err != nil || claims.Entitlements == nil {
+		return nil, errors.Join(jwtauth.ErrInvalidClaims, err)
+	}
+	return &claims, nil
+}
+
+// Close stops the background refresh of the JWKS.
+func (t *TokenVerifier) Close() {
+	t.v.Close()
+}
@@ -0,0 +1,34 @@
+package jwtauth
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const principalKey = "principal"
+
+// Middleware verifies the `Authorization: Bearer <token>` header and stores
+// the Principal in the Fiber context.
+func Middleware(v *Verifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing_token"})
+		}
+
+		p, err := v.Verify(c.UserContext(), token)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_token"})
+		}
+
+		c.Locals(principalKey, p)
+		return c.Next()
+	}
+}
+
+// PrincipalFrom returns the Principal stored by Middleware, or nil.
+func PrincipalFrom(c *fiber.Ctx) *Principal {
+	p, _ := c.Locals(principalKey).(*Principal)
+	return p
+}
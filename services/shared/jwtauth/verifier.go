@@ -0,0 +1,262 @@
+// Package jwtauth verifies Clerk session JWTs locally against a cached JWKS.
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken = errors.New("jwtauth: malformed token")
+	ErrUnsupportedAlg = errors.New("jwtauth: unsupported signing algorithm")
+	ErrUnknownKey     = errors.New("jwtauth: unknown signing key")
+	ErrBadSignature   = errors.New("jwtauth: invalid signature")
+	ErrExpired        = errors.New("jwtauth: token expired")
+	ErrNotYetValid    = errors.New("jwtauth: token not valid yet")
+	ErrInvalidClaims  = errors.New("jwtauth: invalid claims")
+	ErrWrongType      = errors.New("jwtauth: unexpected token type")
+)
+
+// Config configures a Verifier.
+type Config struct {
+	// JWKSURL is the JWKS endpoint, e.g. https://<instance>.clerk.accounts.dev/.well-known/jwks.json.
+	JWKSURL string
+	// Issuer, when set, must match the `iss` claim.
+	Issuer string
+	// Type, when set, must match the `typ` header (case-insensitively, see
+	// RFC 7515), so that tokens of another kind signed by the same keys are
+	// rejected.
+	Type string
+	// AuthorizedParties, when set, must contain the `azp` claim.
+	AuthorizedParties []string
+	// RefreshInterval is how often the JWKS is refreshed in the background. Defaults to 1h.
+	RefreshInterval time.Duration
+	// MinRefreshInterval limits on-demand refreshes triggered by unknown key IDs. Defaults to 30s.
+	MinRefreshInterval time.Duration
+	// Leeway is the clock skew tolerated on `exp`, `nbf` and `iat`. Defaults to 5s.
+	Leeway time.Duration
+	// HTTPClient fetches the JWKS. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID    string         `json:"user_id"`
+	SessionID string         `json:"session_id"`
+	IssuedAt  time.Time      `json:"issued_at"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	Claims    map[string]any `json:"-"`
+}
+
+// Verifier verifies session tokens with the keys of a JWKS endpoint.
+type Verifier struct {
+	cfg    Config
+	keys   *keySet
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewVerifier fetches the JWKS and starts refreshing it in the background
+// until ctx is done or Close is called.
+func NewVerifier(ctx context.Context, cfg Config) (*Verifier, error) {
+	if cfg.JWKSURL == "" {
+		return nil, errors.New("jwtauth: JWKSURL is required")
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Hour
+	}
+	if cfg.MinRefreshInterval <= 0 {
+		cfg.MinRefreshInterval = 30 * time.Second
+	}
+	if cfg.Leeway <= 0 {
+		cfg.Leeway = 5 * time.Second
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	v := &Verifier{
+		cfg: cfg,
+		keys: &keySet{
+			url:         cfg.JWKSURL,
+			client:      cfg.HTTPClient,
+			minInterval: cfg.MinRefreshInterval,
+			now:         cfg.Now,
+		},
+		done: make(chan struct{}),
+	}
+	if err := v.keys.refresh(ctx, true); err != nil {
+		return nil, err
+	}
+
+	ctx, v.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go v.refreshLoop(ctx)
+	return v, nil
+}
+
+// Close stops the background refresh.
+func (v *Verifier) Close() {
+	v.cancel()
+	<-v.done
+}
+
+func (v *Verifier) refreshLoop(ctx context.Context) {
+	defer close(v.done)
+
+	ticker := time.NewTicker(v.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// On failure keep serving with the cached keys
+			if err := v.keys.refresh(ctx, true); err != nil {
+				log.Printf("jwtauth: background JWKS refresh failed: %v", err)
+			}
+		}
+	}
+}
+
+// Verify checks the signature and claims of a token and returns its Principal.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+		Typ string `json:"typ"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if v.cfg.Type != "" && !strings.EqualFold(header.Typ, v.cfg.Type) {
+		return nil, ErrWrongType
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	key, err := v.keys.get(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	return v.principal(claims)
+}
+
+func (v *Verifier) principal(claims map[string]any) (*Principal, error) {
+	now := v.cfg.Now()
+
+	exp, ok := numericDate(claims, "exp")
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+	if now.After(exp.Add(v.cfg.Leeway)) {
+		return nil, ErrExpired
+	}
+	if nbf, ok := numericDate(claims, "nbf"); ok && now.Add(v.cfg.Leeway).Before(nbf) {
+		return nil, ErrNotYetValid
+	}
+	iat, ok := numericDate(claims, "iat")
+	if ok && now.Add(v.cfg.Leeway).Before(iat) {
+		return nil, ErrNotYetValid
+	}
+
+	if v.cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+			return nil, ErrInvalidClaims
+		}
+	}
+	if len(v.cfg.AuthorizedParties) > 0 {
+		// Clerk omits azp for tokens not issued to a browser origin
+		if azp, _ := claims["azp"].(string); azp != "" && !slices.Contains(v.cfg.AuthorizedParties, azp) {
+			return nil, ErrInvalidClaims
+		}
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, ErrInvalidClaims
+	}
+	sid, _ := claims["sid"].(string)
+
+	return &Principal{
+		UserID:    sub,
+		SessionID: sid,
+		IssuedAt:  iat,
+		ExpiresAt: exp,
+		Claims:    claims,
+	}, nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, sig []byte) error {
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) != nil {
+			return ErrBadSignature
+		}
+		return nil
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		if !ed25519.Verify(pub, []byte(signingInput), sig) {
+			return ErrBadSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlg
+	}
+}
+
+func decodeSegment(seg string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func numericDate(claims map[string]any, name string) (time.Time, bool) {
+	n, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(n), 0), true
+}
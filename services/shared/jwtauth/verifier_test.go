@@ -0,0 +1,249 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer publishes the public keys of its signers by kid and counts the
+// fetches.
+type jwksServer struct {
+	*httptest.Server
+	fetches atomic.Int32
+
+	mu   sync.Mutex
+	keys map[string]crypto.Signer
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	t.Helper()
+	s := &jwksServer{keys: map[string]crypto.Signer{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var set struct {
+			Keys []jwk `json:"keys"`
+		}
+		for kid, key := range s.keys {
+			switch pub := key.Public().(type) {
+			case *rsa.PublicKey:
+				set.Keys = append(set.Keys, jwk{Kid: kid, Kty: "RSA", Alg: "RS256", Use: "sig",
+					N: b64(pub.N.Bytes()), E: b64(big.NewInt(int64(pub.E)).Bytes())})
+			case ed25519.PublicKey:
+				set.Keys = append(set.Keys, jwk{Kid: kid, Kty: "OKP", Crv: "Ed25519", Alg: "EdDSA", Use: "sig", X: b64(pub)})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// publish adds (or replaces) the key of kid.
+func (s *jwksServer) publish(kid string, key crypto.Signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = key
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// sign returns a token of claims signed by key with the alg and kid of the
+// header.
+func sign(t *testing.T, key crypto.Signer, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := b64(header) + "." + b64(payload)
+
+	var sig []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(input))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(input))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + b64(sig)
+}
+
+func newVerifier(t *testing.T, s *jwksServer, now *time.Time) *Verifier {
+	t.Helper()
+	v, err := NewVerifier(context.Background(), Config{
+		JWKSURL:            s.URL,
+		Issuer:             "https://clerk.example.com",
+		AuthorizedParties:  []string{"https://app.example.com"},
+		MinRefreshInterval: 30 * time.Second,
+		Now:                func() time.Time { return *now },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(v.Close)
+	return v
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	s := newJWKSServer(t)
+	s.publish("rsa", rsaKey)
+	s.publish("ed", edKey)
+	now := time.Unix(1_760_000_000, 0)
+	v := newVerifier(t, s, &now)
+
+	claims := func(edit func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"sub": "user_1",
+			"sid": "sess_1",
+			"iss": "https://clerk.example.com",
+			"azp": "https://app.example.com",
+			"iat": now.Unix(),
+			"nbf": now.Unix(),
+			"exp": now.Add(time.Minute).Unix(),
+		}
+		if edit != nil {
+			edit(c)
+		}
+		return c
+	}
+	tampered := func(token string) string {
+		// Swaps the payload for another one, keeping the signature
+		other := sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["sub"] = "user_2" }))
+		parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+		return parts[0] + "." + otherParts[1] + "." + parts[2]
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{name: "RS256", token: sign(t, rsaKey, "RS256", "rsa", claims(nil))},
+		{name: "EdDSA", token: sign(t, edKey, "EdDSA", "ed", claims(nil))},
+		{name: "bad signature", token: sign(t, otherKey, "EdDSA", "ed", claims(nil)), want: ErrBadSignature},
+		{name: "tampered payload", token: tampered(sign(t, edKey, "EdDSA", "ed", claims(nil))), want: ErrBadSignature},
+		{name: "alg not of the key", token: sign(t, edKey, "RS256", "ed", claims(nil)), want: ErrUnsupportedAlg},
+		{name: "RSA key as EdDSA", token: sign(t, rsaKey, "EdDSA", "rsa", claims(nil)), want: ErrUnsupportedAlg},
+		{name: "alg none", token: sign(t, edKey, "none", "ed", claims(nil)), want: ErrUnsupportedAlg},
+		{name: "expired", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["exp"] = now.Add(-6 * time.Second).Unix() })), want: ErrExpired},
+		{name: "expired within leeway", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["exp"] = now.Add(-4 * time.Second).Unix() }))},
+		{name: "not valid yet", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["nbf"] = now.Add(time.Minute).Unix() })), want: ErrNotYetValid},
+		{name: "issued in the future", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["iat"] = now.Add(time.Minute).Unix() })), want: ErrNotYetValid},
+		{name: "nbf within leeway", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["nbf"] = now.Add(4 * time.Second).Unix() }))},
+		{name: "wrong issuer", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["iss"] = "https://evil.example.com" })), want: ErrInvalidClaims},
+		{name: "unauthorized party", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { c["azp"] = "https://evil.example.com" })), want: ErrInvalidClaims},
+		{name: "no azp", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { delete(c, "azp") }))},
+		{name: "no exp", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { delete(c, "exp") })), want: ErrInvalidClaims},
+		{name: "no sub", token: sign(t, edKey, "EdDSA", "ed", claims(func(c map[string]any) { delete(c, "sub") })), want: ErrInvalidClaims},
+		{name: "malformed", token: "not.a.token", want: ErrMalformedToken},
+		{name: "two segments", token: "a.b", want: ErrMalformedToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(context.Background(), tt.token)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify = %v, want %v", err, tt.want)
+			}
+			if err == nil && (p.UserID != "user_1" || p.SessionID != "sess_1") {
+				t.Errorf("principal = %+v", p)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+	s := newJWKSServer(t)
+	s.publish("old", oldKey)
+	now := time.Unix(1_760_000_000, 0)
+	v := newVerifier(t, s, &now)
+	claims := map[string]any{"sub": "user_1", "iss": "https://clerk.example.com"}
+
+	// Rotated after the last fetch: the unknown kid triggers one refresh
+	now = now.Add(time.Minute)
+	claims["exp"] = now.Add(time.Minute).Unix()
+	s.publish("new", newKey)
+	for range 3 {
+		if _, err := v.Verify(context.Background(), sign(t, newKey, "EdDSA", "new", claims)); err != nil {
+			t.Fatalf("Verify with the new key: %v", err)
+		}
+	}
+	if n := s.fetches.Load(); n != 2 {
+		t.Errorf("%d JWKS fetch(es), want 2 (initial and one refresh)", n)
+	}
+	if _, err := v.Verify(context.Background(), sign(t, oldKey, "EdDSA", "old", claims)); err != nil {
+		t.Errorf("Verify with the old key still published: %v", err)
+	}
+}
+
+func TestRefreshRateLimit(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	s := newJWKSServer(t)
+	s.publish("k1", key)
+	now := time.Unix(1_760_000_000, 0)
+	v := newVerifier(t, s, &now)
+	bogus := sign(t, key, "EdDSA", "bogus", map[string]any{"sub": "user_1", "iss": "https://clerk.example.com", "exp": now.Add(time.Hour).Unix()})
+
+	// Within MinRefreshInterval of the initial fetch: no refresh at all
+	for range 5 {
+		if _, err := v.Verify(context.Background(), bogus); !errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("Verify = %v, want ErrUnknownKey", err)
+		}
+	}
+	if n := s.fetches.Load(); n != 1 {
+		t.Errorf("%d JWKS fetch(es) within the interval, want 1", n)
+	}
+
+	// Past it: one refresh, then limited again
+	now = now.Add(31 * time.Second)
+	for range 5 {
+		if _, err := v.Verify(context.Background(), bogus); !errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("Verify = %v, want ErrUnknownKey", err)
+		}
+	}
+	if n := s.fetches.Load(); n != 2 {
+		t.Errorf("%d JWKS fetch(es), want 2", n)
+	}
+}
+
+func TestNewVerifierJWKSUnavailable(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer s.Close()
+	if _, err := NewVerifier(context.Background(), Config{JWKSURL: s.URL}); err == nil {
+		t.Error("NewVerifier succeeded without a JWKS")
+	}
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"shared/dbservicepb"
+	"shared/entitlements"
+	"shared/jwtauth"
+	"storage-service/backups"
+	"storage-service/storage"
+	"storage-service/validate"
+)
+
+// uploadHandler gère POST /backups : enregistre la base exportée par
+// l'extension (champ multipart "file", nommée leakr_db_<uuid>_<date>_it<n>.sqlite)
+// après l'avoir validée (voir package validate). Les champs base_iteration,
+// base_sha256 et force décrivent la sauvegarde dont elle dérive (voir
+// backups.Precondition).
+func (s *server) uploadHandler(c *fiber.Ctx) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "file is required"})
+	}
+	pre, err := formPrecondition(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if fh.Size > s.maxSize {
+		return rejected(c, &validate.Rejection{Reason: validate.ReasonTooLarge, Detail: fmt.Sprintf("%d bytes, the maximum is %d", fh.Size, s.maxSize)})
+	}
+
+	u, name, ok := s.ownedName(c, fh.Filename)
+	if !ok {
+		return nil
+	}
+
+	// SQLite lit un fichier : l'upload est copié sur disque le temps de la validation
+	path, err := stage(fh)
+	if err != nil {
+		log.Printf("Staging backup %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+	}
+	defer os.Remove(path)
+
+	return s.storeBackup(c, u, name, path, pre)
+}
+
+// formPrecondition reads the precondition fields of a multipart upload.
+func formPrecondition(c *fiber.Ctx) (backups.Precondition, error) {
+	var pre backups.Precondition
+	if v := c.FormValue("base_iteration"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return pre, errors.New("base_iteration must be a non-negative integer")
+		}
+		pre.BaseIteration = &n
+	}
+	pre.BaseSHA256 = c.FormValue("base_sha256")
+	if v := c.FormValue("force"); v != "" {
+		force, err := strconv.ParseBool(v)
+		if err != nil {
+			return pre, errors.New("force must be a boolean")
+		}
+		pre.Force = force
+	}
+	return pre, nil
+}
+
+// storeBackup validates the database at path, the backup name of u, and
+// stores it in main unless it conflicts with the head of u (see
+// backups.Check). It answers 201 with the stored backup, 200 when it is the
+// head already, 403 when the plan of u does not allow it, 409 with the head
+// on conflict, or the rejection.
+func (s *server) storeBackup(c *fiber.Ctx, u *dbservicepb.User, name backups.Name, path string, pre backups.Precondition) error {
+	b, status, ok := s.saveBackup(c, u, name, path, pre)
+	if !ok {
+		return nil
+	}
+	return c.Status(status).JSON(b)
+}
+
+// saveBackup is storeBackup, returning the backup stored (or the head) with
+// the status to answer. It returns false when the request was answered with
+// an error.
+func (s *server) saveBackup(c *fiber.Ctx, u *dbservicepb.User, name backups.Name, path string, pre backups.Precondition) (*backups.Backup, int, bool) {
+	set, ok := s.syncEntitlements(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	ctx := c.UserContext()
+	info, err := validate.Backup(ctx, path, validate.Expect{UUID: u.GetUuid(), Iteration: name.Iteration, MaxSize: s.maxSize})
+	var rejection *validate.Rejection
+	if errors.As(err, &rejection) {
+		_ = rejected(c, rejection)
+		return nil, 0, false
+	}
+	if err != nil {
+		log.Printf("Validating backup %s failed: %v", name.Key(), err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to validate backup"})
+		return nil, 0, false
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+		return nil, 0, false
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+		return nil, 0, false
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	// Vérification de la tête et écriture sans upload concurrent du même utilisateur
+	unlock, err := s.lockUser(ctx, name.UUID)
+	if err != nil {
+		log.Printf("Locking backups of %s failed: %v", name.UUID, err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+		return nil, 0, false
+	}
+	defer unlock()
+	head, count, err := s.head(ctx, name.UUID)
+	if err != nil {
+		log.Printf("Reading head backup of %s failed: %v", name.UUID, err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+		return nil, 0, false
+	}
+	same, err := backups.Check(head, name, sum, pre)
+	var conflict *backups.Conflict
+	if errors.As(err, &conflict) {
+		_ = c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Backup conflict", "reason": conflict.Reason, "detail": conflict.Detail, "head": conflict.Head})
+		return nil, 0, false
+	}
+	if same {
+		return head, fiber.StatusOK, true
+	}
+	if err := set.Check(entitlements.MaxCloudBackups, int64(count)); err != nil {
+		_ = notEntitled(c, err)
+		return nil, 0, false
+	}
+
+	if err := s.stores.Main.Put(ctx, name.Key(), f, size, storage.Metadata{SHA256: sum, Version: info.Version}); err != nil {
+		log.Printf("Storing backup %s failed: %v", name.Key(), err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+		return nil, 0, false
+	}
+	obj, err := s.stores.Main.Stat(ctx, name.Key())
+	if err != nil {
+		log.Printf("Reading stored backup %s failed: %v", name.Key(), err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+		return nil, 0, false
+	}
+
+	b, _ := backups.FromObject(*obj, backups.LocationMain)
+	return &b, fiber.StatusCreated, true
+}
+
+// syncEntitlements returns the entitlements of the caller, from db-service,
+// after checking that their plan includes sync. It returns false when the
+// request was answered with an error.
+func (s *server) syncEntitlements(c *fiber.Ctx) (*entitlements.Set, bool) {
+	set, err := entitlements.Fetch(userContext(c), s.plans, nil)
+	if err != nil {
+		_ = dbError(c, err)
+		return nil, false
+	}
+	if err := set.Check(entitlements.Sync, 0); err != nil {
+		_ = notEntitled(c, err)
+		return nil, false
+	}
+	return set, true
+}
+
+// notEntitled writes the 403 response for an action the plan of the caller
+// does not allow (an *entitlements.Error).
+func notEntitled(c *fiber.Ctx, err error) error {
+	var e *entitlements.Error
+	if !errors.As(err, &e) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Not entitled"})
+	}
+	body := fiber.Map{"error": "Not entitled", "entitlement": e.Key, "plan": e.Plan}
+	if !e.Disabled {
+		body["limit"] = e.Limit
+	}
+	return c.Status(fiber.StatusForbidden).JSON(body)
+}
+
+// stage copies an uploaded file to a temporary file and returns its path.
+func stage(fh *multipart.FileHeader) (string, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	return stageReader(src)
+}
+
+// stageReader copies r to a temporary file and returns its path.
+func stageReader(r io.Reader) (string, error) {
+	dst, err := os.CreateTemp("", "leakr-upload-*.sqlite")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(dst, r)
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dst.Name())
+		return "", err
+	}
+	return dst.Name(), nil
+}
+
+// rejected writes the response for an invalid backup: 413 when too large,
+// 422 otherwise, with the reason of the rejection.
+func rejected(c *fiber.Ctx, r *validate.Rejection) error {
+	status := fiber.StatusUnprocessableEntity
+	if r.Reason == validate.ReasonTooLarge {
+		status = fiber.StatusRequestEntityTooLarge
+	}
+	return c.Status(status).JSON(fiber.Map{"error": "Invalid backup", "reason": r.Reason, "detail": r.Detail})
+}
+
+// listHandler gère GET /backups : les points de restauration de l'utilisateur
+// (main/ et backup/), le plus récent en premier.
+func (s *server) listHandler(c *fiber.Ctx) error {
+	list, ok := s.userBackups(c)
+	if !ok {
+		return nil
+	}
+	return c.JSON(list)
+}
+
+// latestHandler gère GET /backups/latest : télécharge la sauvegarde la plus
+// récente. ?upgrade=true la met d'abord au schéma courant (voir sendBackup).
+func (s *server) latestHandler(c *fiber.Ctx) error {
+	list, ok := s.userBackups(c)
+	if !ok {
+		return nil
+	}
+	if len(list) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No backup found"})
+	}
+	return s.sendBackup(c, list[0].Name())
+}
+
+// downloadHandler gère GET /backups/:filename, avec ?upgrade=true comme
+// latestHandler.
+func (s *server) downloadHandler(c *fiber.Ctx) error {
+	_, name, ok := s.ownedName(c, c.Params("filename"))
+	if !ok {
+		return nil
+	}
+	return s.sendBackup(c, name)
+}
+
+// deleteHandler gère DELETE /backups/:filename.
+func (s *server) deleteHandler(c *fiber.Ctx) error {
+	_, name, ok := s.ownedName(c, c.Params("filename"))
+	if !ok {
+		return nil
+	}
+
+	ctx := c.UserContext()
+	location, _, err := s.stores.Find(ctx, name)
+	if err == nil {
+		err = s.stores.Store(location).Delete(ctx, name.Key())
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Backup not found"})
+	}
+	if err != nil {
+		log.Printf("Deleting backup %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete backup"})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// sendBackup streams a stored backup, from either location, as an attachment.
+// With ?upgrade=true, a backup older than the extension's schema is upgraded
+// and stored back first.
+func (s *server) sendBackup(c *fiber.Ctx, name backups.Name) error {
+	if v := c.Query("upgrade"); v != "" {
+		upgrade, err := strconv.ParseBool(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "upgrade must be a boolean"})
+		}
+		if upgrade && !s.upgradeBackup(c, name) {
+			return nil
+		}
+	}
+
+	ctx := c.UserContext()
+	location, _, err := s.stores.Find(ctx, name)
+	var r io.ReadCloser
+	var obj *storage.Object
+	if err == nil {
+		r, obj, err = s.stores.Store(location).Get(ctx, name.Key())
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Backup not found"})
+	}
+	if err != nil {
+		log.Printf("Reading backup %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve backup"})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/vnd.sqlite3")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+name.Filename+`"`)
+	c.Set("X-Backup-Iteration", strconv.FormatInt(name.Iteration, 10))
+	c.Set("X-Backup-Location", location)
+	if obj.SHA256 != "" {
+		c.Set("X-Backup-SHA256", obj.SHA256)
+	}
+	if obj.Version != "" {
+		c.Set("X-Backup-Version", obj.Version)
+	}
+	// fasthttp ferme r une fois la réponse envoyée
+	return c.SendStream(r, int(obj.Size))
+}
+
+// fetchBackup copies a stored backup, from either location, to a temporary
+// file and returns its path.
+func (s *server) fetchBackup(ctx context.Context, name backups.Name) (string, error) {
+	location, _, err := s.stores.Find(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	r, _, err := s.stores.Store(location).Get(ctx, name.Key())
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	return stageReader(r)
+}
+
+// userBackups returns the backups of the caller in both locations, newest
+// first. It returns false when the request was answered with an error.
+func (s *server) userBackups(c *fiber.Ctx) ([]backups.Backup, bool) {
+	ctx := userContext(c)
+	u, err := s.currentUser(ctx, c)
+	if err != nil {
+		_ = dbError(c, err)
+		return nil, false
+	}
+
+	list, err := s.stores.List(ctx, u.GetUuid())
+	if err != nil {
+		log.Printf("Listing backups of %s failed: %v", u.GetUuid(), err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to list backups"})
+		return nil, false
+	}
+	return list, true
+}
+
+// ownedName parses a backup filename of the caller, returned with it; a
+// filename naming another user's backup is rejected. It returns false when
+// the request was answered with an error.
+func (s *server) ownedName(c *fiber.Ctx, filename string) (*dbservicepb.User, backups.Name, bool) {
+	// Les paramètres de route ne sont pas décodés (espaces des dates SQLite)
+	if decoded, err := url.PathUnescape(filename); err == nil {
+		filename = decoded
+	}
+	name, err := backups.ParseName(filename)
+	if err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid backup filename, expected leakr_db_{uuid}_{date}_it{iteration}.sqlite"})
+		return nil, name, false
+	}
+
+	u, err := s.currentUser(userContext(c), c)
+	if err != nil {
+		_ = dbError(c, err)
+		return nil, name, false
+	}
+	if name.UUID != strings.ToLower(u.GetUuid()) {
+		_ = c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Backup belongs to another user"})
+		return nil, name, false
+	}
+	return u, name, true
+}
+
+// userContext returns a context calling db-service on behalf of the caller,
+// with their own session token.
+func userContext(c *fiber.Ctx) context.Context {
+	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	return dbservicepb.WithToken(c.UserContext(), token)
+}
+
+// currentUser returns the db-service record of the authenticated caller.
+func (s *server) currentUser(ctx context.Context, c *fiber.Ctx) (*dbservicepb.User, error) {
+	principal := jwtauth.PrincipalFrom(c)
+	if principal == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid_token")
+	}
+	return s.users.GetUserByClerkID(ctx, &dbservicepb.GetUserByClerkIDRequest{ClerkUserId: principal.UserID})
+}
+
+// dbError writes the response for a failed db-service call.
+func dbError(c *fiber.Ctx, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	case codes.Unauthenticated:
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid_token"})
+	case codes.PermissionDenied:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+	default:
+		log.Printf("db-service error: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "db_service_error"})
+	}
+}
@@ -0,0 +1,91 @@
+package backups
+
+import "fmt"
+
+// Reasons of a Conflict.
+const (
+	// ReasonStaleBase: the upload derives from another backup than the head.
+	ReasonStaleBase = "stale_base"
+	// ReasonStaleIteration: the upload is not newer than the head.
+	ReasonStaleIteration = "stale_iteration"
+)
+
+// Precondition is what an upload declares about the backup it derives from:
+// the head of the user when their database was last synchronized.
+type Precondition struct {
+	// BaseIteration is the iteration of that backup; nil when the client did
+	// not declare it (older extensions). Without BaseIteration nor
+	// BaseSHA256, the upload only has to be newer than the head.
+	BaseIteration *int64 `json:"base_iteration,omitempty"`
+	// BaseSHA256 is its hex SHA-256, compared when both are known, with or
+	// without BaseIteration. A backup
+	// upgraded since (see package migrate) matches both its SHA-256 as
+	// uploaded and as stored.
+	BaseSHA256 string `json:"base_sha256,omitempty"`
+	// Force replaces the head whatever the base, as long as the upload is at
+	// least at its iteration: the head stays the backup of highest
+	// iteration, so a client forcing older data bumps its counter first.
+	Force bool `json:"force,omitempty"`
+}
+
+// Conflict is returned when an upload would overwrite a backup its client
+// has not seen.
+type Conflict struct {
+	Reason string
+	// Detail tells the client what to change, e.g. the iteration a forced
+	// upload must reach.
+	Detail string
+	Head   Backup
+}
+
+func (c *Conflict) Error() string {
+	return fmt.Sprintf("backups: conflict with %s (%s)", c.Head.Filename, c.Reason)
+}
+
+// Check checks the upload of the backup name, of content sha256, against the
+// head of its user (nil when they have no backup). It returns true when the
+// upload is the head itself, e.g. a retried request, and a *Conflict when it
+// must be rejected.
+//
+// The iteration counter of the extension only grows, so an upload derived
+// from the head has a base iteration equal to it and a higher iteration;
+// anything else was made from an older state and would lose the changes of
+// another device.
+func Check(head *Backup, name Name, sha256 string, p Precondition) (same bool, err error) {
+	if head == nil {
+		return false, nil
+	}
+	if head.Filename == name.Filename && head.HasSHA256(sha256) {
+		return true, nil
+	}
+	if p.Force {
+		if name.Iteration < head.Iteration {
+			return false, &Conflict{
+				Reason: ReasonStaleIteration,
+				Detail: fmt.Sprintf("a forced upload cannot go below the head's iteration %d: bump the iteration first", head.Iteration),
+				Head:   *head,
+			}
+		}
+		return false, nil
+	}
+
+	// Each part of the base is checked when declared
+	if p.BaseIteration != nil && *p.BaseIteration != head.Iteration {
+		return false, &Conflict{
+			Reason: ReasonStaleBase,
+			Detail: fmt.Sprintf("base_iteration %d is not the head's iteration %d", *p.BaseIteration, head.Iteration),
+			Head:   *head,
+		}
+	}
+	if p.BaseSHA256 != "" && head.SHA256 != "" && !head.HasSHA256(p.BaseSHA256) {
+		return false, &Conflict{Reason: ReasonStaleBase, Detail: "base_sha256 is not the head's SHA-256", Head: *head}
+	}
+	if name.Iteration <= head.Iteration {
+		return false, &Conflict{
+			Reason: ReasonStaleIteration,
+			Detail: fmt.Sprintf("iteration %d is not above the head's iteration %d", name.Iteration, head.Iteration),
+			Head:   *head,
+		}
+	}
+	return false, nil
+}
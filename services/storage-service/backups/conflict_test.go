@@ -0,0 +1,64 @@
+package backups
+
+import (
+	"errors"
+	"testing"
+)
+
+const uuid = "0b0e3f0a-1111-4222-8333-444455556666"
+
+func name(t *testing.T, iteration string) Name {
+	t.Helper()
+	n, err := ParseName("leakr_db_" + uuid + "_2025-05-17 10-21-03_it" + iteration + ".sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestCheck(t *testing.T) {
+	iteration := func(n int64) *int64 { return &n }
+	head := &Backup{Filename: name(t, "5").Filename, Iteration: 5, SHA256: "aaaa"}
+	upgraded := &Backup{Filename: name(t, "5").Filename, Iteration: 5, SHA256: "bbbb", UploadedSHA256: "aaaa"}
+
+	tests := []struct {
+		name   string
+		head   *Backup
+		upload string
+		sha256 string
+		pre    Precondition
+		same   bool
+		reason string
+	}{
+		{name: "no head", upload: "1"},
+		{name: "derived from the head", head: head, upload: "6", pre: Precondition{BaseIteration: iteration(5), BaseSHA256: "aaaa"}},
+		{name: "retry of the head", head: head, upload: "5", sha256: "AAAA", same: true},
+		{name: "stale base iteration", head: head, upload: "6", pre: Precondition{BaseIteration: iteration(4)}, reason: ReasonStaleBase},
+		{name: "other base content", head: head, upload: "6", pre: Precondition{BaseIteration: iteration(5), BaseSHA256: "cccc"}, reason: ReasonStaleBase},
+		{name: "hash only, same", head: head, upload: "6", pre: Precondition{BaseSHA256: "aaaa"}},
+		{name: "hash only, other", head: head, upload: "6", pre: Precondition{BaseSHA256: "cccc"}, reason: ReasonStaleBase},
+		{name: "not newer", head: head, upload: "5", sha256: "cccc", reason: ReasonStaleIteration},
+		{name: "forced", head: head, upload: "5", sha256: "cccc", pre: Precondition{Force: true}},
+		{name: "forced to a lower iteration", head: head, upload: "4", pre: Precondition{Force: true}, reason: ReasonStaleIteration},
+		{name: "upgraded head, uploaded hash", head: upgraded, upload: "6", pre: Precondition{BaseIteration: iteration(5), BaseSHA256: "aaaa"}},
+		{name: "upgraded head, stored hash", head: upgraded, upload: "6", pre: Precondition{BaseIteration: iteration(5), BaseSHA256: "bbbb"}},
+		{name: "upgraded head, retry of the upload", head: upgraded, upload: "5", sha256: "aaaa", same: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			same, err := Check(tt.head, name(t, tt.upload), tt.sha256, tt.pre)
+			if same != tt.same {
+				t.Errorf("same = %v, want %v", same, tt.same)
+			}
+			var conflict *Conflict
+			switch {
+			case tt.reason == "" && err != nil:
+				t.Errorf("unexpected error %v", err)
+			case tt.reason != "" && !errors.As(err, &conflict):
+				t.Errorf("error = %v, want a conflict %s", err, tt.reason)
+			case tt.reason != "" && conflict.Reason != tt.reason:
+				t.Errorf("reason = %s, want %s", conflict.Reason, tt.reason)
+			}
+		})
+	}
+}
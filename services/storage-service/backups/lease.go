@@ -0,0 +1,133 @@
+package backups
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"storage-service/storage"
+)
+
+// LeasePrefix is the prefix of the leases of the users (see Stores.Lock),
+// stored in main out of the users' prefixes.
+const LeasePrefix = "leases/"
+
+// LeaseTTL bounds how long a lease is held: the lease of a crashed instance
+// is taken over once expired. The writes under a lease must be shorter.
+const LeaseTTL = 2 * time.Minute
+
+// leaseRetry is the delay between two attempts to take a held lease.
+const leaseRetry = 100 * time.Millisecond
+
+// lease is the content of a lease object. A lease whose Expires is past,
+// e.g. released, is free.
+type lease struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+// LeaseKey returns the storage key of the lease of a user.
+func LeaseKey(uuid string) string {
+	return LeasePrefix + strings.ToLower(uuid)
+}
+
+// Lock takes the lease of the backups of a user, waiting while another
+// request holds it, whichever its instance, and returns the function
+// releasing it. The lease is a conditional write (storage.BlobStore.PutIf)
+// of an object of main, so that the check of an upload against the head and
+// its write are not interleaved with those of another upload.
+func (s Stores) Lock(ctx context.Context, uuid string) (unlock func(), err error) {
+	owner := make([]byte, 16)
+	if _, err := rand.Read(owner); err != nil {
+		return nil, err
+	}
+	l := lease{Owner: hex.EncodeToString(owner)}
+	key := LeaseKey(uuid)
+
+	for {
+		cond, held, err := s.leaseCondition(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !held {
+			l.Expires = time.Now().Add(LeaseTTL)
+			err = s.putLease(ctx, key, l, cond)
+			if err == nil {
+				return func() { s.release(key, l.Owner) }, nil
+			}
+			if !errors.Is(err, storage.ErrPreconditionFailed) {
+				return nil, fmt.Errorf("taking lease %s: %w", key, err)
+			}
+		}
+
+		// Held, or taken by another request meanwhile
+		t := time.NewTimer(leaseRetry)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// leaseCondition reads the lease under key and returns the condition of a
+// write taking it, or held when it is not expired.
+func (s Stores) leaseCondition(ctx context.Context, key string) (cond storage.Condition, held bool, err error) {
+	l, obj, err := s.getLease(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return storage.Condition{IfNoneMatch: true}, false, nil
+	}
+	if err != nil {
+		return cond, false, fmt.Errorf("reading lease %s: %w", key, err)
+	}
+	if time.Now().Before(l.Expires) {
+		return cond, true, nil
+	}
+	return storage.Condition{IfMatch: obj.ETag}, false, nil
+}
+
+// release frees the lease under key if owner still holds it. A failure
+// only delays the next upload until the lease expires.
+func (s Stores) release(key, owner string) {
+	ctx := context.Background()
+	l, obj, err := s.getLease(ctx, key)
+	if err == nil && l.Owner != owner {
+		// Expired and taken over: nothing to release
+		return
+	}
+	if err == nil {
+		err = s.putLease(ctx, key, lease{}, storage.Condition{IfMatch: obj.ETag})
+	}
+	if err != nil && !errors.Is(err, storage.ErrPreconditionFailed) {
+		log.Printf("Releasing lease %s failed: %v", key, err)
+	}
+}
+
+func (s Stores) getLease(ctx context.Context, key string) (lease, *storage.Object, error) {
+	r, obj, err := s.Main.Get(ctx, key)
+	if err != nil {
+		return lease{}, nil, err
+	}
+	defer r.Close()
+	var l lease
+	b, err := io.ReadAll(r)
+	if err == nil {
+		// An unreadable lease is taken over, as an expired one
+		_ = json.Unmarshal(b, &l)
+	}
+	return l, obj, err
+}
+
+func (s Stores) putLease(ctx context.Context, key string, l lease, cond storage.Condition) error {
+	b, _ := json.Marshal(l)
+	return s.Main.PutIf(ctx, key, bytes.NewReader(b), int64(len(b)), storage.Metadata{}, cond)
+}
@@ -0,0 +1,94 @@
+package backups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"storage-service/storage"
+)
+
+func newStores(t *testing.T) Stores {
+	t.Helper()
+	main, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Stores{Main: main, Backup: backup}
+}
+
+func TestLock(t *testing.T) {
+	s := newStores(t)
+	ctx := context.Background()
+
+	// Two Stores over the same store stand for two instances
+	var inside, overlaps atomic.Int32
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := Stores{Main: s.Main, Backup: s.Backup}.Lock(ctx, uuid)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if inside.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			time.Sleep(5 * time.Millisecond)
+			inside.Add(-1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+	if n := overlaps.Load(); n != 0 {
+		t.Errorf("lease held by several requests %d time(s)", n)
+	}
+
+	// Released: taken at once
+	unlock, err := s.Lock(ctx, uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Held: waits until the context is done
+	waitCtx, cancel := context.WithTimeout(ctx, 3*leaseRetry)
+	defer cancel()
+	if _, err := s.Lock(waitCtx, uuid); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Lock of a held lease = %v, want DeadlineExceeded", err)
+	}
+	unlock()
+}
+
+func TestLockExpired(t *testing.T) {
+	s := newStores(t)
+	ctx := context.Background()
+
+	// Left by a crashed instance
+	b, _ := json.Marshal(lease{Owner: "crashed", Expires: time.Now().Add(-time.Second)})
+	if err := s.Main.Put(ctx, LeaseKey(uuid), bytes.NewReader(b), int64(len(b)), storage.Metadata{}); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err := s.Lock(ctx, uuid)
+	if err != nil {
+		t.Fatalf("Lock of an expired lease: %v", err)
+	}
+	unlock()
+
+	if list, err := s.List(ctx, uuid); err != nil || len(list) != 0 {
+		t.Errorf("List = %v, %v: the lease is not a backup", list, err)
+	}
+	if users, err := s.Users(ctx); err != nil || len(users) != 0 {
+		t.Errorf("Users = %v, %v: the lease is not a backup", users, err)
+	}
+}
@@ -0,0 +1,159 @@
+// Package backups names and orders the backups of the extension's database.
+//
+// The extension exports its SQLite database as
+// leakr_db_<uuid>_<date>_it<iteration>.sqlite (see exportDatabaseData in
+// extension/src/lib/dbUtils.ts), uuid being settings.uuid, date the
+// version.date_maj column with ':' and '.' replaced by '-', and iteration
+// the version.iterations counter. Backups are stored under <uuid>/<filename>
+// in one of two areas (see Stores): main for the most recent versions and
+// backup for the older ones.
+package backups
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"storage-service/storage"
+)
+
+// ErrInvalidName is returned for a filename not produced by the extension.
+var ErrInvalidName = errors.New("backups: invalid backup filename")
+
+var nameRe = regexp.MustCompile(`^leakr_db_([0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12})_([0-9A-Za-z -]{1,40})_it([0-9]{1,18})\.sqlite$`)
+
+// Name is a parsed backup filename.
+type Name struct {
+	Filename  string
+	UUID      string
+	Date      string // as in the filename, e.g. 2025-05-17 10-21-03
+	Iteration int64
+}
+
+// ParseName parses a backup filename.
+func ParseName(filename string) (Name, error) {
+	m := nameRe.FindStringSubmatch(filename)
+	if m == nil {
+		return Name{}, ErrInvalidName
+	}
+	iteration, err := strconv.ParseInt(m[3], 10, 64)
+	if err != nil {
+		return Name{}, ErrInvalidName
+	}
+	return Name{Filename: filename, UUID: strings.ToLower(m[1]), Date: m[2], Iteration: iteration}, nil
+}
+
+// Time returns the date of the filename, the last update of the database,
+// or false when it is not in one of the formats produced by the extension:
+// SQLite's CURRENT_TIMESTAMP (2025-05-17 10-21-03) or an ISO 8601 date
+// (2025-05-17T10-21-03-000Z), both in UTC.
+func (n Name) Time() (time.Time, bool) {
+	if len(n.Date) < 19 {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02 15-04-05", strings.Replace(n.Date[:19], "T", " ", 1))
+	return t, err == nil
+}
+
+// Key returns the storage key of the backup.
+func (n Name) Key() string {
+	return Prefix(n.UUID) + n.Filename
+}
+
+// IncomingPrefix is the prefix of the backups assembled from a resumable
+// upload, stored there until validated.
+const IncomingPrefix = "incoming/"
+
+// IncomingKey returns the storage key of the backup while it is being
+// assembled from the resumable upload id, out of its user's prefix: two
+// uploads of the same file are assembled apart. An empty id gives the key of
+// the uploads started before the IDs.
+func (n Name) IncomingKey(id string) string {
+	if id == "" {
+		return IncomingPrefix + n.Key()
+	}
+	return IncomingPrefix + Prefix(n.UUID) + id + "/" + n.Filename
+}
+
+// Prefix returns the storage prefix of the backups of a user.
+func Prefix(uuid string) string {
+	return strings.ToLower(uuid) + "/"
+}
+
+// Backup is a stored backup, a restore point of the user.
+type Backup struct {
+	Filename  string `json:"filename"`
+	Date      string `json:"date"`
+	Iteration int64  `json:"iteration"`
+	Size      int64  `json:"size"`
+	// UploadedAt is when the backup was stored in its current location.
+	UploadedAt time.Time `json:"uploaded_at"`
+	Location   string    `json:"location"` // LocationMain or LocationBackup
+	// SHA256 is the hex SHA-256 of the file, when known (not in listings).
+	SHA256 string `json:"sha256,omitempty"`
+	// UploadedSHA256 is the SHA-256 of the file as uploaded, when an upgrade
+	// rewrote it since (see storage.Metadata): the clients may still know
+	// the backup by it.
+	UploadedSHA256 string `json:"-"`
+	// Version is the schema version of the database, when known (not in
+	// listings, nor for the backups stored before it was recorded).
+	Version string `json:"version,omitempty"`
+}
+
+// Name returns the parsed filename of b.
+func (b Backup) Name() Name {
+	n, _ := ParseName(b.Filename)
+	return n
+}
+
+// HasSHA256 reports whether sum is the SHA-256 of b, as stored or as
+// uploaded. It is false when b has no known SHA-256.
+func (b Backup) HasSHA256(sum string) bool {
+	return sum != "" && (strings.EqualFold(b.SHA256, sum) || strings.EqualFold(b.UploadedSHA256, sum))
+}
+
+// Age returns the time elapsed since the database was last updated, from the
+// date of its filename, or since its upload when the date cannot be parsed.
+// The date survives moves between locations, unlike the upload time.
+func (b Backup) Age(now time.Time) time.Duration {
+	if t, ok := b.Name().Time(); ok {
+		return now.Sub(t)
+	}
+	return now.Sub(b.UploadedAt)
+}
+
+// FromObject returns the backup stored as o in location, or false when its
+// key is not a backup's.
+func FromObject(o storage.Object, location string) (Backup, bool) {
+	i := strings.LastIndex(o.Key, "/")
+	n, err := ParseName(o.Key[i+1:])
+	if err != nil || o.Key != n.Key() {
+		return Backup{}, false
+	}
+	return Backup{Filename: n.Filename, Date: n.Date, Iteration: n.Iteration, Size: o.Size, UploadedAt: o.LastModified, Location: location, SHA256: o.SHA256, UploadedSHA256: o.UploadedSHA256, Version: o.Version}, true
+}
+
+// FromObjects returns the backups among objects stored in location.
+func FromObjects(objects []storage.Object, location string) []Backup {
+	list := make([]Backup, 0, len(objects))
+	for _, o := range objects {
+		if b, ok := FromObject(o, location); ok {
+			list = append(list, b)
+		}
+	}
+	return list
+}
+
+// SortNewestFirst orders backups by decreasing iteration, then upload time:
+// the iteration counter of the database is more reliable than the clocks.
+func SortNewestFirst(list []Backup) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Iteration != list[j].Iteration {
+			return list[i].Iteration > list[j].Iteration
+		}
+		return list[i].UploadedAt.After(list[j].UploadedAt)
+	})
+}
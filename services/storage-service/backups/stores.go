@@ -0,0 +1,86 @@
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"storage-service/storage"
+)
+
+// Locations of the backups.
+const (
+	// LocationMain holds the most recent versions, where uploads are stored.
+	LocationMain = "main"
+	// LocationBackup holds the older versions, moved there by the retention job.
+	LocationBackup = "backup"
+)
+
+// Stores are the two areas backups are stored in.
+type Stores struct {
+	Main   storage.BlobStore
+	Backup storage.BlobStore
+}
+
+// Store returns the store of a location.
+func (s Stores) Store(location string) storage.BlobStore {
+	if location == LocationBackup {
+		return s.Backup
+	}
+	return s.Main
+}
+
+// List returns the backups of a user in both locations, newest first.
+func (s Stores) List(ctx context.Context, uuid string) ([]Backup, error) {
+	var list []Backup
+	for _, location := range []string{LocationMain, LocationBackup} {
+		objects, err := s.Store(location).List(ctx, Prefix(uuid))
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", location, err)
+		}
+		list = append(list, FromObjects(objects, location)...)
+	}
+	SortNewestFirst(list)
+	return list, nil
+}
+
+// Find returns the location of a backup, main first. It returns
+// storage.ErrNotFound when the backup is in neither.
+func (s Stores) Find(ctx context.Context, n Name) (string, *storage.Object, error) {
+	for _, location := range []string{LocationMain, LocationBackup} {
+		obj, err := s.Store(location).Stat(ctx, n.Key())
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return location, obj, nil
+	}
+	return "", nil, storage.ErrNotFound
+}
+
+// Users returns the UUIDs of the users with backups in any location.
+func (s Stores) Users(ctx context.Context) ([]string, error) {
+	seen := map[string]bool{}
+	var uuids []string
+	for _, location := range []string{LocationMain, LocationBackup} {
+		objects, err := s.Store(location).List(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", location, err)
+		}
+		for _, o := range objects {
+			// Skips the objects other than backups, e.g. the incoming ones
+			if _, ok := FromObject(o, location); !ok {
+				continue
+			}
+			uuid, _, _ := strings.Cut(o.Key, "/")
+			if !seen[uuid] {
+				seen[uuid] = true
+				uuids = append(uuids, uuid)
+			}
+		}
+	}
+	return uuids, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+
+	"storage-service/backups"
+	"storage-service/diff"
+	"storage-service/storage"
+)
+
+// diffHandler gère GET /backups/diff?from=...&to=... : ce qui change d'une
+// sauvegarde à l'autre, table par table (voir package diff), pour savoir ce
+// qu'une restauration ferait gagner ou perdre.
+func (s *server) diffHandler(c *fiber.Ctx) error {
+	if c.Query("from") == "" || c.Query("to") == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "from and to are required"})
+	}
+	_, from, ok := s.ownedName(c, c.Query("from"))
+	if !ok {
+		return nil
+	}
+	to, err := backups.ParseName(c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid backup filename, expected leakr_db_{uuid}_{date}_it{iteration}.sqlite"})
+	}
+	if to.UUID != from.UUID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Backup belongs to another user"})
+	}
+
+	ctx := c.UserContext()
+	var paths [2]string
+	for i, name := range [2]backups.Name{from, to} {
+		path, err := s.fetchBackup(ctx, name)
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Backup not found", "filename": name.Filename})
+		}
+		if err != nil {
+			log.Printf("Reading backup %s failed: %v", name.Key(), err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve backup"})
+		}
+		defer os.Remove(path)
+		paths[i] = path
+	}
+
+	d, err := diff.Compare(ctx, paths[0], paths[1])
+	if err != nil {
+		log.Printf("Comparing backups %s and %s failed: %v", from.Key(), to.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to compare backups"})
+	}
+	return c.JSON(d)
+}
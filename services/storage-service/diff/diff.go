@@ -0,0 +1,387 @@
+// Package diff compares two backups of the extension's database, e.g. two
+// restore points of a user, table by table.
+//
+// Both backups are read as of the current schema (see package snapshot),
+// so that backups of different versions of the extension compare on their
+// data only. Rows are paired by natural key first (nom for createurs and
+// plateformes, creator and url for contenus, creator and platform for
+// profils_plateforme), the row of the same id first, then, for createurs
+// and plateformes, by id: a creator found under the same id with another
+// name was renamed.
+package diff
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"storage-service/snapshot"
+)
+
+// Diff is what changed from one backup to another.
+type Diff struct {
+	From Info `json:"from"`
+	To   Info `json:"to"`
+
+	Summary Summary `json:"summary"`
+
+	Createurs   Table[Createur]   `json:"createurs"`
+	Contenus    Table[Contenu]    `json:"contenus"`
+	Plateformes Table[Plateforme] `json:"plateformes"`
+	Profils     Table[Profil]     `json:"profils_plateforme"`
+
+	// Favorites lists the creators and contents whose favori changed.
+	Favorites []Favorite `json:"favorites"`
+	// Renamed lists the creators whose nom changed.
+	Renamed []Rename `json:"renamed"`
+}
+
+// Info describes one of the backups compared.
+type Info struct {
+	Version         string `json:"version"`
+	Iterations      int64  `json:"iterations"`
+	DateMaj         string `json:"date_maj"`
+	ShareCollection bool   `json:"share_collection"`
+}
+
+// Summary counts the changes.
+type Summary struct {
+	Createurs   Counts `json:"createurs"`
+	Contenus    Counts `json:"contenus"`
+	Plateformes Counts `json:"plateformes"`
+	Profils     Counts `json:"profils_plateforme"`
+
+	FavoritesAdded   int `json:"favorites_added"`
+	FavoritesRemoved int `json:"favorites_removed"`
+	Renamed          int `json:"renamed"`
+}
+
+// Counts counts the changes of a table.
+type Counts struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+}
+
+// Table lists the changes of a table.
+type Table[R any] struct {
+	Added    []R         `json:"added"`
+	Removed  []R         `json:"removed"`
+	Modified []Change[R] `json:"modified"`
+}
+
+// Change is a modified row, before and after, with the columns that changed.
+type Change[R any] struct {
+	From   R        `json:"from"`
+	To     R        `json:"to"`
+	Fields []string `json:"fields"`
+}
+
+// Favorite is a creator or content added to (or removed from) the favorites.
+type Favorite struct {
+	Table string `json:"table"`
+	ID    int64  `json:"id"`
+	// Name is the nom of a creator, the url of a content.
+	Name   string `json:"name"`
+	Favori bool   `json:"favori"`
+}
+
+// Rename is a renamed creator.
+type Rename struct {
+	ID   int64  `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Createur is a row of createurs.
+type Createur struct {
+	ID        int64    `json:"id"`
+	Nom       string   `json:"nom"`
+	Aliases   []string `json:"aliases"`
+	DateAjout string   `json:"date_ajout"`
+	Favori    bool     `json:"favori"`
+	Verifie   bool     `json:"verifie"`
+}
+
+// Contenu is a row of contenus, with the nom of its creator.
+type Contenu struct {
+	ID         int64   `json:"id"`
+	URL        string  `json:"url"`
+	Tabname    *string `json:"tabname"`
+	DateAjout  string  `json:"date_ajout"`
+	IDCreateur int64   `json:"id_createur"`
+	Createur   string  `json:"createur"`
+	Favori     bool    `json:"favori"`
+}
+
+// Plateforme is a row of plateformes.
+type Plateforme struct {
+	ID  int64  `json:"id"`
+	Nom string `json:"nom"`
+}
+
+// Profil is a row of profils_plateforme, with the names of the rows it
+// references.
+type Profil struct {
+	ID           int64  `json:"id"`
+	Lien         string `json:"lien"`
+	IDCreateur   int64  `json:"id_createur"`
+	Createur     string `json:"createur"`
+	IDPlateforme int64  `json:"id_plateforme"`
+	Plateforme   string `json:"plateforme"`
+}
+
+// Compare returns what changed from the backup at from to the one at to.
+func Compare(ctx context.Context, from, to string) (*Diff, error) {
+	a, err := snapshot.Read(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("diff: reading %s: %w", from, err)
+	}
+	b, err := snapshot.Read(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("diff: reading %s: %w", to, err)
+	}
+	return compare(a, b), nil
+}
+
+func compare(a, b *snapshot.Snapshot) *Diff {
+	d := &Diff{From: info(a), To: info(b)}
+
+	createurs := pair(a.Createurs, b.Createurs, func(c snapshot.Createur) string { return c.Nom }, true)
+	d.Createurs = changes(createurs, a.Createurs, b.Createurs, createurRow, createurRow, createurFields)
+
+	plateformes := pair(a.Plateformes, b.Plateformes, func(p snapshot.Plateforme) string { return p.Nom }, true)
+	d.Plateformes = changes(plateformes, a.Plateformes, b.Plateformes, plateformeRow, plateformeRow, func(x, y snapshot.Plateforme) []string {
+		return changed(nil, x.Nom != y.Nom, "nom")
+	})
+
+	// The rows referencing others are keyed on the ids of to
+	contenus := pairRefs(a.Contenus, b.Contenus, func(c snapshot.Contenu, from bool) contenuKey {
+		if from {
+			c.Createur = createurs.to(c.Createur)
+		}
+		return contenuKey{c.Createur, c.URL}
+	})
+	d.Contenus = changes(contenus, a.Contenus, b.Contenus, contenuRow(a), contenuRow(b), func(x, y snapshot.Contenu) []string {
+		f := changed(nil, x.Tabname != y.Tabname, "tabname")
+		f = changed(f, x.DateAjout != y.DateAjout, "date_ajout")
+		return changed(f, x.Favori != y.Favori, "favori")
+	})
+
+	profils := pairRefs(a.Profils, b.Profils, func(p snapshot.Profil, from bool) profilKey {
+		if from {
+			p.Createur, p.Plateforme = createurs.to(p.Createur), plateformes.to(p.Plateforme)
+		}
+		return profilKey{p.Createur, p.Plateforme}
+	})
+	d.Profils = changes(profils, a.Profils, b.Profils, profilRow(a), profilRow(b), func(x, y snapshot.Profil) []string {
+		return changed(nil, x.Lien != y.Lien, "lien")
+	})
+
+	d.Favorites, d.Renamed = []Favorite{}, []Rename{}
+	for _, c := range d.Createurs.Modified {
+		if c.From.Nom != c.To.Nom {
+			d.Renamed = append(d.Renamed, Rename{ID: c.To.ID, From: c.From.Nom, To: c.To.Nom})
+		}
+		if c.From.Favori != c.To.Favori {
+			d.Favorites = append(d.Favorites, Favorite{Table: "createurs", ID: c.To.ID, Name: c.To.Nom, Favori: c.To.Favori})
+		}
+	}
+	for _, c := range d.Contenus.Modified {
+		if c.From.Favori != c.To.Favori {
+			d.Favorites = append(d.Favorites, Favorite{Table: "contenus", ID: c.To.ID, Name: c.To.URL, Favori: c.To.Favori})
+		}
+	}
+
+	d.Summary = Summary{
+		Createurs:   d.Createurs.counts(),
+		Contenus:    d.Contenus.counts(),
+		Plateformes: d.Plateformes.counts(),
+		Profils:     d.Profils.counts(),
+		Renamed:     len(d.Renamed),
+	}
+	for _, f := range d.Favorites {
+		if f.Favori {
+			d.Summary.FavoritesAdded++
+		} else {
+			d.Summary.FavoritesRemoved++
+		}
+	}
+	return d
+}
+
+type contenuKey struct {
+	createur int64
+	url      string
+}
+
+type profilKey struct {
+	createur, plateforme int64
+}
+
+func createurRow(id int64, c snapshot.Createur) Createur {
+	return Createur{ID: id, Nom: c.Nom, Aliases: snapshot.ParseAliases(c.Aliases), DateAjout: c.DateAjout, Favori: c.Favori, Verifie: c.Verifie}
+}
+
+func createurFields(x, y snapshot.Createur) []string {
+	f := changed(nil, x.Nom != y.Nom, "nom")
+	f = changed(f, x.Aliases != y.Aliases, "aliases")
+	f = changed(f, x.DateAjout != y.DateAjout, "date_ajout")
+	f = changed(f, x.Favori != y.Favori, "favori")
+	return changed(f, x.Verifie != y.Verifie, "verifie")
+}
+
+func plateformeRow(id int64, p snapshot.Plateforme) Plateforme {
+	return Plateforme{ID: id, Nom: p.Nom}
+}
+
+// contenuRow returns the rows of the contenus of s.
+func contenuRow(s *snapshot.Snapshot) func(int64, snapshot.Contenu) Contenu {
+	return func(id int64, c snapshot.Contenu) Contenu {
+		row := Contenu{ID: id, URL: c.URL, DateAjout: c.DateAjout, IDCreateur: c.Createur, Createur: s.Createurs[c.Createur].Nom, Favori: c.Favori}
+		if c.Tabname.Valid {
+			row.Tabname = &c.Tabname.String
+		}
+		return row
+	}
+}
+
+// profilRow returns the rows of the profils_plateforme of s.
+func profilRow(s *snapshot.Snapshot) func(int64, snapshot.Profil) Profil {
+	return func(id int64, p snapshot.Profil) Profil {
+		return Profil{
+			ID:           id,
+			Lien:         p.Lien,
+			IDCreateur:   p.Createur,
+			Createur:     s.Createurs[p.Createur].Nom,
+			IDPlateforme: p.Plateforme,
+			Plateforme:   s.Plateformes[p.Plateforme].Nom,
+		}
+	}
+}
+
+// changed appends the column name to fields when it differs.
+func changed(fields []string, differs bool, name string) []string {
+	if differs {
+		return append(fields, name)
+	}
+	return fields
+}
+
+func info(s *snapshot.Snapshot) Info {
+	return Info{Version: s.Version, Iterations: s.Iterations, DateMaj: s.DateMaj, ShareCollection: s.ShareCollection}
+}
+
+func (t Table[R]) counts() Counts {
+	return Counts{Added: len(t.Added), Removed: len(t.Removed), Modified: len(t.Modified)}
+}
+
+// pairing pairs the ids of the rows of both backups.
+type pairing struct {
+	pairs   [][2]int64
+	toID    map[int64]int64
+	removed []int64
+	added   []int64
+}
+
+// to returns the id in to of the row with id in from, or -id when it was
+// removed, so that it matches no row of to.
+func (p *pairing) to(id int64) int64 {
+	if to, ok := p.toID[id]; ok {
+		return to
+	}
+	return -id
+}
+
+// pair pairs the rows of from and to by key, then by id when byID.
+func pair[R any, K comparable](from, to map[int64]R, key func(R) K, byID bool) *pairing {
+	p := &pairing{toID: map[int64]int64{}}
+	unpaired := map[int64]bool{}
+	byKey := map[K][]int64{}
+	for _, id := range sortedIDs(to) {
+		unpaired[id] = true
+		k := key(to[id])
+		byKey[k] = append(byKey[k], id)
+	}
+
+	var rest []int64
+	for _, id := range sortedIDs(from) {
+		k := key(from[id])
+		// Same key: the row of the same id first, else the first left
+		ids := byKey[k]
+		i := slices.Index(ids, id)
+		if i < 0 && len(ids) > 0 {
+			i = 0
+		}
+		if i < 0 {
+			rest = append(rest, id)
+			continue
+		}
+		p.match(id, ids[i], unpaired)
+		byKey[k] = slices.Delete(ids, i, i+1)
+	}
+	for _, id := range rest {
+		if byID && unpaired[id] {
+			p.match(id, id, unpaired)
+			continue
+		}
+		p.removed = append(p.removed, id)
+	}
+	for _, id := range sortedIDs(to) {
+		if unpaired[id] {
+			p.added = append(p.added, id)
+		}
+	}
+	return p
+}
+
+func (p *pairing) match(from, to int64, unpaired map[int64]bool) {
+	p.pairs = append(p.pairs, [2]int64{from, to})
+	p.toID[from] = to
+	delete(unpaired, to)
+}
+
+// pairRefs pairs rows referencing others by key, computed on the ids of to
+// (from reports whether the row is one of from).
+func pairRefs[R any, K comparable](from, to map[int64]R, key func(r R, from bool) K) *pairing {
+	type side struct {
+		from bool
+		row  R
+	}
+	wrap := func(rows map[int64]R, isFrom bool) map[int64]side {
+		m := make(map[int64]side, len(rows))
+		for id, r := range rows {
+			m[id] = side{isFrom, r}
+		}
+		return m
+	}
+	return pair(wrap(from, true), wrap(to, false), func(s side) K { return key(s.row, s.from) }, false)
+}
+
+// changes lists the rows added, removed and modified, made by rowFrom and
+// rowTo, diff returning the columns changed.
+func changes[S, R any](p *pairing, from, to map[int64]S, rowFrom, rowTo func(int64, S) R, diff func(S, S) []string) Table[R] {
+	t := Table[R]{Added: []R{}, Removed: []R{}, Modified: []Change[R]{}}
+	for _, id := range p.added {
+		t.Added = append(t.Added, rowTo(id, to[id]))
+	}
+	for _, id := range p.removed {
+		t.Removed = append(t.Removed, rowFrom(id, from[id]))
+	}
+	for _, ids := range p.pairs {
+		x, y := from[ids[0]], to[ids[1]]
+		if f := diff(x, y); len(f) > 0 {
+			t.Modified = append(t.Modified, Change[R]{From: rowFrom(ids[0], x), To: rowTo(ids[1], y), Fields: f})
+		}
+	}
+	return t
+}
+
+func sortedIDs[R any](rows map[int64]R) []int64 {
+	ids := make([]int64, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
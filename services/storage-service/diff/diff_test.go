@@ -0,0 +1,158 @@
+package diff_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"storage-service/diff"
+	"storage-service/snapshot"
+	"storage-service/snapshot/snapshottest"
+)
+
+const date = "2026-01-01"
+
+var rows = []string{
+	`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (1, 'Alice', '["alice"]', '` + date + `'), (2, 'Bob', '["bob"]', '` + date + `')`,
+	`INSERT INTO plateformes (id, nom) VALUES (1, 'YouTube')`,
+	`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (1, 'https://a/1', '` + date + `', 1)`,
+	`INSERT INTO profils_plateforme (id, lien, id_createur, id_plateforme) VALUES (1, 'https://youtube.com/@alice', 1, 1)`,
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to func(t *testing.T) string
+		summary  diff.Summary
+		renamed  []diff.Rename
+		added    []string // createurs
+		removed  []string
+		fields   [][]string // of the modified createurs
+	}{
+		{
+			name: "unchanged",
+			from: func(t *testing.T) string { return snapshottest.New(t, snapshot.Version, rows...) },
+			to:   func(t *testing.T) string { return snapshottest.New(t, snapshot.Version, rows...) },
+		},
+		{
+			name: "renamed",
+			from: func(t *testing.T) string { return snapshottest.New(t, snapshot.Version, rows...) },
+			to: func(t *testing.T) string {
+				return snapshottest.New(t, snapshot.Version, append(rows, `UPDATE createurs SET nom = 'Alicia' WHERE id = 1`)...)
+			},
+			summary: diff.Summary{Createurs: diff.Counts{Modified: 1}, Renamed: 1},
+			renamed: []diff.Rename{{ID: 1, From: "Alice", To: "Alicia"}},
+			fields:  [][]string{{"nom"}},
+		},
+		{
+			// Deleted, then added again under the same name: the same creator,
+			// with the same content and profile
+			name: "re-added",
+			from: func(t *testing.T) string { return snapshottest.New(t, snapshot.Version, rows...) },
+			to: func(t *testing.T) string {
+				return snapshottest.New(t, snapshot.Version, append(rows,
+					`DELETE FROM profils_plateforme`,
+					`DELETE FROM contenus`,
+					`DELETE FROM createurs WHERE id = 1`,
+					`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (3, 'Alice', '["alice"]', '`+date+`')`,
+					`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (2, 'https://a/1', '`+date+`', 3)`,
+					`INSERT INTO profils_plateforme (id, lien, id_createur, id_plateforme) VALUES (2, 'https://youtube.com/@alice', 3, 1)`,
+				)...)
+			},
+		},
+		{
+			// Deleted, another added under a new id: not a rename
+			name: "replaced",
+			from: func(t *testing.T) string { return snapshottest.New(t, snapshot.Version, rows...) },
+			to: func(t *testing.T) string {
+				return snapshottest.New(t, snapshot.Version, append(rows,
+					`DELETE FROM profils_plateforme`,
+					`DELETE FROM contenus`,
+					`DELETE FROM createurs WHERE id = 1`,
+					`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (3, 'Alicia', '["alice"]', '`+date+`')`,
+					`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (2, 'https://a/1', '`+date+`', 3)`,
+				)...)
+			},
+			summary: diff.Summary{
+				Createurs: diff.Counts{Added: 1, Removed: 1},
+				Contenus:  diff.Counts{Added: 1, Removed: 1},
+				Profils:   diff.Counts{Removed: 1},
+			},
+			added:   []string{"Alicia"},
+			removed: []string{"Alice"},
+		},
+		{
+			// Read as of 1.1.2: the invalid aliases are none, verifie is false
+			// before 1.1.0
+			name: "1.0.0 to 1.1.2",
+			from: func(t *testing.T) string {
+				return snapshottest.New(t, "1.0.0",
+					`INSERT INTO createurs (id, nom, aliases, date_ajout, favori) VALUES (1, 'Alice', '["alice"]', '`+date+`', 'true'), (2, 'Bob', 'bob', '`+date+`', 0)`,
+					`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (1, 'https://a/1', '`+date+`', 1)`,
+				)
+			},
+			to: func(t *testing.T) string {
+				return snapshottest.New(t, snapshot.Version,
+					`INSERT INTO createurs (id, nom, aliases, date_ajout, favori, verifie) VALUES (1, 'Alice', '["alice"]', '`+date+`', 1, 1), (2, 'Bob', '[]', '`+date+`', 1, 0)`,
+					`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (1, 'https://a/1', '`+date+`', 1)`,
+				)
+			},
+			summary: diff.Summary{Createurs: diff.Counts{Modified: 2}, FavoritesAdded: 1},
+			fields:  [][]string{{"verifie"}, {"favori"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := diff.Compare(context.Background(), tt.from(t), tt.to(t))
+			if err != nil {
+				t.Fatalf("Compare: %v", err)
+			}
+			if d.Summary != tt.summary {
+				t.Errorf("summary = %+v, want %+v", d.Summary, tt.summary)
+			}
+			if tt.renamed == nil {
+				tt.renamed = []diff.Rename{}
+			}
+			if !reflect.DeepEqual(d.Renamed, tt.renamed) {
+				t.Errorf("renamed = %+v, want %+v", d.Renamed, tt.renamed)
+			}
+			names := func(rows []diff.Createur) []string {
+				var n []string
+				for _, r := range rows {
+					n = append(n, r.Nom)
+				}
+				return n
+			}
+			if got := names(d.Createurs.Added); !reflect.DeepEqual(got, tt.added) {
+				t.Errorf("added = %q, want %q", got, tt.added)
+			}
+			if got := names(d.Createurs.Removed); !reflect.DeepEqual(got, tt.removed) {
+				t.Errorf("removed = %q, want %q", got, tt.removed)
+			}
+			var fields [][]string
+			for _, c := range d.Createurs.Modified {
+				fields = append(fields, c.Fields)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("modified fields = %q, want %q", fields, tt.fields)
+			}
+		})
+	}
+
+	t.Run("versions", func(t *testing.T) {
+		from := snapshottest.New(t, "1.0.0", `INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (1, 'Alice', '["alice"]', '`+date+`')`)
+		d, err := diff.Compare(context.Background(), from, snapshottest.New(t, snapshot.Version, rows...))
+		if err != nil {
+			t.Fatalf("Compare: %v", err)
+		}
+		if d.From.Version != "1.0.0" || d.To.Version != snapshot.Version {
+			t.Errorf("versions = %s, %s", d.From.Version, d.To.Version)
+		}
+		if len(d.Createurs.Modified) != 0 || len(d.Createurs.Added) != 1 || d.Createurs.Added[0].Nom != "Bob" {
+			t.Errorf("createurs = %+v, want Bob added, Alice unchanged", d.Createurs)
+		}
+		if len(d.Contenus.Added) != 1 || !reflect.DeepEqual(d.Contenus.Added[0], diff.Contenu{ID: 1, URL: "https://a/1", DateAjout: date, IDCreateur: 1, Createur: "Alice"}) {
+			t.Errorf("contenus added = %+v", d.Contenus.Added)
+		}
+	})
+}
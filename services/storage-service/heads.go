@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"storage-service/backups"
+	"storage-service/storage"
+)
+
+// userLocks serializes the uploads of each user on the instance, before they
+// wait for the lease of the user (see lockUser).
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[string]*userLock
+}
+
+type userLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// lock locks uuid and returns the function unlocking it.
+func (l *userLocks) lock(uuid string) (unlock func()) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*userLock)
+	}
+	ul := l.locks[uuid]
+	if ul == nil {
+		ul = &userLock{}
+		l.locks[uuid] = ul
+	}
+	ul.waiters++
+	l.mu.Unlock()
+
+	ul.Lock()
+	return func() {
+		ul.Unlock()
+		l.mu.Lock()
+		if ul.waiters--; ul.waiters == 0 {
+			delete(l.locks, uuid)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// lockUser serializes the writes of a user, so that two uploads cannot both
+// pass the check against the head: on the instance with s.locks, then across
+// the instances with the lease of backups.Stores.Lock.
+func (s *server) lockUser(ctx context.Context, uuid string) (unlock func(), err error) {
+	unlockLocal := s.locks.lock(uuid)
+	unlockLease, err := s.stores.Lock(ctx, uuid)
+	if err != nil {
+		unlockLocal()
+		return nil, err
+	}
+	return func() {
+		unlockLease()
+		unlockLocal()
+	}, nil
+}
+
+// head returns the newest backup of a user with its Metadata when known, or
+// nil when they have no backup, and the number of backups of the user.
+func (s *server) head(ctx context.Context, uuid string) (*backups.Backup, int, error) {
+	list, err := s.stores.List(ctx, uuid)
+	if err != nil || len(list) == 0 {
+		return nil, 0, err
+	}
+	head := list[0]
+	obj, err := s.stores.Store(head.Location).Stat(ctx, head.Name().Key())
+	if errors.Is(err, storage.ErrNotFound) {
+		// Deleted by its user meanwhile: the hash is only informative
+		return &head, len(list), nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	head.SHA256, head.UploadedSHA256, head.Version = obj.SHA256, obj.UploadedSHA256, obj.Version
+	return &head, len(list), nil
+}
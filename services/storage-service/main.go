@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"shared/dbservicepb"
+	"shared/jwtauth"
+	"storage-service/backups"
+	"storage-service/retention"
+	"storage-service/storage"
+	"storage-service/uploads"
+)
+
+// defaultMaxBackupSize bounds the size of an uploaded backup.
+const defaultMaxBackupSize = 64 << 20
+
+// defaultPartSize is the size of the parts of a resumable upload.
+const defaultPartSize = 8 << 20
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "retention":
+			runRetention(os.Args[2:])
+			return
+		case "upgrade":
+			runUpgrade(os.Args[2:])
+			return
+		}
+	}
+
+	// 1) Stockage des sauvegardes : R2 par défaut, ou disque local (STORAGE_BACKEND=local).
+	// main reçoit les uploads, backup les anciennes versions (voir package retention)
+	stores, err := storesFromEnv()
+	if err != nil {
+		log.Fatalf("failed initializing storage: %v", err)
+	}
+
+	maxSize := int64(defaultMaxBackupSize)
+	if v := os.Getenv("MAX_BACKUP_SIZE"); v != "" {
+		if maxSize, err = strconv.ParseInt(v, 10, 64); err != nil || maxSize <= 0 {
+			log.Fatalf("invalid MAX_BACKUP_SIZE %q", v)
+		}
+	}
+
+	// 2) Client gRPC de db-service : l'UUID public de l'utilisateur nomme ses sauvegardes
+	db, err := dbservicepb.NewClient(os.Getenv("DB_SERVICE_GRPC_ADDR"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed connecting to db-service: %v", err)
+	}
+	defer db.Close()
+
+	// 3) Jetons de session Clerk vérifiés localement, comme dans db-service
+	verifier, err := jwtauth.NewVerifier(context.Background(), jwtauth.Config{
+		JWKSURL: os.Getenv("CLERK_JWKS_URL"),
+		Issuer:  os.Getenv("CLERK_ISSUER"),
+	})
+	if err != nil {
+		log.Fatalf("failed initializing token verifier: %v", err)
+	}
+	defer verifier.Close()
+
+	srv := &server{
+		stores:   stores,
+		users:    db.Users,
+		plans:    db.Plans,
+		maxSize:  maxSize,
+		partSize: defaultPartSize,
+	}
+	// Les envois en plusieurs parties reposent sur le multipart S3 (ou les fichiers locaux)
+	if mp, ok := stores.Main.(storage.Multipart); ok {
+		srv.multipart = mp
+		srv.uploadKey = uploadKeyFromEnv()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		gc := &uploads.GC{Store: stores.Main, Multipart: mp, TTL: durationEnv("UPLOAD_TTL", uploads.DefaultTTL)}
+		go gc.Run(ctx)
+	}
+	app := newApp(srv, jwtauth.Middleware(verifier))
+
+	// 4) Rétention des sauvegardes selon le plan, sur une seule instance :
+	// RETENTION_INTERVAL=0 la désactive sur les autres
+	if interval := durationEnv("RETENTION_INTERVAL", retention.DefaultInterval); interval > 0 {
+		if dir, err := directoryFromEnv(db); err != nil {
+			log.Printf("Backup retention disabled: %v", err)
+		} else {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			job := &retention.Job{Stores: stores, Directory: dir, Interval: interval}
+			go job.Run(ctx)
+		}
+	}
+
+	// 5) Lancement du serveur
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	log.Printf("Starting storage-service on port %s...", port)
+	log.Fatal(app.Listen(":" + port))
+}
+
+// server holds the dependencies of the handlers. The stores are
+// storage.BlobStores so that storage.Local can replace R2.
+type server struct {
+	stores backups.Stores
+	users  dbservicepb.UserServiceClient
+	// plans returns the entitlements of the callers (see syncEntitlements).
+	plans dbservicepb.PlanServiceClient
+	// multipart stores the resumable uploads; nil when the main store does
+	// not support them.
+	multipart storage.Multipart
+	// uploadKey signs the tokens of the resumable uploads.
+	uploadKey []byte
+	locks     userLocks
+
+	maxSize  int64 // maximum size of an uploaded backup, in bytes
+	partSize int64 // size of the parts of a resumable upload
+}
+
+// newApp déclare les routes. auth authentifie l'utilisateur (jwtauth.Middleware
+// en production).
+func newApp(s *server, auth fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{
+		// Marge pour l'enveloppe multipart autour du fichier
+		BodyLimit: int(s.maxSize) + 1<<20,
+	})
+
+	backupGroup := app.Group("/backups", auth)
+	if s.multipart != nil {
+		backupGroup.Post("/uploads", s.initiateUploadHandler)
+		backupGroup.Get("/uploads/:upload_id", s.uploadStatusHandler)
+		backupGroup.Put("/uploads/:upload_id/parts/:number", s.uploadPartHandler)
+		backupGroup.Post("/uploads/:upload_id/complete", s.completeUploadHandler)
+		backupGroup.Delete("/uploads/:upload_id", s.abortUploadHandler)
+	}
+	backupGroup.Post("/", s.uploadHandler)
+	backupGroup.Post("/merge", s.mergeHandler)
+	backupGroup.Get("/", s.listHandler)
+	backupGroup.Get("/latest", s.latestHandler)
+	backupGroup.Get("/diff", s.diffHandler)
+	backupGroup.Get("/:filename", s.downloadHandler)
+	backupGroup.Delete("/:filename", s.deleteHandler)
+
+	return app
+}
+
+// uploadKeyFromEnv returns the key signing the upload tokens,
+// UPLOAD_TOKEN_SECRET. Without it, a random key only lets this instance
+// resume its uploads, until it restarts.
+func uploadKeyFromEnv() []byte {
+	if secret := os.Getenv("UPLOAD_TOKEN_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	log.Printf("UPLOAD_TOKEN_SECRET is not set: upload tokens are only valid on this instance")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatalf("failed generating upload token key: %v", err)
+	}
+	return key
+}
+
+// durationEnv parses a Go duration (e.g. "36h") from the environment.
+func durationEnv(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Fatalf("invalid %s: %q", name, v)
+	}
+	return d
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"storage-service/backups"
+	"storage-service/merge"
+	"storage-service/storage"
+)
+
+// mergeHandler gère POST /backups/merge : fusionne les sauvegardes ours et
+// theirs, dérivées de la sauvegarde base ({"base", "ours", "theirs"}, des noms
+// de fichiers), et enregistre le résultat comme une nouvelle sauvegarde, avec
+// le rapport de fusion (voir package merge). Avec "dry_run", seul le rapport
+// est renvoyé.
+func (s *server) mergeHandler(c *fiber.Ctx) error {
+	var body struct {
+		Base   string `json:"base"`
+		Ours   string `json:"ours"`
+		Theirs string `json:"theirs"`
+		DryRun bool   `json:"dry_run"`
+	}
+	if err := c.BodyParser(&body); err != nil || body.Base == "" || body.Ours == "" || body.Theirs == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "base, ours and theirs are required"})
+	}
+
+	u, ours, ok := s.ownedName(c, body.Ours)
+	if !ok {
+		return nil
+	}
+	filenames := [3]string{body.Base, body.Ours, body.Theirs}
+	names := [3]backups.Name{1: ours}
+	for _, i := range []int{0, 2} {
+		name, err := backups.ParseName(filenames[i])
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid backup filename, expected leakr_db_{uuid}_{date}_it{iteration}.sqlite"})
+		}
+		if name.UUID != ours.UUID {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Backup belongs to another user"})
+		}
+		names[i] = name
+	}
+
+	ctx := c.UserContext()
+	var paths [3]string
+	for i, name := range names {
+		path, err := s.fetchBackup(ctx, name)
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Backup not found", "filename": name.Filename})
+		}
+		if err != nil {
+			log.Printf("Reading backup %s failed: %v", name.Key(), err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve backup"})
+		}
+		defer os.Remove(path)
+		paths[i] = path
+	}
+
+	f, err := os.CreateTemp("", "leakr-merge-*.sqlite")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to merge backups"})
+	}
+	f.Close()
+	out := f.Name()
+	defer os.Remove(out)
+	report, err := merge.Merge(ctx, paths[0], paths[1], paths[2], out)
+	if err != nil {
+		log.Printf("Merging backups of %s failed: %v", ours.UUID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to merge backups"})
+	}
+	if body.DryRun {
+		return c.JSON(fiber.Map{"report": report})
+	}
+
+	// Nommée comme par exportDatabaseData, d'après la ligne version fusionnée
+	date := strings.NewReplacer(":", "-", ".", "-").Replace(report.DateMaj)
+	name, err := backups.ParseName(fmt.Sprintf("leakr_db_%s_%s_it%d.sqlite", ours.UUID, date, report.Iterations))
+	if err != nil {
+		log.Printf("Naming merged backup of %s failed: %v", ours.UUID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to merge backups"})
+	}
+	b, status, ok := s.saveBackup(c, u, name, out, backups.Precondition{})
+	if !ok {
+		return nil
+	}
+	return c.Status(status).JSON(fiber.Map{"backup": b, "report": report})
+}
@@ -0,0 +1,699 @@
+// Package merge merges two copies of the extension's database that diverged
+// from a common ancestor, e.g. the backups of two devices of the same user.
+//
+// The merge is three-way, row by row. The extension's tables (see
+// createSchema in extension/src/lib/dbUtils.ts) have AUTOINCREMENT ids,
+// never reused: a row of ours or theirs whose id is in the ancestor is that
+// row, possibly modified, and a row of the ancestor missing from one side
+// was deleted there. Rows added on both sides are paired by natural key:
+// nom for createurs and plateformes, creator and url for contenus, creator,
+// platform and lien for profils_plateforme.
+//
+// A field changed on one side takes that side's value. Changed on both
+// sides to different values, ours wins and a Conflict is reported. Aliases
+// merge as sets, and a flag (favori, verifie) set on either side of a row
+// added on both is kept. A row deleted on one side and modified on the
+// other is kept and reported, as is a deleted row still referenced by a
+// kept one.
+//
+// createurs.nom and plateformes.nom are unique: creators (or platforms)
+// that end up with the same name are combined, and the rows referencing
+// them remapped. Creators with the same aliases cannot be told apart and
+// are reported (see KindDuplicateAliases).
+//
+// The merged database is a copy of ours, keeping its schema and version,
+// with the merged rows and settings and version.iterations one past the
+// highest of both sides.
+package merge
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"storage-service/snapshot"
+)
+
+// Kinds of a Conflict.
+const (
+	// KindEdit: a field was changed on both sides; ours was kept.
+	KindEdit = "edit"
+	// KindEditDelete: a row was deleted on one side but modified, or still
+	// referenced, on the other; it was kept.
+	KindEditDelete = "edit_delete"
+	// KindDuplicateAliases: creators have the same aliases, which the unique
+	// index on createurs.aliases forbids.
+	KindDuplicateAliases = "duplicate_aliases"
+	// KindDanglingReference: a row of one side referenced a row missing from
+	// it; it was dropped.
+	KindDanglingReference = "dangling_reference"
+)
+
+// Conflict is a difference between both sides the merge could not
+// reconcile, and what it did instead.
+type Conflict struct {
+	Kind  string `json:"kind"`
+	Table string `json:"table"`
+	// ID is the id of the row in the merged database, zero when dropped.
+	ID     int64  `json:"id,omitempty"`
+	Field  string `json:"field,omitempty"`
+	Ours   any    `json:"ours,omitempty"`
+	Theirs any    `json:"theirs,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// Report describes the merged database.
+type Report struct {
+	Version    string `json:"version"`
+	Iterations int64  `json:"iterations"`
+	DateMaj    string `json:"date_maj"`
+
+	// Row counts of the merged database.
+	Createurs   int `json:"createurs"`
+	Contenus    int `json:"contenus"`
+	Plateformes int `json:"plateformes"`
+	Profils     int `json:"profils_plateforme"`
+	// Combined is the number of creators and platforms combined with
+	// another of the same name.
+	Combined int `json:"combined"`
+
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// Sides of a merge, the index of a snapshot.
+const (
+	base = iota
+	ours
+	theirs
+)
+
+var sideNames = [3]string{"base", "ours", "theirs"}
+
+// Merge merges the databases at ours and theirs, both derived from the
+// database at base, into a new database at out.
+func Merge(ctx context.Context, basePath, oursPath, theirsPath, out string) (*Report, error) {
+	var snaps [3]*snapshot.Snapshot
+	for i, path := range [3]string{basePath, oursPath, theirsPath} {
+		s, err := snapshot.Read(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("merge: reading %s: %w", sideNames[i], err)
+		}
+		snaps[i] = s
+	}
+
+	m := &merger{snaps: snaps}
+	m.merge()
+
+	r := &Report{
+		Version:    snaps[ours].Version,
+		Iterations: max(snaps[ours].Iterations, snaps[theirs].Iterations) + 1,
+		DateMaj:    time.Now().UTC().Format(time.DateTime),
+		Combined:   m.combined,
+	}
+	if err := m.write(ctx, oursPath, out, r); err != nil {
+		return nil, fmt.Errorf("merge: writing %s: %w", out, err)
+	}
+
+	r.Createurs = m.createurs.count()
+	r.Contenus = m.contenus.count()
+	r.Plateformes = m.plateformes.count()
+	r.Profils = m.profils.count()
+	r.Conflicts = make([]Conflict, 0, len(m.conflicts))
+	for _, c := range m.conflicts {
+		if c.at != nil {
+			c.ID = *c.at
+		}
+		c.Ours, c.Theirs = reported(c.Ours), reported(c.Theirs)
+		r.Conflicts = append(r.Conflicts, c.Conflict)
+	}
+	return r, nil
+}
+
+type (
+	createur   = snapshot.Createur
+	plateforme = snapshot.Plateforme
+)
+
+// contenu and profil are the rows of contenus and profils_plateforme, with
+// the nodes of the rows they reference.
+type contenu struct {
+	URL       string
+	Tabname   sql.NullString
+	DateAjout string
+	Createur  *node[createur]
+	Favori    bool
+}
+
+type profil struct {
+	Lien       string
+	Createur   *node[createur]
+	Plateforme *node[plateforme]
+}
+
+// node is a row of the merged database with its versions in each snapshot.
+type node[R comparable] struct {
+	rows [3]*R
+	ids  [3]int64
+	// row is the merged row, when kept.
+	row  R
+	kept bool
+	// id is the id of the row in the merged database, once assigned.
+	id int64
+	// into is the node this one was combined into.
+	into *node[R]
+}
+
+// mergedID returns the id of the row of n in the merged database.
+func (n *node[R]) mergedID() int64 {
+	return n.resolved().id
+}
+
+// reported returns the value of a conflicting field as in the database: a
+// reference as the id of the row referenced.
+func reported(v any) any {
+	switch v := v.(type) {
+	case interface{ mergedID() int64 }:
+		return v.mergedID()
+	case sql.NullString:
+		if !v.Valid {
+			return nil
+		}
+		return v.String
+	default:
+		return v
+	}
+}
+
+// resolved returns the node holding the row of n.
+func (n *node[R]) resolved() *node[R] {
+	for n.into != nil {
+		n = n.into
+	}
+	return n
+}
+
+// table holds the nodes of a table, those of the ancestor first by id.
+type table[R comparable] struct {
+	name  string
+	nodes []*node[R]
+	// byID maps the ids of each snapshot to their node.
+	byID [3]map[int64]*node[R]
+}
+
+func (t *table[R]) count() int {
+	n := 0
+	for _, nd := range t.nodes {
+		if nd.kept {
+			n++
+		}
+	}
+	return n
+}
+
+// sorted returns the kept nodes by id.
+func (t *table[R]) sorted() []*node[R] {
+	var nodes []*node[R]
+	for _, n := range t.nodes {
+		if n.kept {
+			nodes = append(nodes, n)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b *node[R]) int { return cmp.Compare(a.id, b.id) })
+	return nodes
+}
+
+// match pairs the rows of the snapshots: by id for the rows of the
+// ancestor, by key for the rows added on both sides.
+func match[R, K comparable](name string, sides [3]map[int64]R, key func(R) K) *table[R] {
+	t := &table[R]{name: name}
+	for i := range t.byID {
+		t.byID[i] = map[int64]*node[R]{}
+	}
+	add := func(n *node[R], side int, id int64) {
+		r := sides[side][id]
+		n.rows[side], n.ids[side] = &r, id
+		t.byID[side][id] = n
+	}
+
+	for _, id := range sortedIDs(sides[base]) {
+		n := &node[R]{}
+		for side := range sides {
+			if _, ok := sides[side][id]; ok {
+				add(n, side, id)
+			}
+		}
+		t.nodes = append(t.nodes, n)
+	}
+
+	// Rows added on ours, waiting for their counterpart on theirs
+	pending := map[K][]*node[R]{}
+	for _, side := range []int{ours, theirs} {
+		for _, id := range sortedIDs(sides[side]) {
+			if _, ok := sides[base][id]; ok {
+				continue
+			}
+			k := key(sides[side][id])
+			var n *node[R]
+			if side == theirs && len(pending[k]) > 0 {
+				n, pending[k] = pending[k][0], pending[k][1:]
+			} else {
+				n = &node[R]{}
+				t.nodes = append(t.nodes, n)
+				if side == ours {
+					pending[k] = append(pending[k], n)
+				}
+			}
+			add(n, side, id)
+		}
+	}
+	return t
+}
+
+func sortedIDs[R any](rows map[int64]R) []int64 {
+	ids := make([]int64, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// fieldConflict is a field changed to different values on both sides.
+type fieldConflict struct {
+	field        string
+	ours, theirs any
+}
+
+// conflict is a Conflict with the node it is about, whose id is only known
+// once the merge is done.
+type conflict struct {
+	Conflict
+	at *int64
+}
+
+type merger struct {
+	snaps [3]*snapshot.Snapshot
+
+	createurs   *table[createur]
+	plateformes *table[plateforme]
+	contenus    *table[contenu]
+	profils     *table[profil]
+
+	shareCollection bool
+	combined        int
+	conflicts       []*conflict
+}
+
+func (m *merger) conflict(c Conflict, at *int64) {
+	m.conflicts = append(m.conflicts, &conflict{Conflict: c, at: at})
+}
+
+func (m *merger) merge() {
+	var createurs [3]map[int64]createur
+	var plateformes [3]map[int64]plateforme
+	for i, s := range m.snaps {
+		createurs[i], plateformes[i] = s.Createurs, s.Plateformes
+	}
+	m.createurs = match("createurs", createurs, func(c createur) string { return c.Nom })
+	m.plateformes = match("plateformes", plateformes, func(p plateforme) string { return p.Nom })
+	resolve(m, m.createurs, mergeCreateur)
+	resolve(m, m.plateformes, mergePlateforme)
+
+	// The dependent rows are paired on the nodes of the rows they reference
+	m.contenus = match("contenus", m.contenuSides(), func(c contenu) contenuKey { return contenuKey{c.Createur, c.URL} })
+	m.profils = match("profils_plateforme", m.profilSides(), func(p profil) profil { return p })
+	resolve(m, m.contenus, mergeContenu)
+	resolve(m, m.profils, mergeProfil)
+
+	// A row kept on one side keeps the rows it references
+	for _, n := range m.contenus.nodes {
+		if n.kept {
+			revive(m, m.createurs, n.row.Createur, "contenus")
+		}
+	}
+	for _, n := range m.profils.nodes {
+		if n.kept {
+			revive(m, m.createurs, n.row.Createur, "profils_plateforme")
+			revive(m, m.plateformes, n.row.Plateforme, "profils_plateforme")
+		}
+	}
+
+	// Names are unique: combine the rows sharing one, then remap references
+	always := func(int) bool { return true }
+	m.combined += fold(m.createurs, func(c createur) string { return c.Nom }, always, combineCreateur)
+	m.combined += fold(m.plateformes, func(p plateforme) string { return p.Nom }, always, func(*plateforme, plateforme) {})
+	for _, n := range m.contenus.nodes {
+		if n.kept {
+			n.row.Createur = n.row.Createur.resolved()
+		}
+	}
+	for _, n := range m.profils.nodes {
+		if n.kept {
+			n.row.Createur = n.row.Createur.resolved()
+			n.row.Plateforme = n.row.Plateforme.resolved()
+		}
+	}
+	// Rows added on a side may now duplicate others of the same creator
+	fold(m.contenus, func(c contenu) contenuKey { return contenuKey{c.Createur, c.URL} }, m.contenus.added, combineContenu)
+	fold(m.profils, func(p profil) profil { return p }, m.profils.added, func(*profil, profil) {})
+
+	share := func(s snapshot.Snapshot) bool { return s.ShareCollection }
+	m.shareCollection = flag(m.snaps[base], *m.snaps[ours], *m.snaps[theirs], share)
+
+	assign(m.createurs, m.snaps)
+	assign(m.plateformes, m.snaps)
+	assign(m.contenus, m.snaps)
+	assign(m.profils, m.snaps)
+}
+
+type contenuKey struct {
+	createur *node[createur]
+	url      string
+}
+
+// added reports whether the i-th node of t was added since the ancestor.
+func (t *table[R]) added(i int) bool {
+	return t.nodes[i].rows[base] == nil
+}
+
+// contenuSides returns the contenus of each snapshot, referencing the
+// creator nodes; rows referencing a missing creator are dropped.
+func (m *merger) contenuSides() [3]map[int64]contenu {
+	var sides [3]map[int64]contenu
+	for i, s := range m.snaps {
+		sides[i] = make(map[int64]contenu, len(s.Contenus))
+		for _, id := range sortedIDs(s.Contenus) {
+			r := s.Contenus[id]
+			c := m.createurs.byID[i][r.Createur]
+			if c == nil {
+				m.dangling(i, "contenus", id, "createurs", r.Createur)
+				continue
+			}
+			sides[i][id] = contenu{URL: r.URL, Tabname: r.Tabname, DateAjout: r.DateAjout, Createur: c, Favori: r.Favori}
+		}
+	}
+	return sides
+}
+
+// profilSides is contenuSides for profils_plateforme.
+func (m *merger) profilSides() [3]map[int64]profil {
+	var sides [3]map[int64]profil
+	for i, s := range m.snaps {
+		sides[i] = make(map[int64]profil, len(s.Profils))
+		for _, id := range sortedIDs(s.Profils) {
+			r := s.Profils[id]
+			c := m.createurs.byID[i][r.Createur]
+			if c == nil {
+				m.dangling(i, "profils_plateforme", id, "createurs", r.Createur)
+				continue
+			}
+			p := m.plateformes.byID[i][r.Plateforme]
+			if p == nil {
+				m.dangling(i, "profils_plateforme", id, "plateformes", r.Plateforme)
+				continue
+			}
+			sides[i][id] = profil{Lien: r.Lien, Createur: c, Plateforme: p}
+		}
+	}
+	return sides
+}
+
+func (m *merger) dangling(side int, table string, id int64, target string, targetID int64) {
+	// The ancestor's rows only matter through the sides
+	if side == base {
+		return
+	}
+	m.conflict(Conflict{
+		Kind:   KindDanglingReference,
+		Table:  table,
+		Detail: fmt.Sprintf("row %d of %s references %s %d, which does not exist; dropped", id, sideNames[side], target, targetID),
+	}, nil)
+}
+
+// resolve merges the rows of every node of t with mergeRow.
+func resolve[R comparable](m *merger, t *table[R], mergeRow func(b *R, o, t R) (R, []fieldConflict)) {
+	for _, n := range t.nodes {
+		b, o, th := n.rows[base], n.rows[ours], n.rows[theirs]
+		switch {
+		case o != nil && th != nil:
+			row, conflicts := mergeRow(b, *o, *th)
+			n.row, n.kept = row, true
+			for _, fc := range conflicts {
+				m.conflict(Conflict{
+					Kind:   KindEdit,
+					Table:  t.name,
+					Field:  fc.field,
+					Ours:   fc.ours,
+					Theirs: fc.theirs,
+					Detail: "changed on both sides; ours kept",
+				}, &n.id)
+			}
+		case o == nil && th == nil:
+			// Deleted on both sides
+		case b == nil:
+			n.row, n.kept = *first(o, th), true
+		default:
+			// Deleted on one side: the other side's changes win over the deletion
+			if kept := first(o, th); *kept != *b {
+				n.row, n.kept = *kept, true
+				m.conflict(Conflict{
+					Kind:   KindEditDelete,
+					Table:  t.name,
+					Detail: fmt.Sprintf("deleted by %s, modified by %s; kept", deleter(n.rows), keeper(n.rows)),
+				}, &n.id)
+			}
+		}
+	}
+}
+
+// revive keeps a row deleted on one side but referenced by a kept row of
+// the table by.
+func revive[R comparable](m *merger, t *table[R], n *node[R], by string) {
+	if n.kept {
+		return
+	}
+	n.row, n.kept = *first(n.rows[ours], n.rows[theirs], n.rows[base]), true
+	m.conflict(Conflict{
+		Kind:   KindEditDelete,
+		Table:  t.name,
+		Detail: fmt.Sprintf("deleted by %s, still referenced by %s of %s; kept", deleter(n.rows), by, keeper(n.rows)),
+	}, &n.id)
+}
+
+// fold combines the kept nodes of t with the same key into the first one,
+// for the nodes (by index) that can be combined. It returns the number of
+// nodes combined.
+func fold[R, K comparable](t *table[R], key func(R) K, can func(int) bool, combine func(into *R, from R)) int {
+	seen := map[K]*node[R]{}
+	n := 0
+	for i, nd := range t.nodes {
+		if !nd.kept {
+			continue
+		}
+		k := key(nd.row)
+		into, ok := seen[k]
+		if !ok {
+			seen[k] = nd
+			continue
+		}
+		if !can(i) {
+			continue
+		}
+		combine(&into.row, nd.row)
+		nd.kept, nd.into = false, into
+		n++
+	}
+	return n
+}
+
+// assign gives the kept rows of t their id in the merged database: the
+// ancestor's and those of ours are kept, those of theirs renumbered past
+// the highest id ever used.
+func assign[R comparable](t *table[R], snaps [3]*snapshot.Snapshot) {
+	last := int64(0)
+	for side, s := range snaps {
+		last = max(last, s.Seq[t.name])
+		for id := range t.byID[side] {
+			last = max(last, id)
+		}
+	}
+
+	taken := map[int64]bool{}
+	for _, side := range []int{base, ours} {
+		for _, n := range t.nodes {
+			if n.kept && n.id == 0 && n.rows[side] != nil && !taken[n.ids[side]] {
+				n.id = n.ids[side]
+				taken[n.id] = true
+			}
+		}
+	}
+	for _, n := range t.nodes {
+		if n.kept && n.id == 0 {
+			last++
+			n.id = last
+		}
+	}
+	for _, n := range t.nodes {
+		if n.into != nil {
+			n.id = n.resolved().id
+		}
+	}
+}
+
+// seq returns the sqlite_sequence of t in the merged database.
+func (t *table[R]) seq(snaps [3]*snapshot.Snapshot) int64 {
+	last := int64(0)
+	for _, s := range snaps {
+		last = max(last, s.Seq[t.name])
+	}
+	for _, n := range t.nodes {
+		last = max(last, n.id)
+	}
+	return last
+}
+
+// first returns the first non-nil row.
+func first[R any](rows ...*R) *R {
+	for _, r := range rows {
+		if r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+func deleter[R any](rows [3]*R) string {
+	if rows[ours] == nil {
+		return sideNames[ours]
+	}
+	return sideNames[theirs]
+}
+
+func keeper[R any](rows [3]*R) string {
+	if rows[ours] == nil {
+		return sideNames[theirs]
+	}
+	return sideNames[ours]
+}
+
+// field merges a field: the side that changed it wins; when both did, ours
+// wins and the conflict is recorded.
+func field[R any, T comparable](b *R, o, t R, get func(R) T, name string, conflicts *[]fieldConflict) T {
+	vo, vt := get(o), get(t)
+	switch {
+	case vo == vt:
+		return vo
+	case b != nil && get(*b) == vo:
+		return vt
+	case b != nil && get(*b) == vt:
+		return vo
+	}
+	if conflicts != nil {
+		*conflicts = append(*conflicts, fieldConflict{name, vo, vt})
+	}
+	return vo
+}
+
+// flag merges a boolean field, which never conflicts: set when set on
+// either side of a row added on both.
+func flag[R any](b *R, o, t R, get func(R) bool) bool {
+	if b == nil {
+		return get(o) || get(t)
+	}
+	return field(b, o, t, get, "", nil)
+}
+
+// earliest merges date_ajout: the earliest for a row added on both sides.
+func earliest[R any](b *R, o, t R, get func(R) string, conflicts *[]fieldConflict) string {
+	if b == nil {
+		return min(get(o), get(t))
+	}
+	return field(b, o, t, get, "date_ajout", conflicts)
+}
+
+func mergeCreateur(b *createur, o, t createur) (createur, []fieldConflict) {
+	var conflicts []fieldConflict
+	m := createur{
+		Nom:       field(b, o, t, func(c createur) string { return c.Nom }, "nom", &conflicts),
+		DateAjout: earliest(b, o, t, func(c createur) string { return c.DateAjout }, &conflicts),
+		Favori:    flag(b, o, t, func(c createur) bool { return c.Favori }),
+		Verifie:   flag(b, o, t, func(c createur) bool { return c.Verifie }),
+	}
+	aliases := mergeAliases(b, o, t)
+	// A name lost to ours remains searchable as an alias
+	if len(conflicts) > 0 && conflicts[0].field == "nom" && !slices.Contains(aliases, t.Nom) {
+		aliases = append(aliases, t.Nom)
+	}
+	m.Aliases = snapshot.AliasesJSON(aliases)
+	return m, conflicts
+}
+
+// mergeAliases merges the aliases as sets: those of both sides but the ones
+// either side removed from the ancestor's, in the order of ours.
+func mergeAliases(b *createur, o, t createur) []string {
+	oa, ta := snapshot.ParseAliases(o.Aliases), snapshot.ParseAliases(t.Aliases)
+	removed := map[string]bool{}
+	if b != nil {
+		for _, a := range snapshot.ParseAliases(b.Aliases) {
+			if !slices.Contains(oa, a) || !slices.Contains(ta, a) {
+				removed[a] = true
+			}
+		}
+	}
+	var aliases []string
+	for _, a := range append(oa, ta...) {
+		if !removed[a] && !slices.Contains(aliases, a) {
+			aliases = append(aliases, a)
+		}
+	}
+	return aliases
+}
+
+func combineCreateur(into *createur, from createur) {
+	aliases := snapshot.ParseAliases(into.Aliases)
+	for _, a := range snapshot.ParseAliases(from.Aliases) {
+		if !slices.Contains(aliases, a) {
+			aliases = append(aliases, a)
+		}
+	}
+	into.Aliases = snapshot.AliasesJSON(aliases)
+	into.DateAjout = min(into.DateAjout, from.DateAjout)
+	into.Favori = into.Favori || from.Favori
+	into.Verifie = into.Verifie || from.Verifie
+}
+
+func mergePlateforme(b *plateforme, o, t plateforme) (plateforme, []fieldConflict) {
+	var conflicts []fieldConflict
+	return plateforme{Nom: field(b, o, t, func(p plateforme) string { return p.Nom }, "nom", &conflicts)}, conflicts
+}
+
+func mergeContenu(b *contenu, o, t contenu) (contenu, []fieldConflict) {
+	var conflicts []fieldConflict
+	return contenu{
+		URL:       field(b, o, t, func(c contenu) string { return c.URL }, "url", &conflicts),
+		Tabname:   field(b, o, t, func(c contenu) sql.NullString { return c.Tabname }, "tabname", &conflicts),
+		DateAjout: earliest(b, o, t, func(c contenu) string { return c.DateAjout }, &conflicts),
+		Createur:  field(b, o, t, func(c contenu) *node[createur] { return c.Createur }, "id_createur", &conflicts),
+		Favori:    flag(b, o, t, func(c contenu) bool { return c.Favori }),
+	}, conflicts
+}
+
+func combineContenu(into *contenu, from contenu) {
+	into.DateAjout = min(into.DateAjout, from.DateAjout)
+	into.Favori = into.Favori || from.Favori
+	if !into.Tabname.Valid {
+		into.Tabname = from.Tabname
+	}
+}
+
+func mergeProfil(b *profil, o, t profil) (profil, []fieldConflict) {
+	var conflicts []fieldConflict
+	return profil{
+		Lien:       field(b, o, t, func(p profil) string { return p.Lien }, "lien", &conflicts),
+		Createur:   field(b, o, t, func(p profil) *node[createur] { return p.Createur }, "id_createur", &conflicts),
+		Plateforme: field(b, o, t, func(p profil) *node[plateforme] { return p.Plateforme }, "id_plateforme", &conflicts),
+	}, conflicts
+}
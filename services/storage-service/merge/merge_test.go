@@ -0,0 +1,172 @@
+package merge_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"storage-service/merge"
+	"storage-service/snapshot"
+	"storage-service/snapshot/snapshottest"
+)
+
+const date = "2026-01-01"
+
+// base is the common ancestor: Alice and Bob, a content of each, and the
+// YouTube profile of Alice.
+var base = []string{
+	`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (1, 'Alice', '["alice"]', '` + date + `'), (2, 'Bob', '["bob"]', '` + date + `')`,
+	`INSERT INTO plateformes (id, nom) VALUES (1, 'YouTube')`,
+	`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (1, 'https://a/1', '` + date + `', 1), (2, 'https://b/1', '` + date + `', 2)`,
+	`INSERT INTO profils_plateforme (id, lien, id_createur, id_plateforme) VALUES (1, 'https://youtube.com/@alice', 1, 1)`,
+}
+
+func createur(nom, aliases string) snapshot.Createur {
+	return snapshot.Createur{Nom: nom, Aliases: aliases, DateAjout: date}
+}
+
+func contenu(url string, createur int64) snapshot.Contenu {
+	return snapshot.Contenu{URL: url, DateAjout: date, Createur: createur}
+}
+
+func TestMerge(t *testing.T) {
+	favori := func(c snapshot.Contenu) snapshot.Contenu { c.Favori = true; return c }
+	alice, bob := createur("Alice", `["alice"]`), createur("Bob", `["bob"]`)
+	a1, b1 := contenu("https://a/1", 1), contenu("https://b/1", 2)
+
+	tests := []struct {
+		name         string
+		ours, theirs []string
+		createurs    map[int64]snapshot.Createur
+		contenus     map[int64]snapshot.Contenu
+		combined     int
+		conflicts    []string // kind table id field
+	}{
+		{
+			name:      "unchanged",
+			createurs: map[int64]snapshot.Createur{1: alice, 2: bob},
+			contenus:  map[int64]snapshot.Contenu{1: a1, 2: b1},
+		},
+		{
+			name:      "concurrent renames",
+			ours:      []string{`UPDATE createurs SET nom = 'Alicia' WHERE id = 1`},
+			theirs:    []string{`UPDATE createurs SET nom = 'Alice B.' WHERE id = 1`},
+			createurs: map[int64]snapshot.Createur{1: createur("Alicia", `["alice","Alice B."]`), 2: bob},
+			contenus:  map[int64]snapshot.Contenu{1: a1, 2: b1},
+			conflicts: []string{"edit createurs 1 nom"},
+		},
+		{
+			name:      "renamed on one side",
+			ours:      []string{`UPDATE createurs SET nom = 'Robert' WHERE id = 2`},
+			theirs:    []string{`UPDATE createurs SET nom = 'Alicia' WHERE id = 1`},
+			createurs: map[int64]snapshot.Createur{1: createur("Alicia", `["alice"]`), 2: createur("Robert", `["bob"]`)},
+			contenus:  map[int64]snapshot.Contenu{1: a1, 2: b1},
+		},
+		{
+			name:      "alias set merge",
+			ours:      []string{`UPDATE createurs SET aliases = '["alice","ali"]' WHERE id = 1`},
+			theirs:    []string{`UPDATE createurs SET aliases = '["al"]' WHERE id = 1`, `UPDATE createurs SET aliases = '["bob","bobby"]' WHERE id = 2`},
+			createurs: map[int64]snapshot.Createur{1: createur("Alice", `["ali","al"]`), 2: createur("Bob", `["bob","bobby"]`)},
+			contenus:  map[int64]snapshot.Contenu{1: a1, 2: b1},
+		},
+		{
+			name:      "deleted and unchanged",
+			ours:      []string{`DELETE FROM contenus WHERE id = 2`},
+			createurs: map[int64]snapshot.Createur{1: alice, 2: bob},
+			contenus:  map[int64]snapshot.Contenu{1: a1},
+		},
+		{
+			name:      "edit/delete",
+			ours:      []string{`DELETE FROM contenus WHERE id = 1`},
+			theirs:    []string{`UPDATE contenus SET favori = 1 WHERE id = 1`},
+			createurs: map[int64]snapshot.Createur{1: alice, 2: bob},
+			contenus:  map[int64]snapshot.Contenu{1: favori(a1), 2: b1},
+			conflicts: []string{"edit_delete contenus 1 "},
+		},
+		{
+			name: "deleted creator still referenced",
+			ours: []string{
+				`DELETE FROM profils_plateforme WHERE id = 1`,
+				`DELETE FROM contenus WHERE id = 1`,
+				`DELETE FROM createurs WHERE id = 1`,
+			},
+			theirs:    []string{`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (3, 'https://a/2', '` + date + `', 1)`},
+			createurs: map[int64]snapshot.Createur{1: alice, 2: bob},
+			contenus:  map[int64]snapshot.Contenu{2: b1, 4: contenu("https://a/2", 1)},
+			conflicts: []string{"edit_delete createurs 1 "},
+		},
+		{
+			name: "added on both sides",
+			ours: []string{
+				`INSERT INTO createurs (id, nom, aliases, date_ajout, favori) VALUES (3, 'Carol', '["carol"]', '2026-02-01', 1)`,
+				`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (3, 'https://c/1', '` + date + `', 3)`,
+			},
+			theirs: []string{
+				`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (3, 'Dave', '["dave"]', '` + date + `')`,
+				`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (4, 'Carol', '["caro"]', '` + date + `')`,
+				`INSERT INTO contenus (id, url, date_ajout, id_createur) VALUES (3, 'https://c/1', '2026-03-01', 4)`,
+			},
+			createurs: map[int64]snapshot.Createur{
+				1: alice, 2: bob,
+				3: {Nom: "Carol", Aliases: `["carol","caro"]`, DateAjout: date, Favori: true},
+				5: createur("Dave", `["dave"]`),
+			},
+			contenus: map[int64]snapshot.Contenu{1: a1, 2: b1, 3: contenu("https://c/1", 3)},
+		},
+		{
+			name: "name folding with contenu remap",
+			ours: []string{`UPDATE createurs SET nom = 'Carol' WHERE id = 2`},
+			theirs: []string{
+				`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (3, 'Carol', '["carol"]', '` + date + `')`,
+				`INSERT INTO contenus (id, url, date_ajout, id_createur, favori) VALUES (3, 'https://c/1', '` + date + `', 3, 0), (4, 'https://b/1', '` + date + `', 3, 1)`,
+			},
+			createurs: map[int64]snapshot.Createur{1: alice, 2: createur("Carol", `["bob","carol"]`)},
+			contenus:  map[int64]snapshot.Contenu{1: a1, 2: favori(b1), 5: contenu("https://c/1", 2)},
+			combined:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			b := snapshottest.New(t, snapshot.Version, base...)
+			out := filepath.Join(t.TempDir(), "merged.sqlite")
+
+			r, err := merge.Merge(ctx, b, snapshottest.Copy(t, b, tt.ours...), snapshottest.Copy(t, b, tt.theirs...), out)
+			if err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			conflicts := []string{}
+			for _, c := range r.Conflicts {
+				conflicts = append(conflicts, fmt.Sprintf("%s %s %d %s", c.Kind, c.Table, c.ID, c.Field))
+			}
+			if tt.conflicts == nil {
+				tt.conflicts = []string{}
+			}
+			if !reflect.DeepEqual(conflicts, tt.conflicts) {
+				t.Errorf("conflicts = %q, want %q", conflicts, tt.conflicts)
+			}
+			if r.Combined != tt.combined || r.Iterations != 2 || r.Version != snapshot.Version {
+				t.Errorf("report = %+v, want %d combined at iteration 2", r, tt.combined)
+			}
+
+			s, err := snapshot.Read(ctx, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(s.Createurs, tt.createurs) {
+				t.Errorf("createurs = %+v, want %+v", s.Createurs, tt.createurs)
+			}
+			if !reflect.DeepEqual(s.Contenus, tt.contenus) {
+				t.Errorf("contenus = %+v, want %+v", s.Contenus, tt.contenus)
+			}
+			if r.Createurs != len(tt.createurs) || r.Contenus != len(tt.contenus) {
+				t.Errorf("report counts (%d, %d), want (%d, %d)", r.Createurs, r.Contenus, len(tt.createurs), len(tt.contenus))
+			}
+			if s.Iterations != 2 || s.UUID != snapshottest.UUID {
+				t.Errorf("merged version = (%d, %s), want (2, %s)", s.Iterations, s.UUID, snapshottest.UUID)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package merge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"storage-service/snapshot"
+)
+
+// aliasesIndex is the unique index on createurs.aliases created by the
+// migrations, which migration 1.1.2 only recreates without duplicates.
+const aliasesIndex = "idx_createurs_aliases_unique"
+
+// write stores the merged database at out, a copy of the database at src
+// (ours) with the merged rows.
+func (m *merger) write(ctx context.Context, src, out string, r *Report) error {
+	if err := copyFile(src, out); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: out}).EscapedPath())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, t := range []string{"profils_plateforme", "contenus", "createurs", "plateformes"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+t); err != nil {
+			return err
+		}
+	}
+	hadIndex, strict, err := aliasesUnique(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if hadIndex {
+		if _, err := tx.ExecContext(ctx, "DROP INDEX "+aliasesIndex); err != nil {
+			return err
+		}
+	}
+	duplicates := m.duplicateAliases(strict)
+
+	verifie, err := snapshot.HasColumn(ctx, tx, "createurs", "verifie")
+	if err != nil {
+		return err
+	}
+	for _, n := range m.createurs.sorted() {
+		c := n.row
+		if verifie {
+			_, err = tx.ExecContext(ctx, "INSERT INTO createurs (id, nom, aliases, date_ajout, favori, verifie) VALUES (?, ?, ?, ?, ?, ?)",
+				n.id, c.Nom, c.Aliases, c.DateAjout, bit(c.Favori), bit(c.Verifie))
+		} else {
+			_, err = tx.ExecContext(ctx, "INSERT INTO createurs (id, nom, aliases, date_ajout, favori) VALUES (?, ?, ?, ?, ?)",
+				n.id, c.Nom, c.Aliases, c.DateAjout, bit(c.Favori))
+		}
+		if err != nil {
+			return fmt.Errorf("inserting createurs %d: %w", n.id, err)
+		}
+	}
+	for _, n := range m.plateformes.sorted() {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO plateformes (id, nom) VALUES (?, ?)", n.id, n.row.Nom); err != nil {
+			return fmt.Errorf("inserting plateformes %d: %w", n.id, err)
+		}
+	}
+	for _, n := range m.contenus.sorted() {
+		c := n.row
+		_, err := tx.ExecContext(ctx, "INSERT INTO contenus (id, url, tabname, date_ajout, id_createur, favori) VALUES (?, ?, ?, ?, ?, ?)",
+			n.id, c.URL, c.Tabname, c.DateAjout, c.Createur.mergedID(), bit(c.Favori))
+		if err != nil {
+			return fmt.Errorf("inserting contenus %d: %w", n.id, err)
+		}
+	}
+	for _, n := range m.profils.sorted() {
+		p := n.row
+		_, err := tx.ExecContext(ctx, "INSERT INTO profils_plateforme (id, lien, id_createur, id_plateforme) VALUES (?, ?, ?, ?)",
+			n.id, p.Lien, p.Createur.mergedID(), p.Plateforme.mergedID())
+		if err != nil {
+			return fmt.Errorf("inserting profils_plateforme %d: %w", n.id, err)
+		}
+	}
+
+	if hadIndex && !duplicates {
+		if _, err := tx.ExecContext(ctx, "CREATE UNIQUE INDEX "+aliasesIndex+" ON createurs(aliases)"); err != nil {
+			return err
+		}
+	}
+	if err := m.writeSequences(ctx, tx); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE version SET iterations = ?, date_maj = ? WHERE id = 1", r.Iterations, r.DateMaj)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE settings SET uuid = ?, share_collection = ? WHERE id = (SELECT id FROM settings ORDER BY id LIMIT 1)",
+		m.snaps[ours].UUID, bit(m.shareCollection))
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Reclaims the pages of the rows of ours replaced by the merged ones
+	_, err = db.ExecContext(ctx, "VACUUM")
+	return err
+}
+
+// aliasesUnique reports whether createurs has the aliasesIndex, and whether
+// another unique index covers aliases alone (the UNIQUE column constraint
+// of createSchema), which cannot be dropped.
+func aliasesUnique(ctx context.Context, tx *sql.Tx) (hadIndex, strict bool, err error) {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_index_list('createurs') WHERE "unique" = 1`)
+	if err != nil {
+		return false, false, err
+	}
+	var indexes []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return false, false, err
+		}
+		indexes = append(indexes, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, false, err
+	}
+
+	for _, index := range indexes {
+		var columns string
+		err := tx.QueryRowContext(ctx, "SELECT COALESCE(group_concat(name), '') FROM pragma_index_info(?)", index).Scan(&columns)
+		if err != nil {
+			return false, false, err
+		}
+		switch {
+		case index == aliasesIndex:
+			hadIndex = true
+		case columns == "aliases":
+			strict = true
+		}
+	}
+	return hadIndex, strict, nil
+}
+
+// duplicateAliases reports the kept creators with the same aliases as a
+// previous one. When strict, the aliases must stay unique: they get a
+// "_dupN" alias, as in migration 1.1.1. It reports whether duplicates
+// remain in the merged rows.
+func (m *merger) duplicateAliases(strict bool) bool {
+	seen := map[string]int{}
+	remain := false
+	for _, n := range m.createurs.sorted() {
+		aliases := n.row.Aliases
+		seen[aliases]++
+		dup := seen[aliases] - 1
+		if dup == 0 {
+			continue
+		}
+
+		detail := "same aliases as another creator; the unique index on aliases is not recreated"
+		if strict {
+			suffixed := append(snapshot.ParseAliases(aliases), fmt.Sprintf("_dup%d", dup))
+			n.row.Aliases = snapshot.AliasesJSON(suffixed)
+			detail = fmt.Sprintf("same aliases as another creator; %q alias added", suffixed[len(suffixed)-1])
+		} else {
+			remain = true
+		}
+		m.conflict(Conflict{
+			Kind:   KindDuplicateAliases,
+			Table:  "createurs",
+			Field:  "aliases",
+			Ours:   aliases,
+			Detail: detail,
+		}, &n.id)
+	}
+	return remain
+}
+
+// writeSequences carries over the highest sqlite_sequence of the
+// snapshots, so that the ids deleted on any side are never reused.
+func (m *merger) writeSequences(ctx context.Context, tx *sql.Tx) error {
+	if ok, err := snapshot.HasTable(ctx, tx, "sqlite_sequence"); err != nil || !ok {
+		return err
+	}
+	seqs := map[string]int64{
+		m.createurs.name:   m.createurs.seq(m.snaps),
+		m.plateformes.name: m.plateformes.seq(m.snaps),
+		m.contenus.name:    m.contenus.seq(m.snaps),
+		m.profils.name:     m.profils.seq(m.snaps),
+	}
+	for _, name := range snapshot.Sequenced {
+		seq := seqs[name]
+		res, err := tx.ExecContext(ctx, "UPDATE sqlite_sequence SET seq = ? WHERE name = ?", seq, name)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 && seq > 0 {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO sqlite_sequence (name, seq) VALUES (?, ?)", name, seq); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func bit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
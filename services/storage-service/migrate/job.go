@@ -0,0 +1,202 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"storage-service/backups"
+	"storage-service/snapshot"
+	"storage-service/storage"
+	"storage-service/validate"
+)
+
+// ErrChanged is returned when a backup was replaced, moved or deleted while
+// being upgraded: the upgraded copy is not stored.
+var ErrChanged = errors.New("migrate: backup changed during the upgrade")
+
+// Job upgrades the stored backups older than Target. An upgraded backup is
+// stored back under the same key, in the same location, tagged with its new
+// version and its SHA-256 as uploaded, which the preconditions of the next
+// uploads still accept (see storage.Metadata and backups.Check). The Job holds no lock: callers upgrading
+// on behalf of a user hold theirs.
+type Job struct {
+	Stores backups.Stores
+	// DryRun upgrades copies of the backups without storing them back.
+	DryRun bool
+}
+
+// Result reports what a run did.
+type Result struct {
+	// Backups is the number of backups checked.
+	Backups int
+	// Upgraded is the number of backups upgraded.
+	Upgraded int
+	// Failed is the number of backups that cannot be upgraded (see Failure).
+	Failed int
+}
+
+// RunOnce upgrades the backups of every user. A failure on one backup is
+// logged and does not stop the others; the first error other than a
+// *Failure is returned.
+func (j *Job) RunOnce(ctx context.Context) (Result, error) {
+	var res Result
+	uuids, err := j.Stores.Users(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	var firstErr error
+	for _, uuid := range uuids {
+		list, err := j.Stores.List(ctx, uuid)
+		if err != nil {
+			return res, err
+		}
+		for _, b := range list {
+			if err := ctx.Err(); err != nil {
+				return res, err
+			}
+			res.Backups++
+			key := b.Name().Key()
+			from, upgraded, err := j.Backup(ctx, b.Location, b.Name())
+			var failure *Failure
+			switch {
+			case errors.As(err, &failure):
+				log.Printf("Backup upgrade: %s/%s cannot be upgraded: %v", b.Location, key, failure)
+				res.Failed++
+			// Replaced, moved or deleted meanwhile: the next run sees it again
+			case errors.Is(err, ErrChanged), errors.Is(err, storage.ErrNotFound):
+			case err != nil:
+				log.Printf("Backup upgrade failed for %s/%s: %v", b.Location, key, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+			case upgraded && j.DryRun:
+				log.Printf("Backup upgrade (dry run): would upgrade %s/%s from %s to %s", b.Location, key, from, Target)
+				res.Upgraded++
+			case upgraded:
+				res.Upgraded++
+			}
+		}
+	}
+	return res, firstErr
+}
+
+// Backup upgrades the backup name stored in location when it is older than
+// Target. It returns its version before the upgrade and whether it was
+// upgraded. The backup is validated before and after the upgrade (see
+// validate.Backup and Check): a backup that does not pass is left as is and
+// a *Failure is returned.
+func (j *Job) Backup(ctx context.Context, location string, name backups.Name) (string, bool, error) {
+	store := j.Stores.Store(location)
+	key := name.Key()
+	obj, err := store.Stat(ctx, key)
+	if err != nil {
+		return "", false, err
+	}
+	// Tagged by an upload or a previous upgrade: no need to read it
+	if obj.Version != "" && !snapshot.Less(obj.Version, Target) {
+		return obj.Version, false, nil
+	}
+
+	path, err := fetch(ctx, store, key)
+	if err != nil {
+		return "", false, err
+	}
+	defer os.Remove(path)
+
+	want := validate.Expect{UUID: name.UUID, Iteration: name.Iteration}
+	info, err := validate.Backup(ctx, path, want)
+	var rejection *validate.Rejection
+	if errors.As(err, &rejection) {
+		return "", false, fail(ReasonInvalid, "%s: %s", rejection.Reason, rejection.Detail)
+	}
+	if err != nil {
+		return "", false, err
+	}
+	from := info.Version
+	if !snapshot.Less(from, Target) {
+		return from, false, nil
+	}
+
+	if _, err := Upgrade(ctx, path); err != nil {
+		return from, false, err
+	}
+	info, err = validate.Backup(ctx, path, want)
+	if errors.As(err, &rejection) {
+		return from, false, fail(ReasonSchema, "%s: %s", rejection.Reason, rejection.Detail)
+	}
+	if err != nil {
+		return from, false, err
+	}
+	if err := Check(ctx, path); err != nil {
+		return from, false, err
+	}
+	if j.DryRun {
+		return from, true, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return from, false, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return from, false, err
+	}
+
+	// Not atomic: narrows the window in which a newer object is overwritten
+	now, err := store.Stat(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return from, false, ErrChanged
+	}
+	if err != nil {
+		return from, false, err
+	}
+	if now.Size != obj.Size || now.SHA256 != obj.SHA256 || !now.LastModified.Equal(obj.LastModified) {
+		return from, false, ErrChanged
+	}
+	// The clients still know the backup by the SHA-256 it was uploaded with
+	uploaded := obj.UploadedSHA256
+	if uploaded == "" {
+		uploaded = obj.SHA256
+	}
+	meta := storage.Metadata{SHA256: hex.EncodeToString(h.Sum(nil)), UploadedSHA256: uploaded, Version: info.Version}
+	if err := store.Put(ctx, key, f, size, meta); err != nil {
+		return from, false, fmt.Errorf("storing %s: %w", key, err)
+	}
+	return from, true, nil
+}
+
+// fetch copies the object stored under key to a temporary file and returns
+// its path.
+func fetch(ctx context.Context, store storage.BlobStore, key string) (string, error) {
+	r, _, err := store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	dst, err := os.CreateTemp("", "leakr-upgrade-*.sqlite")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(dst, r)
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dst.Name())
+		return "", err
+	}
+	return dst.Name(), nil
+}
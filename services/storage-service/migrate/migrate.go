@@ -0,0 +1,319 @@
+// Package migrate upgrades backups written by older versions of the
+// extension to the current schema, Target, so that they can be restored by
+// any client.
+//
+// Migrations is a port of the migrations of the extension (see migrations
+// in extension/src/lib/dbUtils.ts), applied the same way: in order, in a
+// single transaction, each one setting version_texte. Unlike the extension,
+// the upgrade leaves date_maj and iterations alone: they name the backup
+// (see backups.Name), which keeps its filename once upgraded. Its SHA-256
+// changes, but the one it was uploaded with is kept along.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	_ "modernc.org/sqlite"
+
+	"storage-service/snapshot"
+)
+
+// Target is the version backups are upgraded to, the DB_VERSION of the
+// extension.
+const Target = snapshot.Version
+
+// Migration upgrades a database to the version To.
+type Migration struct {
+	To string
+	Up func(ctx context.Context, tx *sql.Tx) error
+}
+
+// Migrations lists the migrations in order, the last one to Target.
+var Migrations = []Migration{
+	{To: "1.1.0", Up: to110},
+	{To: "1.1.1", Up: to111},
+	{To: "1.1.2", Up: to112},
+}
+
+// Reasons of a Failure.
+const (
+	// ReasonInvalid: the stored backup is not valid (see validate.Backup).
+	ReasonInvalid = "invalid_backup"
+	// ReasonMigration: a migration failed on the data of the backup, e.g. two
+	// creators with the same name.
+	ReasonMigration = "migration_failed"
+	// ReasonSchema: the upgraded backup does not have the schema of Target.
+	ReasonSchema = "schema_mismatch"
+)
+
+// Failure reports why a backup cannot be upgraded.
+type Failure struct {
+	Reason string `json:"reason"`
+	Detail string `json:"detail"`
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("migrate: backup cannot be upgraded (%s): %s", f.Reason, f.Detail)
+}
+
+func fail(reason, format string, args ...any) *Failure {
+	return &Failure{Reason: reason, Detail: fmt.Sprintf(format, args...)}
+}
+
+// aliasesIndex and nomIndex are the unique indexes created by the
+// migrations.
+const (
+	aliasesIndex = "idx_createurs_aliases_unique"
+	nomIndex     = "idx_createurs_nom_unique"
+)
+
+// Upgrade applies the migrations to the database at path, in place, and
+// returns its version before them. A database at Target or later is left
+// untouched. A failed migration rolls them all back and returns a *Failure.
+func Upgrade(ctx context.Context, path string) (string, error) {
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath())
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	var from string
+	if err := db.QueryRowContext(ctx, "SELECT version_texte FROM version WHERE id = 1").Scan(&from); err != nil {
+		return "", fmt.Errorf("reading version: %w", err)
+	}
+	if !snapshot.Less(from, Target) {
+		return from, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	for _, m := range Migrations {
+		if !snapshot.Less(from, m.To) || snapshot.Less(Target, m.To) {
+			continue
+		}
+		if err := m.Up(ctx, tx); err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", fail(ReasonMigration, "migration %s: %v", m.To, err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE version SET version_texte = ? WHERE id = 1", m.To); err != nil {
+			return "", err
+		}
+	}
+	return from, tx.Commit()
+}
+
+// Check verifies that the database at path has the schema of Target: its
+// version, the verifie column, the unique names of the creators and aliases
+// that are JSON arrays. It returns a *Failure when it does not.
+func Check(ctx context.Context, path string) error {
+	// immutable=1: no lock nor journal, the file is never written
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath()+"?mode=ro&immutable=1")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT version_texte FROM version WHERE id = 1").Scan(&version); err != nil {
+		return fmt.Errorf("reading version: %w", err)
+	}
+	if version != Target {
+		return fail(ReasonSchema, "version %s is not %s", version, Target)
+	}
+	if ok, err := snapshot.HasColumn(ctx, db, "createurs", "verifie"); err != nil {
+		return err
+	} else if !ok {
+		return fail(ReasonSchema, "createurs.verifie is missing")
+	}
+
+	var n int
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_index_list('createurs') AS l
+		WHERE l."unique" = 1 AND (SELECT group_concat(name) FROM pragma_index_info(l.name)) = 'nom'`).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fail(ReasonSchema, "createurs.nom is not unique")
+	}
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM createurs
+		WHERE aliases IS NULL OR NOT json_valid(aliases) OR json_type(aliases) <> 'array'`).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fail(ReasonSchema, "%d creator(s) with aliases that are not a JSON array", n)
+	}
+	return nil
+}
+
+// to110 adds createurs.verifie, tells the creators with the same aliases
+// apart with a "_dupN" suffix, then makes nom and aliases unique.
+func to110(ctx context.Context, tx *sql.Tx) error {
+	// The column is in the schema of the databases created since
+	if ok, err := snapshot.HasColumn(ctx, tx, "createurs", "verifie"); err != nil {
+		return err
+	} else if !ok {
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE createurs ADD COLUMN verifie BOOLEAN DEFAULT FALSE"); err != nil {
+			return err
+		}
+	}
+
+	err := eachDuplicate(ctx, tx, func(aliases sql.NullString, i int, id int64) error {
+		// NULL aliases are never duplicates for a unique index
+		if !aliases.Valid {
+			return nil
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE createurs SET aliases = ? WHERE id = ?", fmt.Sprintf("%s_dup%d", aliases.String, i), id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return createIndexes(ctx, tx)
+}
+
+// to111 resets the aliases that are not a JSON array, then adds the
+// "_dupN" suffix of the duplicates as an alias of the array instead.
+//
+// Unlike the extension, the unique index on aliases is dropped and the
+// aliases reset first: two creators with invalid aliases, or one with none,
+// fail the index otherwise, and the migration with it.
+func to111(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, "DROP INDEX IF EXISTS "+aliasesIndex); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "UPDATE createurs SET aliases = '[]' WHERE aliases IS NULL OR TRIM(aliases) = '' OR aliases NOT LIKE '[%'")
+	if err != nil {
+		return err
+	}
+
+	err = eachDuplicate(ctx, tx, func(aliases sql.NullString, i int, id int64) error {
+		dup := fmt.Sprintf(`"_dup%d"`, i)
+		suffixed := "[" + dup + "]"
+		if aliases.String != "[]" {
+			suffixed = aliases.String
+			if strings.HasSuffix(suffixed, "]") {
+				suffixed = strings.TrimSuffix(suffixed, "]") + ", " + dup + "]"
+			}
+		}
+		_, err := tx.ExecContext(ctx, "UPDATE createurs SET aliases = ? WHERE id = ?", suffixed, id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return createIndexes(ctx, tx)
+}
+
+// leadingArray matches the JSON array before a "_dupN" suffix added by
+// to110.
+var leadingArray = regexp.MustCompile(`^(\[.*?\])`)
+
+// to112 drops the "_dupN" aliases, then makes aliases unique again unless
+// duplicates remain.
+func to112(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, "DROP INDEX IF EXISTS "+aliasesIndex); err != nil {
+		return err
+	}
+
+	cleaned := map[int64]string{}
+	rows, err := tx.QueryContext(ctx, "SELECT id, aliases FROM createurs WHERE instr(aliases, '_dup') > 0")
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int64
+		var aliases string
+		if err := rows.Scan(&id, &aliases); err != nil {
+			rows.Close()
+			return err
+		}
+		if !json.Valid([]byte(aliases)) {
+			aliases = leadingArray.FindString(aliases)
+		}
+		cleaned[id] = snapshot.AliasesJSON(snapshot.WithoutDup(snapshot.ParseAliases(aliases)))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for id, aliases := range cleaned {
+		if _, err := tx.ExecContext(ctx, "UPDATE createurs SET aliases = ? WHERE id = ?", aliases, id); err != nil {
+			return err
+		}
+	}
+
+	var duplicates int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM (SELECT 1 FROM createurs GROUP BY aliases HAVING COUNT(*) > 1)").Scan(&duplicates)
+	if err != nil || duplicates > 0 {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "CREATE UNIQUE INDEX IF NOT EXISTS "+aliasesIndex+" ON createurs(aliases)")
+	return err
+}
+
+// eachDuplicate calls fn for the creators with the same aliases as a
+// previous one, by id: i is 1 for the second one, 2 for the third...
+func eachDuplicate(ctx context.Context, tx *sql.Tx, fn func(aliases sql.NullString, i int, id int64) error) error {
+	type duplicate struct {
+		aliases sql.NullString
+		i       int
+		id      int64
+	}
+	var duplicates []duplicate
+	rows, err := tx.QueryContext(ctx, `SELECT aliases, id FROM createurs
+		WHERE aliases IN (SELECT aliases FROM createurs GROUP BY aliases HAVING COUNT(*) > 1)
+		ORDER BY aliases, id`)
+	if err != nil {
+		return err
+	}
+	var prev duplicate
+	for rows.Next() {
+		var d duplicate
+		if err := rows.Scan(&d.aliases, &d.id); err != nil {
+			rows.Close()
+			return err
+		}
+		if prev.id != 0 && d.aliases == prev.aliases {
+			d.i = prev.i + 1
+			duplicates = append(duplicates, d)
+		}
+		prev = d
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, d := range duplicates {
+		if err := fn(d.aliases, d.i, d.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createIndexes(ctx context.Context, tx *sql.Tx) error {
+	for _, stmt := range []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS " + nomIndex + " ON createurs(nom)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS " + aliasesIndex + " ON createurs(aliases)",
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
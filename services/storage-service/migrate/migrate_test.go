@@ -0,0 +1,206 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"storage-service/snapshot/snapshottest"
+)
+
+// createurs inserts creators c1, c2... with the given aliases.
+func createurs(aliases ...string) []string {
+	stmts := make([]string, len(aliases))
+	for i, a := range aliases {
+		stmts[i] = fmt.Sprintf("INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (%d, 'c%[1]d', '%s', '2026-01-01')",
+			i+1, strings.ReplaceAll(a, "'", "''"))
+	}
+	return stmts
+}
+
+// aliases returns the aliases of the creators by id, and whether the
+// unique index on aliases exists.
+func aliases(t *testing.T, path string) ([]string, bool) {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath()+"?mode=ro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT aliases FROM createurs ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var list []string
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			t.Fatal(err)
+		}
+		list = append(list, a)
+	}
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = ?", aliasesIndex).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return list, n > 0
+}
+
+// TestMigrations checks the aliases each migration leaves, as the ones of
+// the extension.
+func TestMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		aliases []string
+		to      string
+		want    []string
+		index   bool // the unique index on aliases is created
+	}{
+		{
+			name:    "1.1.0 suffixes duplicates",
+			version: "1.0.0",
+			aliases: []string{`["x"]`, `["x"]`, `["y"]`, `["x"]`, `invalid`},
+			to:      "1.1.0",
+			want:    []string{`["x"]`, `["x"]_dup1`, `["y"]`, `["x"]_dup2`, `invalid`},
+			index:   true,
+		},
+		{
+			name:    "1.1.1 resets invalid aliases",
+			version: "1.0.0",
+			aliases: []string{`["x"]`, `["x"]`, `["y"]`, `["x"]`, `invalid`},
+			to:      "1.1.1",
+			want:    []string{`["x"]`, `["x"]_dup1`, `["y"]`, `["x"]_dup2`, `[]`},
+			index:   true,
+		},
+		{
+			name:    "1.1.2 strips suffixes",
+			version: "1.0.0",
+			aliases: []string{`["x"]`, `["x","z"]`, `["y"]`, `["x","z"]`, `invalid`},
+			to:      "1.1.2",
+			want:    []string{`["x"]`, `["x","z"]`, `["y"]`, `["x","z"]`, `[]`},
+		},
+		{
+			// The extension suffixes then resets, which fails the index
+			name:    "1.1.1 suffixes reset aliases",
+			version: "1.1.0",
+			aliases: []string{`[]`, ``, `invalid`, `["a"]`, `["a"]_dup1`},
+			to:      "1.1.1",
+			want:    []string{`[]`, `["_dup1"]`, `["_dup2"]`, `["a"]`, `["a"]_dup1`},
+			index:   true,
+		},
+		{
+			name:    "1.1.2 keeps duplicates without index",
+			version: "1.1.0",
+			aliases: []string{`[]`, ``, `invalid`, `["a"]`, `["a"]_dup1`},
+			to:      "1.1.2",
+			want:    []string{`[]`, `[]`, `[]`, `["a"]`, `["a"]`},
+		},
+		{
+			name:    "1.1.2 strips the _dup aliases",
+			version: "1.1.1",
+			aliases: []string{`["a"]`, `["a", "_dup1"]`, `["b", "c"]`, `["_dup1"]`},
+			to:      "1.1.2",
+			want:    []string{`["a"]`, `["a"]`, `["b", "c"]`, `[]`},
+		},
+		{
+			name:    "1.1.2 unique without suffixes",
+			version: "1.1.1",
+			aliases: []string{`["a"]`, `["b", "_dup1"]`, `["_dup2", "c"]`},
+			to:      "1.1.2",
+			want:    []string{`["a"]`, `["b"]`, `["c"]`},
+			index:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			all := Migrations
+			t.Cleanup(func() { Migrations = all })
+			for i, m := range all {
+				if m.To == tt.to {
+					Migrations = all[:i+1]
+				}
+			}
+
+			path := snapshottest.New(t, tt.version, createurs(tt.aliases...)...)
+			from, err := Upgrade(context.Background(), path)
+			if err != nil {
+				t.Fatalf("Upgrade: %v", err)
+			}
+			if from != tt.version {
+				t.Errorf("Upgrade = %s, want %s", from, tt.version)
+			}
+			got, index := aliases(t, path)
+			if !reflect.DeepEqual(got, tt.want) || index != tt.index {
+				t.Errorf("aliases = %q (index %v), want %q (index %v)", got, index, tt.want, tt.index)
+			}
+		})
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	ctx := context.Background()
+	path := snapshottest.New(t, "1.0.0", createurs(`["x"]`, `["x"]`, `bad`)...)
+	if err := Check(ctx, path); !isFailure(err, ReasonSchema) {
+		t.Errorf("Check before Upgrade = %v, want %s", err, ReasonSchema)
+	}
+	if _, err := Upgrade(ctx, path); err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if err := Check(ctx, path); err != nil {
+		t.Errorf("Check: %v", err)
+	}
+
+	// Idempotent: a backup at Target is left untouched
+	for _, path := range []string{path, snapshottest.New(t, Target, createurs(`["x"]`, `[]`)...)} {
+		before, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, err := Upgrade(ctx, path)
+		if err != nil || from != Target {
+			t.Errorf("Upgrade = (%s, %v), want %s", from, err, Target)
+		}
+		after, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(before, after) {
+			t.Errorf("Upgrade of a %s backup changed it", Target)
+		}
+		if err := Check(ctx, path); err != nil {
+			t.Errorf("Check: %v", err)
+		}
+	}
+}
+
+func TestUpgradeFailure(t *testing.T) {
+	ctx := context.Background()
+	// Two creators with the same name fail the unique index of 1.1.0
+	path := snapshottest.New(t, "1.0.0",
+		"INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (1, 'a', '[\"a\"]', '2026-01-01'), (2, 'a', '[\"b\"]', '2026-01-01')")
+	if _, err := Upgrade(ctx, path); !isFailure(err, ReasonMigration) {
+		t.Fatalf("Upgrade = %v, want %s", err, ReasonMigration)
+	}
+	// Rolled back
+	got, index := aliases(t, path)
+	if !reflect.DeepEqual(got, []string{`["a"]`, `["b"]`}) || index {
+		t.Errorf("aliases = %q (index %v), want them untouched", got, index)
+	}
+	if err := Check(ctx, path); !isFailure(err, ReasonSchema) {
+		t.Errorf("Check = %v, want %s", err, ReasonSchema)
+	}
+}
+
+func isFailure(err error, reason string) bool {
+	var f *Failure
+	return errors.As(err, &f) && f.Reason == reason
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"shared/dbservicepb"
+	"storage-service/backups"
+	"storage-service/storage"
+	"storage-service/uploads"
+	"storage-service/validate"
+)
+
+// uploadStatus describes a resumable upload to the client.
+type uploadStatus struct {
+	UploadID string `json:"upload_id"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	PartSize int64  `json:"part_size"`
+	Parts    int    `json:"parts"`
+	// Missing lists the numbers of the parts still to upload.
+	Missing []int `json:"missing"`
+}
+
+// initiateUploadHandler gère POST /backups/uploads : démarre l'envoi en
+// plusieurs parties d'une sauvegarde ({"filename", "size"}, avec la
+// précondition de POST /backups, vérifiée à la fin de l'envoi).
+func (s *server) initiateUploadHandler(c *fiber.Ctx) error {
+	var body struct {
+		Filename string `json:"filename"`
+		Size     int64  `json:"size"`
+		backups.Precondition
+	}
+	if err := c.BodyParser(&body); err != nil || body.Size <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "filename and a positive size are required"})
+	}
+	if body.Size > s.maxSize {
+		return rejected(c, &validate.Rejection{Reason: validate.ReasonTooLarge, Detail: fmt.Sprintf("%d bytes, the maximum is %d", body.Size, s.maxSize)})
+	}
+
+	_, name, ok := s.ownedName(c, body.Filename)
+	if !ok {
+		return nil
+	}
+	// Vérifiée de nouveau à la fin de l'envoi, avec la limite de sauvegardes
+	if _, ok := s.syncEntitlements(c); !ok {
+		return nil
+	}
+
+	session := uploads.Session{Filename: name.Filename, Size: body.Size, PartSize: s.partSize, Precondition: body.Precondition}
+	var err error
+	session.ID, err = uploads.NewID()
+	if err == nil {
+		session.UploadID, err = s.multipart.CreateMultipart(c.UserContext(), session.IncomingKey(name))
+	}
+	if err != nil {
+		log.Printf("Starting upload of %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to start upload"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(s.statusOf(session, nil))
+}
+
+// uploadStatusHandler gère GET /backups/uploads/:upload_id : les parties encore
+// à envoyer, pour reprendre un envoi interrompu.
+func (s *server) uploadStatusHandler(c *fiber.Ctx) error {
+	_, session, name, ok := s.ownedUpload(c)
+	if !ok {
+		return nil
+	}
+	parts, ok := s.uploadedParts(c, session, name)
+	if !ok {
+		return nil
+	}
+	return c.JSON(s.statusOf(session, parts))
+}
+
+// uploadPartHandler gère PUT /backups/uploads/:upload_id/parts/:number : le corps
+// de la requête est la partie, X-Checksum-SHA256 son empreinte en hexadécimal.
+func (s *server) uploadPartHandler(c *fiber.Ctx) error {
+	_, session, name, ok := s.ownedUpload(c)
+	if !ok {
+		return nil
+	}
+
+	number, err := strconv.Atoi(c.Params("number"))
+	size, valid := session.PartSizeOf(number)
+	if err != nil || !valid {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid part number, expected 1 to %d", session.Parts())})
+	}
+	part := c.Body()
+	if int64(len(part)) != size {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Part %d must be %d bytes, got %d", number, size, len(part))})
+	}
+
+	want, err := hex.DecodeString(c.Get("X-Checksum-SHA256"))
+	if err != nil || len(want) != sha256.Size {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "X-Checksum-SHA256 must be the hex SHA-256 of the part"})
+	}
+	sum := sha256.Sum256(part)
+	if !bytes.Equal(sum[:], want) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Checksum mismatch, upload the part again"})
+	}
+
+	err = s.multipart.UploadPart(c.UserContext(), session.IncomingKey(name), session.UploadID, number, bytes.NewReader(part), size)
+	if errors.Is(err, storage.ErrUploadNotFound) {
+		return uploadNotFound(c)
+	}
+	if err != nil {
+		log.Printf("Uploading part %d of %s failed: %v", number, name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store part"})
+	}
+	return c.JSON(fiber.Map{"number": number, "size": size, "sha256": hex.EncodeToString(sum[:])})
+}
+
+// completeUploadHandler gère POST /backups/uploads/:upload_id/complete : assemble
+// les parties, puis valide et enregistre la sauvegarde comme POST /backups.
+func (s *server) completeUploadHandler(c *fiber.Ctx) error {
+	u, session, name, ok := s.ownedUpload(c)
+	if !ok {
+		return nil
+	}
+	parts, ok := s.uploadedParts(c, session, name)
+	if !ok {
+		return nil
+	}
+	if missing := session.Missing(parts); len(missing) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Upload incomplete", "missing": missing})
+	}
+
+	ctx := c.UserContext()
+	key := session.IncomingKey(name)
+	err := s.multipart.CompleteMultipart(ctx, key, session.UploadID, parts)
+	if errors.Is(err, storage.ErrUploadNotFound) {
+		return uploadNotFound(c)
+	}
+	if err != nil {
+		log.Printf("Completing upload of %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to complete upload"})
+	}
+	// La base assemblée n'est qu'une étape : le GC la supprime si cette requête échoue
+	defer func() {
+		if err := s.stores.Main.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Deleting incoming backup %s failed: %v", key, err)
+		}
+	}()
+
+	path, err := s.stageIncoming(c, key)
+	if err != nil {
+		log.Printf("Staging backup %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store backup"})
+	}
+	defer os.Remove(path)
+
+	return s.storeBackup(c, u, name, path, session.Precondition)
+}
+
+// abortUploadHandler gère DELETE /backups/uploads/:upload_id : abandonne l'envoi
+// et supprime les parties déjà reçues.
+func (s *server) abortUploadHandler(c *fiber.Ctx) error {
+	_, session, name, ok := s.ownedUpload(c)
+	if !ok {
+		return nil
+	}
+	err := s.multipart.AbortMultipart(c.UserContext(), session.IncomingKey(name), session.UploadID)
+	if errors.Is(err, storage.ErrUploadNotFound) {
+		return uploadNotFound(c)
+	}
+	if err != nil {
+		log.Printf("Aborting upload of %s failed: %v", name.Key(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to abort upload"})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ownedUpload decodes the upload token of the route, for an upload of the
+// caller returned with it. It returns false when the request was answered
+// with an error.
+func (s *server) ownedUpload(c *fiber.Ctx) (*dbservicepb.User, uploads.Session, backups.Name, bool) {
+	session, err := uploads.ParseToken(c.Params("upload_id"), s.uploadKey)
+	// Signé, mais émis avant un changement de MAX_BACKUP_SIZE ou de la taille des parties
+	if err == nil && (session.Size > s.maxSize || session.PartSize != s.partSize) {
+		err = uploads.ErrInvalidToken
+	}
+	if err != nil {
+		_ = uploadNotFound(c)
+		return nil, session, backups.Name{}, false
+	}
+	u, name, ok := s.ownedName(c, session.Filename)
+	return u, session, name, ok
+}
+
+// uploadedParts returns the parts of the upload stored so far. It returns
+// false when the request was answered with an error.
+func (s *server) uploadedParts(c *fiber.Ctx, session uploads.Session, name backups.Name) ([]storage.Part, bool) {
+	parts, err := s.multipart.ListParts(c.UserContext(), session.IncomingKey(name), session.UploadID)
+	if errors.Is(err, storage.ErrUploadNotFound) {
+		_ = uploadNotFound(c)
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("Listing parts of %s failed: %v", name.Key(), err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to retrieve upload"})
+		return nil, false
+	}
+	return parts, true
+}
+
+// stageIncoming copies an assembled backup to a temporary file for
+// validation and returns its path.
+func (s *server) stageIncoming(c *fiber.Ctx, key string) (string, error) {
+	r, _, err := s.stores.Main.Get(c.UserContext(), key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	return stageReader(r)
+}
+
+func (s *server) statusOf(session uploads.Session, parts []storage.Part) uploadStatus {
+	return uploadStatus{
+		UploadID: session.Token(s.uploadKey),
+		Filename: session.Filename,
+		Size:     session.Size,
+		PartSize: session.PartSize,
+		Parts:    session.Parts(),
+		Missing:  session.Missing(parts),
+	}
+}
+
+func uploadNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Upload not found"})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"shared/dbservicepb"
+	"storage-service/backups"
+	"storage-service/retention"
+	"storage-service/storage"
+)
+
+// runRetention implements the `retention` subcommand: a single run of the
+// retention job (see retention.Job), e.g. from a cron. -dry-run only logs
+// what it would move and delete.
+func runRetention(args []string) {
+	fs := flag.NewFlagSet("retention", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "log the changes without applying them")
+	_ = fs.Parse(args)
+
+	stores, err := storesFromEnv()
+	if err != nil {
+		log.Fatalf("failed initializing storage: %v", err)
+	}
+	db, err := dbservicepb.NewClient(os.Getenv("DB_SERVICE_GRPC_ADDR"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed connecting to db-service: %v", err)
+	}
+	defer db.Close()
+	dir, err := directoryFromEnv(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	job := &retention.Job{Stores: stores, Directory: dir, DryRun: *dryRun}
+	res, err := job.RunOnce(context.Background())
+	if err != nil {
+		log.Fatalf("applying backup retention: %v", err)
+	}
+	if *dryRun {
+		log.Printf("Dry run: would archive %d backup(s) and delete %d, for %d user(s)", res.Moved, res.Deleted, res.Users)
+		return
+	}
+	log.Printf("Archived %d backup(s) and deleted %d, for %d user(s)", res.Moved, res.Deleted, res.Users)
+}
+
+func storesFromEnv() (backups.Stores, error) {
+	mainStore, backupStore, err := storage.FromEnv()
+	if err != nil {
+		return backups.Stores{}, err
+	}
+	return backups.Stores{Main: mainStore, Backup: backupStore}, nil
+}
+
+// directoryFromEnv reads the tiers and the plan catalog from db-service, as
+// the service registered under DB_SERVICE_API_KEY. RETENTION_MAIN_VERSIONS
+// and RETENTION_RULES tune the rules derived from the catalog.
+func directoryFromEnv(db *dbservicepb.Client) (*retention.DBService, error) {
+	apiKey := os.Getenv("DB_SERVICE_API_KEY")
+	if apiKey == "" {
+		return nil, errors.New("DB_SERVICE_API_KEY is not set")
+	}
+
+	mainVersions := retention.DefaultMainVersions
+	if v := os.Getenv("RETENTION_MAIN_VERSIONS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			log.Fatalf("invalid RETENTION_MAIN_VERSIONS %q", v)
+		}
+		mainVersions = n
+	}
+	overrides, err := retention.ParseOverrides(os.Getenv("RETENTION_RULES"))
+	if err != nil {
+		log.Fatalf("invalid RETENTION_RULES: %v", err)
+	}
+
+	return &retention.DBService{
+		Users:        db.Users,
+		Plans:        db.Plans,
+		APIKey:       apiKey,
+		MainVersions: mainVersions,
+		Overrides:    overrides,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"shared/dbservicepb"
+)
+
+// Directory tells the Job the tier of each user and the rules of each tier.
+type Directory interface {
+	// UserTiers returns the subscription tier of the users with the given
+	// UUIDs, by lowercase UUID; unknown users are missing.
+	UserTiers(ctx context.Context, uuids []string) (map[string]string, error)
+	// Rules returns the retention rules by tier.
+	Rules(ctx context.Context) (Rules, error)
+}
+
+// DBService reads the users and the plan catalog from db-service,
+// authenticated with a service API key.
+type DBService struct {
+	Users  dbservicepb.UserServiceClient
+	Plans  dbservicepb.PlanServiceClient
+	APIKey string
+	// MainVersions is the number of backups kept in main/ for every tier.
+	MainVersions int
+	// Overrides replace fields of the rules derived from the catalog (see
+	// ParseOverrides).
+	Overrides map[string]func(*Rule)
+}
+
+// userBatch is the most UUIDs GetUsersByUUID accepts per call.
+const userBatch = 100
+
+func (d *DBService) UserTiers(ctx context.Context, uuids []string) (map[string]string, error) {
+	tiers := make(map[string]string, len(uuids))
+	for batch := range slices.Chunk(uuids, userBatch) {
+		resp, err := d.Users.GetUsersByUUID(dbservicepb.WithAPIKey(ctx, d.APIKey), &dbservicepb.GetUsersByUUIDRequest{Uuids: batch})
+		if err != nil {
+			return nil, fmt.Errorf("looking up users: %w", err)
+		}
+		for _, u := range resp.GetUsers() {
+			tiers[strings.ToLower(u.GetUuid())] = u.GetSubscriptionTier()
+		}
+	}
+	return tiers, nil
+}
+
+func (d *DBService) Rules(ctx context.Context) (Rules, error) {
+	resp, err := d.Plans.ListPlans(dbservicepb.WithAPIKey(ctx, d.APIKey), &dbservicepb.ListPlansRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("listing plans: %w", err)
+	}
+	return FromPlans(resp.GetPlans(), d.MainVersions).Apply(d.Overrides, d.MainVersions), nil
+}
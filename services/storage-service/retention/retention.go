@@ -0,0 +1,203 @@
+// Package retention applies the retention rules of the subscription tiers to
+// the stored backups.
+//
+// Uploads land in the main area (see backups.Stores). The Job keeps the
+// Rule.MainVersions most recent backups of each user there and moves the
+// older ones to the backup area, which holds the older restore points. It
+// deletes the backups beyond the Rule.MaxBackups most recent ones and those
+// older than Rule.RetentionDays, by the date of the database in the
+// filename. The most recent backup of a user is never moved nor deleted, so
+// that a downgraded user can always restore their latest data.
+//
+// The rules derive from the max_cloud_backups and backup_retention_days
+// entitlements of the plan catalog (see FromPlans). The Job holds no lock:
+// schedule it on a single instance.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"storage-service/backups"
+	"storage-service/storage"
+)
+
+// DefaultInterval is the time between two runs of the Job.
+const DefaultInterval = 24 * time.Hour
+
+// Job moves and deletes backups according to the rules of their owner's tier.
+type Job struct {
+	Stores    backups.Stores
+	Directory Directory
+	// Interval between two runs; DefaultInterval when zero.
+	Interval time.Duration
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+	// DryRun reports what a run would do without touching the stores.
+	DryRun bool
+}
+
+// Result reports what a run did.
+type Result struct {
+	// Users is the number of users with backups.
+	Users int
+	// Moved is the number of backups moved from main to backup.
+	Moved int
+	// Deleted is the number of backups deleted.
+	Deleted int
+}
+
+// Action is what the Job does to a backup.
+type Action int
+
+const (
+	Keep    Action = iota
+	Archive        // move from main to backup
+	Delete
+)
+
+// Run runs the Job immediately, then every Interval until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	interval := j.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := j.RunOnce(ctx)
+		switch {
+		case err != nil:
+			log.Printf("Backup retention failed: %v", err)
+		case res.Moved > 0 || res.Deleted > 0:
+			log.Printf("Backup retention: %d backup(s) archived, %d deleted", res.Moved, res.Deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies the rules to the backups of every user. A failure on one
+// user is logged and does not stop the others; the first one is returned.
+func (j *Job) RunOnce(ctx context.Context) (Result, error) {
+	var res Result
+	rules, err := j.Directory.Rules(ctx)
+	if err != nil {
+		return res, err
+	}
+	uuids, err := j.Stores.Users(ctx)
+	if err != nil {
+		return res, err
+	}
+	tiers, err := j.Directory.UserTiers(ctx, uuids)
+	if err != nil {
+		return res, err
+	}
+
+	now := j.now()
+	var firstErr error
+	for _, uuid := range uuids {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		res.Users++
+		// Users unknown to db-service (e.g. deleted) get the free tier's rule
+		rule := rules.For(tiers[uuid])
+		if err := j.apply(ctx, uuid, rule, now, &res); err != nil {
+			log.Printf("Backup retention failed for %s: %v", uuid, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return res, firstErr
+}
+
+func (j *Job) apply(ctx context.Context, uuid string, rule Rule, now time.Time, res *Result) error {
+	list, err := j.Stores.List(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	for i, b := range list {
+		action := Plan(rule, i, b, now)
+		if action == Keep || j.DryRun {
+			if action != Keep {
+				log.Printf("Backup retention (dry run): would %s %s/%s", action, b.Location, b.Name().Key())
+			}
+			count(res, action)
+			continue
+		}
+
+		key := b.Name().Key()
+		store := j.Stores.Store(b.Location)
+		switch action {
+		case Archive:
+			err = storage.Move(ctx, store, j.Stores.Backup, key)
+		case Delete:
+			err = store.Delete(ctx, key)
+		}
+		// A backup deleted by its user meanwhile is already gone
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", action, key, err)
+		}
+		count(res, action)
+	}
+	return nil
+}
+
+// Plan returns the action for the backup b, the i-th most recent of its user
+// (see backups.SortNewestFirst).
+func Plan(rule Rule, i int, b backups.Backup, now time.Time) Action {
+	if i == 0 {
+		return Keep
+	}
+	if rule.MaxBackups != nil && int64(i) >= *rule.MaxBackups {
+		return Delete
+	}
+	if rule.RetentionDays != nil && b.Age(now) > time.Duration(*rule.RetentionDays)*24*time.Hour {
+		return Delete
+	}
+	if b.Location == backups.LocationMain && i >= rule.MainVersions {
+		return Archive
+	}
+	return Keep
+}
+
+func (a Action) String() string {
+	switch a {
+	case Archive:
+		return "archive"
+	case Delete:
+		return "delete"
+	default:
+		return "keep"
+	}
+}
+
+func count(res *Result, action Action) {
+	switch action {
+	case Archive:
+		res.Moved++
+	case Delete:
+		res.Deleted++
+	}
+}
+
+func (j *Job) now() time.Time {
+	if j.Now != nil {
+		return j.Now()
+	}
+	return time.Now()
+}
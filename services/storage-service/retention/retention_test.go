@@ -0,0 +1,274 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"shared/dbservicepb"
+	"shared/entitlements"
+	"storage-service/backups"
+	"storage-service/storage"
+)
+
+const uuid = "0b0e3f0a-1111-4222-8333-444455556666"
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func limit(n int64) *int64 { return &n }
+
+// backup returns the backup of iteration it, whose database was last
+// updated age ago.
+func backup(it int64, age time.Duration, location string) backups.Backup {
+	date := now.Add(-age).Format("2006-01-02 15-04-05")
+	return backups.Backup{
+		Filename:  fmt.Sprintf("leakr_db_%s_%s_it%d.sqlite", uuid, date, it),
+		Date:      date,
+		Iteration: it,
+		Location:  location,
+	}
+}
+
+func TestPlan(t *testing.T) {
+	day := 24 * time.Hour
+	rule := Rule{MainVersions: 2, MaxBackups: limit(4), RetentionDays: limit(30)}
+
+	tests := []struct {
+		name string
+		rule Rule
+		i    int
+		b    backups.Backup
+		want Action
+	}{
+		{name: "newest", rule: rule, i: 0, b: backup(9, day, backups.LocationMain), want: Keep},
+		{name: "newest past retention", rule: rule, i: 0, b: backup(9, 90*day, backups.LocationMain), want: Keep},
+		{name: "newest with no backups allowed", rule: Rule{MaxBackups: limit(0), RetentionDays: limit(0)}, i: 0, b: backup(9, day, backups.LocationBackup), want: Keep},
+		{name: "within main versions", rule: rule, i: 1, b: backup(8, day, backups.LocationMain), want: Keep},
+		{name: "beyond main versions", rule: rule, i: 2, b: backup(7, day, backups.LocationMain), want: Archive},
+		{name: "beyond main versions, archived", rule: rule, i: 2, b: backup(7, day, backups.LocationBackup), want: Keep},
+		{name: "archived within main versions", rule: rule, i: 1, b: backup(8, day, backups.LocationBackup), want: Keep},
+		{name: "beyond max backups", rule: rule, i: 4, b: backup(5, day, backups.LocationBackup), want: Delete},
+		{name: "beyond max backups, in main", rule: rule, i: 4, b: backup(5, day, backups.LocationMain), want: Delete},
+		{name: "past retention", rule: rule, i: 1, b: backup(8, 31*day, backups.LocationMain), want: Delete},
+		{name: "at the end of retention", rule: rule, i: 1, b: backup(8, 30*day, backups.LocationMain), want: Keep},
+		{name: "no limits", rule: Rule{MainVersions: 2}, i: 50, b: backup(1, 900*day, backups.LocationBackup), want: Keep},
+		{name: "no limits, in main", rule: Rule{MainVersions: 2}, i: 50, b: backup(1, 900*day, backups.LocationMain), want: Archive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Plan(tt.rule, tt.i, tt.b, now); got != tt.want {
+				t.Errorf("Plan = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Unparsable date: aged by the upload time
+	b := backup(8, 0, backups.LocationMain)
+	b.Filename = strings.Replace(b.Filename, b.Date, "not a date", 1)
+	b.UploadedAt = now.Add(-31 * day)
+	if got := Plan(rule, 1, b, now); got != Delete {
+		t.Errorf("Plan of an old upload without a date = %s, want delete", got)
+	}
+}
+
+func TestRulesFor(t *testing.T) {
+	free := Rule{MainVersions: 1, MaxBackups: limit(1)}
+	premium := Rule{MainVersions: 3}
+
+	if got := (Rules{"free": free, "premium": premium}).For("premium"); !reflect.DeepEqual(got, premium) {
+		t.Errorf("For(premium) = %+v, want %+v", got, premium)
+	}
+	if got := (Rules{"free": free, "premium": premium}).For("legacy"); !reflect.DeepEqual(got, free) {
+		t.Errorf("For(legacy) = %+v, want the free rule", got)
+	}
+	if got := (Rules{"premium": premium}).For(""); !reflect.DeepEqual(got, Rule{MainVersions: DefaultMainVersions}) {
+		t.Errorf("For without a free rule = %+v, want the default rule", got)
+	}
+}
+
+func TestFromPlans(t *testing.T) {
+	entitlement := func(key string, enabled bool, limit *int64) *dbservicepb.Entitlement {
+		return &dbservicepb.Entitlement{Key: key, Enabled: enabled, Limit: limit}
+	}
+	plans := []*dbservicepb.Plan{
+		{Key: "free", Entitlements: []*dbservicepb.Entitlement{
+			entitlement(entitlements.MaxCloudBackups, true, limit(2)),
+			entitlement(entitlements.BackupRetentionDays, true, limit(7)),
+		}},
+		{Key: "premium", Entitlements: []*dbservicepb.Entitlement{
+			entitlement(entitlements.MaxCloudBackups, true, nil),
+			entitlement(entitlements.BackupRetentionDays, true, nil),
+		}},
+		{Key: "basic", Entitlements: []*dbservicepb.Entitlement{
+			entitlement(entitlements.MaxCloudBackups, false, limit(10)),
+		}},
+	}
+
+	want := Rules{
+		"free":    {MainVersions: 2, MaxBackups: limit(2), RetentionDays: limit(7)},
+		"premium": {MainVersions: 2},
+		"basic":   {MainVersions: 2, MaxBackups: limit(0), RetentionDays: limit(0)},
+	}
+	if got := FromPlans(plans, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromPlans = %s, want %s", dump(got), dump(want))
+	}
+}
+
+func TestParseOverrides(t *testing.T) {
+	rules := Rules{
+		"free":    {MainVersions: 3, MaxBackups: limit(2), RetentionDays: limit(7)},
+		"premium": {MainVersions: 3, RetentionDays: limit(365)},
+	}
+
+	tests := []struct {
+		name string
+		s    string
+		want Rules
+		err  bool
+	}{
+		{name: "empty", s: "", want: rules},
+		{
+			name: "fields",
+			s:    "free=main:1,max:5, days:unlimited ; premium=days:30",
+			want: Rules{
+				"free":    {MainVersions: 1, MaxBackups: limit(5)},
+				"premium": {MainVersions: 3, RetentionDays: limit(30)},
+			},
+		},
+		{
+			name: "tier missing from the catalog",
+			s:    "team=max:unlimited,days:90",
+			want: Rules{
+				"free":    rules["free"],
+				"premium": rules["premium"],
+				"team":    {MainVersions: 3, RetentionDays: limit(90)},
+			},
+		},
+		{name: "no tier", s: "=max:1", err: true},
+		{name: "no fields", s: "free", err: true},
+		{name: "no value", s: "free=max", err: true},
+		{name: "unknown field", s: "free=size:1", err: true},
+		{name: "negative", s: "free=max:-1", err: true},
+		{name: "not a number", s: "free=days:week", err: true},
+		{name: "unlimited main", s: "free=main:unlimited", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := ParseOverrides(tt.s)
+			if (err != nil) != tt.err {
+				t.Fatalf("ParseOverrides = %v, want error %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if got := rules.Apply(overrides, 3); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply = %s, want %s", dump(got), dump(tt.want))
+			}
+		})
+	}
+	if rules["free"].MaxBackups == nil || *rules["free"].MaxBackups != 2 {
+		t.Errorf("Apply modified the rules: %s", dump(rules))
+	}
+}
+
+// dump formats rules with the values of their limits.
+func dump(rules Rules) string {
+	var b strings.Builder
+	for tier, r := range rules {
+		fmt.Fprintf(&b, "%s{main:%d max:%s days:%s} ", tier, r.MainVersions, value(r.MaxBackups), value(r.RetentionDays))
+	}
+	return b.String()
+}
+
+func value(n *int64) string {
+	if n == nil {
+		return "unlimited"
+	}
+	return fmt.Sprint(*n)
+}
+
+type directory struct {
+	tiers map[string]string
+	rules Rules
+}
+
+func (d directory) UserTiers(context.Context, []string) (map[string]string, error) {
+	return d.tiers, nil
+}
+
+func (d directory) Rules(context.Context) (Rules, error) {
+	return d.rules, nil
+}
+
+func TestRunOnce(t *testing.T) {
+	ctx := context.Background()
+	newStore := func() storage.BlobStore {
+		s, err := storage.NewLocal(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	stores := backups.Stores{Main: newStore(), Backup: newStore()}
+	day := 24 * time.Hour
+	for it := int64(1); it <= 5; it++ {
+		b := backup(it, time.Duration(5-it)*day, backups.LocationMain)
+		if err := stores.Main.Put(ctx, b.Name().Key(), strings.NewReader("x"), 1, storage.Metadata{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Past the retention window: deleted from backup as well
+	old := backup(0, 60*day, backups.LocationBackup)
+	if err := stores.Backup.Put(ctx, old.Name().Key(), strings.NewReader("x"), 1, storage.Metadata{}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := &Job{
+		Stores: stores,
+		Directory: directory{
+			tiers: map[string]string{uuid: "basic"},
+			rules: Rules{"basic": {MainVersions: 2, MaxBackups: limit(4), RetentionDays: limit(30)}},
+		},
+		Now: func() time.Time { return now },
+	}
+	locations := func() []string {
+		t.Helper()
+		list, err := stores.List(ctx, uuid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, b := range list {
+			got = append(got, fmt.Sprintf("%d:%s", b.Iteration, b.Location))
+		}
+		return got
+	}
+	before := locations()
+
+	job.DryRun = true
+	res, err := job.RunOnce(ctx)
+	if want := (Result{Users: 1, Moved: 2, Deleted: 2}); err != nil || res != want {
+		t.Errorf("dry run = %+v, %v, want %+v", res, err, want)
+	}
+	if got := locations(); !reflect.DeepEqual(got, before) {
+		t.Errorf("dry run changed the stores: %v, want %v", got, before)
+	}
+
+	job.DryRun = false
+	res, err = job.RunOnce(ctx)
+	if want := (Result{Users: 1, Moved: 2, Deleted: 2}); err != nil || res != want {
+		t.Errorf("RunOnce = %+v, %v, want %+v", res, err, want)
+	}
+	if got, want := locations(), []string{"5:main", "4:main", "3:backup", "2:backup"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("backups = %v, want %v", got, want)
+	}
+
+	// Nothing left to do
+	res, err = job.RunOnce(ctx)
+	if want := (Result{Users: 1}); err != nil || res != want {
+		t.Errorf("second run = %+v, %v, want %+v", res, err, want)
+	}
+}
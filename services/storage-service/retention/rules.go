@@ -0,0 +1,146 @@
+package retention
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"shared/dbservicepb"
+	"shared/entitlements"
+)
+
+// DefaultMainVersions is the number of backups kept in main/ when the rules
+// do not say otherwise.
+const DefaultMainVersions = 3
+
+// freeTier is the tier of the users without a plan of their own, as in
+// db-service.
+const freeTier = "free"
+
+// Rule is the retention of the backups of a subscription tier. The newest
+// backup of a user is always kept, whatever the rule.
+type Rule struct {
+	// MainVersions is the number of most recent backups kept in main/; the
+	// older ones are moved to backup/.
+	MainVersions int `json:"main_versions"`
+	// MaxBackups is the number of backups kept in total; nil for no limit.
+	MaxBackups *int64 `json:"max_backups"`
+	// RetentionDays is how long a backup is kept after the database was last
+	// updated; nil for no limit.
+	RetentionDays *int64 `json:"retention_days"`
+}
+
+// Rules are the retention rules by subscription tier.
+type Rules map[string]Rule
+
+// For returns the rule of a tier. Tiers without a rule (and users unknown to
+// db-service) fall back to the free tier, then to keeping everything.
+func (r Rules) For(tier string) Rule {
+	if rule, ok := r[tier]; ok {
+		return rule
+	}
+	if rule, ok := r[freeTier]; ok {
+		return rule
+	}
+	return Rule{MainVersions: DefaultMainVersions}
+}
+
+// FromPlans derives the rules from db-service's plan catalog: the
+// max_cloud_backups and backup_retention_days entitlements of each plan
+// (the key of a plan is the tier it is sold as). A plan that does not grant
+// an entitlement keeps only the newest backup.
+func FromPlans(plans []*dbservicepb.Plan, mainVersions int) Rules {
+	rules := make(Rules, len(plans))
+	for _, p := range plans {
+		set := entitlements.FromProto(&dbservicepb.Entitlements{Plan: p.GetKey(), Entitlements: p.GetEntitlements()})
+		rule := Rule{MainVersions: mainVersions}
+		if limit, limited := set.Limit(entitlements.MaxCloudBackups); limited {
+			rule.MaxBackups = &limit
+		}
+		if limit, limited := set.Limit(entitlements.BackupRetentionDays); limited {
+			rule.RetentionDays = &limit
+		}
+		rules[p.GetKey()] = rule
+	}
+	return rules
+}
+
+// ParseOverrides parses the RETENTION_RULES format, overriding some fields of
+// the rules per tier, e.g. "free=main:1,max:2,days:7;premium=days:unlimited".
+// Fields: main (backups kept in main/), max (backups kept in total) and days
+// (retention window), a number or "unlimited" (max and days only).
+func ParseOverrides(s string) (map[string]func(*Rule), error) {
+	overrides := map[string]func(*Rule){}
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		tier, fields, ok := strings.Cut(entry, "=")
+		if !ok || tier == "" {
+			return nil, fmt.Errorf("invalid retention rule %q: expected tier=field:value,...", entry)
+		}
+
+		var setters []func(*Rule)
+		for _, field := range strings.Split(fields, ",") {
+			name, value, ok := strings.Cut(strings.TrimSpace(field), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid retention rule field %q for %s", field, tier)
+			}
+			setter, err := parseField(name, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid retention rule field %q for %s: %w", field, tier, err)
+			}
+			setters = append(setters, setter)
+		}
+		overrides[tier] = func(r *Rule) {
+			for _, set := range setters {
+				set(r)
+			}
+		}
+	}
+	return overrides, nil
+}
+
+func parseField(name, value string) (func(*Rule), error) {
+	var limit *int64
+	if value != "unlimited" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("expected a non-negative number or unlimited")
+		}
+		limit = &n
+	}
+
+	switch name {
+	case "main":
+		if limit == nil {
+			return nil, fmt.Errorf("main cannot be unlimited")
+		}
+		return func(r *Rule) { r.MainVersions = int(*limit) }, nil
+	case "max":
+		return func(r *Rule) { r.MaxBackups = limit }, nil
+	case "days":
+		return func(r *Rule) { r.RetentionDays = limit }, nil
+	default:
+		return nil, fmt.Errorf("unknown field %s, expected main, max or days", name)
+	}
+}
+
+// Apply returns the rules with the overrides applied; a tier missing from
+// the rules starts from the default rule.
+func (r Rules) Apply(overrides map[string]func(*Rule), mainVersions int) Rules {
+	out := make(Rules, len(r)+len(overrides))
+	for tier, rule := range r {
+		out[tier] = rule
+	}
+	for tier, override := range overrides {
+		rule, ok := out[tier]
+		if !ok {
+			rule = Rule{MainVersions: mainVersions}
+		}
+		override(&rule)
+		out[tier] = rule
+	}
+	return out
+}
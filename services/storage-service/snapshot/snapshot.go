@@ -0,0 +1,320 @@
+// Package snapshot reads the rows of a backup of the extension's database
+// (see createSchema in extension/src/lib/dbUtils.ts) into memory.
+//
+// Backups written by older versions of the extension are read as of the
+// current schema, Version: the data changes of the later migrations are
+// applied to the rows read, not to the file. verifie, added by 1.1.0, is
+// false before it; aliases that are not a JSON array read as none (1.1.1);
+// the "_dupN" aliases added to tell duplicates apart are dropped (1.1.2).
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// Version is the DB_VERSION of the extension whose schema the rows are read
+// as.
+const Version = "1.1.2"
+
+// Sequenced lists the tables with an AUTOINCREMENT id.
+var Sequenced = []string{"createurs", "contenus", "plateformes", "profils_plateforme"}
+
+// Snapshot is the content of a backup, its rows by id.
+type Snapshot struct {
+	// Version is the version_texte of the file, before normalization.
+	Version    string
+	Iterations int64
+	DateMaj    string
+
+	UUID            string
+	ShareCollection bool
+
+	Createurs   map[int64]Createur
+	Contenus    map[int64]Contenu
+	Plateformes map[int64]Plateforme
+	Profils     map[int64]Profil
+	// Seq is the sqlite_sequence of each table, the highest id ever used.
+	Seq map[string]int64
+}
+
+// Createur is a row of createurs.
+type Createur struct {
+	Nom       string
+	Aliases   string // JSON array, as written by AliasesJSON
+	DateAjout string
+	Favori    bool
+	Verifie   bool
+}
+
+// Contenu is a row of contenus, referencing Createurs by id.
+type Contenu struct {
+	URL       string
+	Tabname   sql.NullString
+	DateAjout string
+	Createur  int64
+	Favori    bool
+}
+
+// Plateforme is a row of plateformes.
+type Plateforme struct {
+	Nom string
+}
+
+// Profil is a row of profils_plateforme, referencing Createurs and
+// Plateformes by id.
+type Profil struct {
+	Lien       string
+	Createur   int64
+	Plateforme int64
+}
+
+// Read loads the backup at path, read-only.
+func Read(ctx context.Context, path string) (*Snapshot, error) {
+	// immutable=1: no lock nor journal, the file is never written
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath()+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	s := &Snapshot{
+		Createurs:   map[int64]Createur{},
+		Contenus:    map[int64]Contenu{},
+		Plateformes: map[int64]Plateforme{},
+		Profils:     map[int64]Profil{},
+		Seq:         map[string]int64{},
+	}
+	var dateMaj sql.NullString
+	err = db.QueryRowContext(ctx, "SELECT version_texte, COALESCE(iterations, 0), date_maj FROM version WHERE id = 1").
+		Scan(&s.Version, &s.Iterations, &dateMaj)
+	if err != nil {
+		return nil, fmt.Errorf("reading version: %w", err)
+	}
+	s.DateMaj = dateMaj.String
+	var share any
+	err = db.QueryRowContext(ctx, "SELECT uuid, share_collection FROM settings ORDER BY id LIMIT 1").Scan(&s.UUID, &share)
+	if err != nil {
+		return nil, fmt.Errorf("reading settings: %w", err)
+	}
+	s.ShareCollection = truthy(share)
+
+	// verifie was added by migration 1.1.0
+	verifie := "FALSE"
+	if ok, err := HasColumn(ctx, db, "createurs", "verifie"); err != nil {
+		return nil, err
+	} else if ok {
+		verifie = "verifie"
+	}
+	dupAliases := Less(s.Version, "1.1.2")
+	err = each(ctx, db, "SELECT id, nom, aliases, date_ajout, favori, "+verifie+" FROM createurs", func(rows *sql.Rows) error {
+		var id int64
+		var c Createur
+		var aliases sql.NullString
+		var favori, verifie any
+		if err := rows.Scan(&id, &c.Nom, &aliases, &c.DateAjout, &favori, &verifie); err != nil {
+			return err
+		}
+		list := ParseAliases(aliases.String)
+		if dupAliases {
+			list = WithoutDup(list)
+		}
+		c.Aliases = AliasesJSON(list)
+		c.Favori, c.Verifie = truthy(favori), truthy(verifie)
+		s.Createurs[id] = c
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading createurs: %w", err)
+	}
+
+	err = each(ctx, db, "SELECT id, url, tabname, date_ajout, id_createur, favori FROM contenus", func(rows *sql.Rows) error {
+		var id int64
+		var c Contenu
+		var favori any
+		if err := rows.Scan(&id, &c.URL, &c.Tabname, &c.DateAjout, &c.Createur, &favori); err != nil {
+			return err
+		}
+		c.Favori = truthy(favori)
+		s.Contenus[id] = c
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading contenus: %w", err)
+	}
+
+	err = each(ctx, db, "SELECT id, nom FROM plateformes", func(rows *sql.Rows) error {
+		var id int64
+		var p Plateforme
+		if err := rows.Scan(&id, &p.Nom); err != nil {
+			return err
+		}
+		s.Plateformes[id] = p
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading plateformes: %w", err)
+	}
+
+	err = each(ctx, db, "SELECT id, lien, id_createur, id_plateforme FROM profils_plateforme", func(rows *sql.Rows) error {
+		var id int64
+		var p Profil
+		if err := rows.Scan(&id, &p.Lien, &p.Createur, &p.Plateforme); err != nil {
+			return err
+		}
+		s.Profils[id] = p
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading profils_plateforme: %w", err)
+	}
+
+	if ok, err := HasTable(ctx, db, "sqlite_sequence"); err != nil {
+		return nil, err
+	} else if ok {
+		err = each(ctx, db, "SELECT name, seq FROM sqlite_sequence", func(rows *sql.Rows) error {
+			var name string
+			var seq int64
+			if err := rows.Scan(&name, &seq); err != nil {
+				return err
+			}
+			s.Seq[name] = seq
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading sqlite_sequence: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// each calls fn for every row of query.
+func each(ctx context.Context, db *sql.DB, query string, fn func(*sql.Rows) error) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Querier is a *sql.DB, *sql.Tx or *sql.Conn.
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// HasColumn reports whether table has column.
+func HasColumn(ctx context.Context, db Querier, table, column string) (bool, error) {
+	var n int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&n)
+	return n > 0, err
+}
+
+// HasTable reports whether the database has table.
+func HasTable(ctx context.Context, db Querier, table string) (bool, error) {
+	var n int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&n)
+	return n > 0, err
+}
+
+// Less reports whether the version a is before b, both x.y.z; an invalid
+// version is before any other.
+func Less(a, b string) bool {
+	pa, pb := parseVersion(a), parseVersion(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			return pa[i] < pb[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) [3]int {
+	var p [3]int
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return [3]int{-1, -1, -1}
+	}
+	for i, s := range parts {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return [3]int{-1, -1, -1}
+		}
+		p[i] = n
+	}
+	return p
+}
+
+// truthy reads a BOOLEAN column, stored by sql.js as 0/1 but possibly as
+// text by older versions.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case bool:
+		return v
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return err == nil && b
+	case []byte:
+		return truthy(string(v))
+	default:
+		return false
+	}
+}
+
+// ParseAliases decodes the aliases column; an invalid value reads as no
+// alias, as in migration 1.1.1.
+func ParseAliases(s string) []string {
+	var raw []any
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil
+	}
+	aliases := make([]string, 0, len(raw))
+	for _, a := range raw {
+		if a, ok := a.(string); ok {
+			aliases = append(aliases, a)
+		}
+	}
+	return aliases
+}
+
+// AliasesJSON encodes aliases like JSON.stringify, which the uniqueness of
+// the column relies on.
+func AliasesJSON(aliases []string) string {
+	if len(aliases) == 0 {
+		return "[]"
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(aliases)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// WithoutDup drops the aliases containing "_dup", as migration 1.1.2.
+func WithoutDup(aliases []string) []string {
+	kept := aliases[:0]
+	for _, a := range aliases {
+		if !strings.Contains(a, "_dup") {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
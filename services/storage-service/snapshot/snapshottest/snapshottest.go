@@ -0,0 +1,154 @@
+// Package snapshottest writes backups of the extension's database for
+// tests, with the schema of a given version of the extension.
+//
+// A backup at snapshot.Version has the schema of createSchema (see
+// extension/src/lib/dbUtils.ts). An older one has the schema the
+// extension's migrations leave: before 1.1.0, no verifie and neither nom
+// nor aliases unique; since, verifie and the unique indexes of migration
+// 1.1.0.
+package snapshottest
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"storage-service/snapshot"
+)
+
+// UUID is the settings.uuid of the backups.
+const UUID = "3f2b1c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+
+// DateMaj is the version.date_maj of the backups.
+const DateMaj = "2026-01-01 00:00:00"
+
+const common = `
+CREATE TABLE version (
+  id INTEGER PRIMARY KEY CHECK (id = 1),
+  version_texte TEXT NOT NULL,
+  iterations INTEGER DEFAULT 0,
+  date_maj TEXT DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE settings (
+  id INTEGER PRIMARY KEY,
+  uuid TEXT NOT NULL,
+  share_collection BOOLEAN DEFAULT TRUE
+);
+CREATE TABLE contenus (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  url TEXT NOT NULL,
+  tabname TEXT,
+  date_ajout TEXT NOT NULL,
+  id_createur INTEGER NOT NULL,
+  favori BOOLEAN DEFAULT FALSE,
+  FOREIGN KEY (id_createur) REFERENCES createurs(id)
+);
+CREATE TABLE plateformes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  nom TEXT NOT NULL UNIQUE
+);
+CREATE TABLE profils_plateforme (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  lien TEXT NOT NULL,
+  id_createur INTEGER NOT NULL,
+  id_plateforme INTEGER NOT NULL,
+  FOREIGN KEY (id_createur) REFERENCES createurs(id),
+  FOREIGN KEY (id_plateforme) REFERENCES plateformes(id)
+);
+`
+
+// createurs100 is createurs before migration 1.1.0.
+const createurs100 = `
+CREATE TABLE createurs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  nom TEXT NOT NULL,
+  aliases TEXT,
+  date_ajout TEXT NOT NULL,
+  favori BOOLEAN DEFAULT FALSE
+);
+`
+
+// migrated110 is what migration 1.1.0 adds to createurs100.
+const migrated110 = `
+ALTER TABLE createurs ADD COLUMN verifie BOOLEAN DEFAULT FALSE;
+CREATE UNIQUE INDEX idx_createurs_nom_unique ON createurs(nom);
+CREATE UNIQUE INDEX idx_createurs_aliases_unique ON createurs(aliases);
+`
+
+// createurs is createurs as of createSchema.
+const createurs = `
+CREATE TABLE createurs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  nom TEXT UNIQUE NOT NULL,
+  aliases TEXT UNIQUE NOT NULL,
+  date_ajout TEXT NOT NULL,
+  favori BOOLEAN DEFAULT FALSE,
+  verifie BOOLEAN DEFAULT FALSE
+);
+`
+
+// New writes a backup of the given version in t.TempDir(), with the rows
+// inserted by stmts, and returns its path.
+func New(t testing.TB, version string, stmts ...string) string {
+	t.Helper()
+	schema := common + createurs
+	if snapshot.Less(version, snapshot.Version) {
+		schema = common + createurs100
+		if !snapshot.Less(version, "1.1.0") {
+			schema += migrated110
+		}
+	}
+	path := filepath.Join(t.TempDir(), "backup.sqlite")
+	Exec(t, path, append([]string{
+		schema,
+		"INSERT INTO version (id, version_texte, iterations, date_maj) VALUES (1, '" + version + "', 1, '" + DateMaj + "')",
+		"INSERT INTO settings (id, uuid, share_collection) VALUES (1, '" + UUID + "', 1)",
+	}, stmts...)...)
+	return path
+}
+
+// Copy copies the backup at path in t.TempDir(), runs stmts on the copy
+// and returns its path.
+func Copy(t testing.TB, path string, stmts ...string) string {
+	t.Helper()
+	in, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	dst := filepath.Join(t.TempDir(), filepath.Base(path))
+	out, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		t.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+	Exec(t, dst, stmts...)
+	return dst
+}
+
+// Exec runs stmts on the database at path, created when missing.
+func Exec(t testing.TB, path string, stmts ...string) {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(context.Background(), stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+}
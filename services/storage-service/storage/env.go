@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FromEnv builds the main and backup BlobStores selected by STORAGE_BACKEND:
+//   - "r2" (default): R2_ACCOUNT_ID, R2_ACCESS_KEY_ID and
+//     R2_SECRET_ACCESS_KEY are required; R2_BUCKET_MAIN_NAME and
+//     R2_BUCKET_BACKUP_NAME default to "main" and "backup". S3_ENDPOINT
+//     replaces the R2 endpoint, e.g. for MinIO.
+//   - "local": the main/ and backup/ directories of LOCAL_STORAGE_DIR
+//     (defaults to ./data).
+func FromEnv() (main, backup BlobStore, err error) {
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", "r2":
+		cfg := S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			AccessKeyID:     os.Getenv("R2_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("R2_SECRET_ACCESS_KEY"),
+		}
+		if cfg.Endpoint == "" {
+			account := os.Getenv("R2_ACCOUNT_ID")
+			if account == "" {
+				return nil, nil, fmt.Errorf("R2_ACCOUNT_ID is not set")
+			}
+			cfg.Endpoint = R2Endpoint(account)
+		}
+		if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+			return nil, nil, fmt.Errorf("R2_ACCESS_KEY_ID and R2_SECRET_ACCESS_KEY are required")
+		}
+
+		cfg.Bucket = envOr("R2_BUCKET_MAIN_NAME", "main")
+		if main, err = NewS3(cfg); err != nil {
+			return nil, nil, err
+		}
+		cfg.Bucket = envOr("R2_BUCKET_BACKUP_NAME", "backup")
+		if backup, err = NewS3(cfg); err != nil {
+			return nil, nil, err
+		}
+		return main, backup, nil
+	case "local":
+		dir := envOr("LOCAL_STORAGE_DIR", "data")
+		if main, err = NewLocal(filepath.Join(dir, "main")); err != nil {
+			return nil, nil, err
+		}
+		if backup, err = NewLocal(filepath.Join(dir, "backup")); err != nil {
+			return nil, nil, err
+		}
+		return main, backup, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,427 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// multipartDir holds the multipart uploads in progress, one directory per
+// upload with the key of the object and the parts. It is hidden from List.
+const multipartDir = ".multipart"
+
+// Local stores objects as files under a root directory, keys being paths
+// relative to it. The Metadata of an object is kept in hidden files next to
+// it (see metaPath).
+type Local struct {
+	root string
+	// mu makes the check and the write of PutIf atomic.
+	mu sync.Mutex
+}
+
+// NewLocal returns a store rooted at dir, created if needed.
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("storage: creating %s: %w", dir, err)
+	}
+	return &Local{root: dir}, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// Extensions of the files holding the Metadata of an object.
+const (
+	checksumExt = ".sha256"
+	uploadedExt = ".uploaded.sha256"
+	versionExt  = ".version"
+)
+
+// metaPath returns the path of the file holding a field of the Metadata of
+// the object at p.
+func metaPath(p, ext string) string {
+	return filepath.Join(filepath.Dir(p), "."+filepath.Base(p)+ext)
+}
+
+// readMeta returns the Metadata of the object at p, empty fields when
+// unknown.
+func readMeta(p string) Metadata {
+	read := func(ext string) string {
+		b, err := os.ReadFile(metaPath(p, ext))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return Metadata{SHA256: read(checksumExt), UploadedSHA256: read(uploadedExt), Version: read(versionExt)}
+}
+
+// Put writes to a temporary file renamed over the object, so that readers
+// never see a partial object. The previous Metadata is removed first, so
+// that it is never reported for the new content.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, meta Metadata) error {
+	return l.PutIf(ctx, key, r, size, meta, Condition{})
+}
+
+// PutIf checks cond and renames the written file under a mutex: conditional
+// writes are only atomic within the process, the local backend serving a
+// single instance.
+func (l *Local) PutIf(_ context.Context, key string, r io.Reader, size int64, meta Metadata, cond Condition) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	n, err := io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("storage: wrote %d bytes of %s, expected %d", n, key, size)
+	}
+	if cond != (Condition{}) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if err := checkCondition(p, cond); err != nil {
+			return err
+		}
+	}
+	for _, ext := range []string{checksumExt, uploadedExt, versionExt} {
+		if err := os.Remove(metaPath(p, ext)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return err
+	}
+	for ext, v := range map[string]string{checksumExt: meta.SHA256, uploadedExt: meta.UploadedSHA256, versionExt: meta.Version} {
+		if v == "" {
+			continue
+		}
+		if err := os.WriteFile(metaPath(p, ext), []byte(v), 0o640); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCondition returns ErrPreconditionFailed unless cond holds for the
+// object at p.
+func checkCondition(p string, cond Condition) error {
+	info, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		if cond.IfMatch != "" {
+			return ErrPreconditionFailed
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cond.IfNoneMatch || (cond.IfMatch != "" && cond.IfMatch != etag(info)) {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+// etag derives the ETag of a file from its modification time and size: each
+// Put renames a new file over the object.
+func etag(info fs.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+func (l *Local) Get(_ context.Context, key string) (io.ReadCloser, *Object, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, &Object{Key: key, Size: info.Size(), LastModified: info.ModTime(), ETag: etag(info), Metadata: readMeta(p)}, nil
+}
+
+func (l *Local) Stat(_ context.Context, key string) (*Object, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Object{Key: key, Size: info.Size(), LastModified: info.ModTime(), ETag: etag(info), Metadata: readMeta(p)}, nil
+}
+
+// List walks the directory containing prefix; hidden files (temporary
+// uploads, metadata) and multipart uploads are skipped.
+func (l *Local) List(_ context.Context, prefix string) ([]Object, error) {
+	start := l.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		var err error
+		if start, err = l.path(prefix[:i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var objects []Object
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() && p == filepath.Join(l.root, multipartDir) {
+			return fs.SkipDir
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete removes the object, and its directory once empty.
+func (l *Local) Delete(_ context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	for _, ext := range []string{checksumExt, uploadedExt, versionExt} {
+		_ = os.Remove(metaPath(p, ext))
+	}
+	if dir := path.Dir(key); dir != "." {
+		_ = os.Remove(filepath.Dir(p)) // fails while not empty
+	}
+	return nil
+}
+
+func (l *Local) CreateMultipart(_ context.Context, key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	dir := filepath.Join(l.root, multipartDir, id)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "key"), []byte(key), 0o640); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// uploadDir returns the directory of an upload to key.
+func (l *Local) uploadDir(key, uploadID string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	if _, err := hex.DecodeString(uploadID); err != nil || uploadID == "" {
+		return "", ErrUploadNotFound
+	}
+	dir := filepath.Join(l.root, multipartDir, uploadID)
+	stored, err := os.ReadFile(filepath.Join(dir, "key"))
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && string(stored) != key) {
+		return "", ErrUploadNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func partFile(number int) string {
+	return fmt.Sprintf("part-%05d", number)
+}
+
+// UploadPart writes to a temporary file renamed over the part, as Put.
+func (l *Local) UploadPart(_ context.Context, key, uploadID string, number int, r io.Reader, size int64) error {
+	dir, err := l.uploadDir(key, uploadID)
+	if err != nil {
+		return err
+	}
+	if number < 1 {
+		return fmt.Errorf("storage: invalid part number %d", number)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	n, err := io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("storage: wrote %d bytes of part %d, expected %d", n, number, size)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, partFile(number)))
+}
+
+func (l *Local) ListParts(_ context.Context, key, uploadID string) ([]Part, error) {
+	dir, err := l.uploadDir(key, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []Part
+	for _, e := range entries {
+		digits, ok := strings.CutPrefix(e.Name(), "part-")
+		if !ok {
+			continue
+		}
+		number, err := strconv.Atoi(digits)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, Part{Number: number, Size: info.Size(), ETag: e.Name(), LastModified: info.ModTime()})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	return parts, nil
+}
+
+// CompleteMultipart concatenates the parts with Put, then removes the upload.
+func (l *Local) CompleteMultipart(ctx context.Context, key, uploadID string, parts []Part) error {
+	dir, err := l.uploadDir(key, uploadID)
+	if err != nil {
+		return err
+	}
+
+	var files []io.Reader
+	var size int64
+	for _, p := range parts {
+		f, err := os.Open(filepath.Join(dir, partFile(p.Number)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("storage: part %d of %s is missing", p.Number, key)
+		}
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		files = append(files, f)
+		size += p.Size
+	}
+	if err := l.Put(ctx, key, io.MultiReader(files...), size, Metadata{}); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (l *Local) AbortMultipart(_ context.Context, key, uploadID string) error {
+	dir, err := l.uploadDir(key, uploadID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// ListMultipart reports the creation of an upload as its initiation time.
+func (l *Local) ListMultipart(_ context.Context) ([]Upload, error) {
+	entries, err := os.ReadDir(filepath.Join(l.root, multipartDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var uploads []Upload
+	for _, e := range entries {
+		keyFile := filepath.Join(l.root, multipartDir, e.Name(), "key")
+		key, err := os.ReadFile(keyFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, Upload{Key: string(key), ID: e.Name(), Initiated: info.ModTime()})
+	}
+	return uploads, nil
+}
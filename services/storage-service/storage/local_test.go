@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newLocal(t *testing.T) *Local {
+	t.Helper()
+	l, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func put(t *testing.T, s BlobStore, key, content string, meta Metadata) {
+	t.Helper()
+	if err := s.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), meta); err != nil {
+		t.Fatalf("Put %s: %v", key, err)
+	}
+}
+
+func get(t *testing.T, s BlobStore, key string) (string, *Object) {
+	t.Helper()
+	r, obj, err := s.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get %s: %v", key, err)
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), obj
+}
+
+func keys(objects []Object) []string {
+	var keys []string
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+	}
+	return keys
+}
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+
+	meta := Metadata{SHA256: "aaaa", UploadedSHA256: "bbbb", Version: "1.1.0"}
+	put(t, l, "u1/b.sqlite", "backup", meta)
+	content, obj := get(t, l, "u1/b.sqlite")
+	if content != "backup" || obj.Size != 6 || obj.Metadata != meta || obj.ETag == "" {
+		t.Errorf("Get = %q, %+v", content, obj)
+	}
+	if st, err := l.Stat(ctx, "u1/b.sqlite"); err != nil || st.Size != 6 || st.Metadata != meta || st.ETag != obj.ETag {
+		t.Errorf("Stat = %+v, %v, want the object of Get", st, err)
+	}
+
+	// Replaced: the metadata of the previous content is gone
+	put(t, l, "u1/b.sqlite", "backup v2", Metadata{SHA256: "cccc"})
+	if _, obj := get(t, l, "u1/b.sqlite"); obj.Metadata != (Metadata{SHA256: "cccc"}) {
+		t.Errorf("Metadata after Put = %+v, want only the new SHA-256", obj.Metadata)
+	}
+
+	if err := l.Put(ctx, "u1/short.sqlite", strings.NewReader("abc"), 4, Metadata{}); err == nil {
+		t.Error("Put of fewer bytes than size succeeded")
+	}
+	if _, err := l.Stat(ctx, "u1/short.sqlite"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat after a failed Put = %v, want ErrNotFound", err)
+	}
+	if _, err := l.Stat(ctx, "u1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat of a directory = %v, want ErrNotFound", err)
+	}
+	if _, _, err := l.Get(ctx, "u2/missing.sqlite"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a missing key = %v, want ErrNotFound", err)
+	}
+
+	for _, key := range []string{"", ".", "../escape", "/abs", "u1/../u2", "u1//b"} {
+		if err := l.Put(ctx, key, strings.NewReader("x"), 1, Metadata{}); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+	}
+}
+
+func TestLocalList(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+	for _, key := range []string{"u2/a", "u1/b", "u1/a", "u10/a", "incoming/u1/a"} {
+		put(t, l, key, "x", Metadata{SHA256: "aaaa"})
+	}
+	if _, err := l.CreateMultipart(ctx, "u1/c"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "", want: []string{"incoming/u1/a", "u1/a", "u1/b", "u10/a", "u2/a"}},
+		{prefix: "u1/", want: []string{"u1/a", "u1/b"}},
+		{prefix: "u1", want: []string{"u1/a", "u1/b", "u10/a"}},
+		{prefix: "u1/b", want: []string{"u1/b"}},
+		{prefix: "u3/", want: nil},
+	}
+	for _, tt := range tests {
+		objects, err := l.List(ctx, tt.prefix)
+		if err != nil {
+			t.Fatalf("List(%q): %v", tt.prefix, err)
+		}
+		if got := keys(objects); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("List(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestLocalDelete(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+	put(t, l, "u1/a", "x", Metadata{SHA256: "aaaa"})
+	put(t, l, "u1/b", "x", Metadata{})
+
+	if err := l.Delete(ctx, "u1/a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Delete(ctx, "u1/a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete = %v, want ErrNotFound", err)
+	}
+	// Not reported for a new object under the key
+	put(t, l, "u1/a", "y", Metadata{})
+	if _, obj := get(t, l, "u1/a"); obj.SHA256 != "" {
+		t.Errorf("SHA-256 of a deleted object reported: %+v", obj)
+	}
+
+	for _, key := range []string{"u1/a", "u1/b"} {
+		if err := l.Delete(ctx, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(l.root, "u1")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("directory of the deleted objects left: %v", err)
+	}
+}
+
+func TestLocalPutIf(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+	putIf := func(content string, cond Condition) error {
+		return l.PutIf(ctx, "leases/u1", strings.NewReader(content), int64(len(content)), Metadata{}, cond)
+	}
+
+	if err := putIf("x", Condition{IfMatch: `"0-1"`}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("IfMatch on a missing object = %v, want ErrPreconditionFailed", err)
+	}
+	if err := putIf("x", Condition{IfNoneMatch: true}); err != nil {
+		t.Fatalf("IfNoneMatch on a missing object: %v", err)
+	}
+	if err := putIf("xx", Condition{IfNoneMatch: true}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("IfNoneMatch on an existing object = %v, want ErrPreconditionFailed", err)
+	}
+
+	first, err := l.Stat(ctx, "leases/u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := putIf("yy", Condition{IfMatch: first.ETag}); err != nil {
+		t.Fatalf("IfMatch of the current ETag: %v", err)
+	}
+	second, err := l.Stat(ctx, "leases/u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.ETag == first.ETag {
+		t.Errorf("ETag %s unchanged by a write", first.ETag)
+	}
+	if err := putIf("zzz", Condition{IfMatch: first.ETag}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("IfMatch of a stale ETag = %v, want ErrPreconditionFailed", err)
+	}
+	if content, _ := get(t, l, "leases/u1"); content != "yy" {
+		t.Errorf("content = %q, want the last successful write", content)
+	}
+
+	// Unconditional, as Put
+	if err := putIf("z", Condition{}); err != nil {
+		t.Errorf("PutIf without a condition: %v", err)
+	}
+}
+
+func TestLocalMultipart(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+	key := "incoming/u1/id/b.sqlite"
+
+	id, err := l.CreateMultipart(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []struct {
+		number  int
+		content string
+	}{{2, "world"}, {1, "hi"}, {1, "hello "}} {
+		if err := l.UploadPart(ctx, key, id, p.number, strings.NewReader(p.content), int64(len(p.content))); err != nil {
+			t.Fatalf("UploadPart %d: %v", p.number, err)
+		}
+	}
+	if err := l.UploadPart(ctx, key, id, 0, strings.NewReader("x"), 1); err == nil {
+		t.Error("UploadPart 0 succeeded")
+	}
+	if _, err := l.ListParts(ctx, "incoming/u1/other/b.sqlite", id); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("ListParts of another key = %v, want ErrUploadNotFound", err)
+	}
+
+	parts, err := l.ListParts(ctx, key, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 || parts[0].Number != 1 || parts[0].Size != 6 || parts[1].Number != 2 || parts[1].Size != 5 {
+		t.Fatalf("parts = %+v, want 1 (retried) and 2", parts)
+	}
+	if uploads, err := l.ListMultipart(ctx); err != nil || len(uploads) != 1 || uploads[0].Key != key || uploads[0].ID != id {
+		t.Errorf("ListMultipart = %+v, %v", uploads, err)
+	}
+
+	if err := l.CompleteMultipart(ctx, key, id, parts); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := get(t, l, key); content != "hello world" {
+		t.Errorf("content = %q, want the parts in order", content)
+	}
+	if err := l.CompleteMultipart(ctx, key, id, parts); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("second CompleteMultipart = %v, want ErrUploadNotFound", err)
+	}
+
+	aborted, err := l.CreateMultipart(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AbortMultipart(ctx, key, aborted); err != nil {
+		t.Fatal(err)
+	}
+	if uploads, err := l.ListMultipart(ctx); err != nil || len(uploads) != 0 {
+		t.Errorf("ListMultipart after completion and abort = %+v, %v", uploads, err)
+	}
+	for _, id := range []string{"", "not-hex", "../../u1"} {
+		if _, err := l.ListParts(ctx, key, id); !errors.Is(err, ErrUploadNotFound) {
+			t.Errorf("ListParts(%q) = %v, want ErrUploadNotFound", id, err)
+		}
+	}
+}
+
+func TestMove(t *testing.T) {
+	ctx := context.Background()
+	main, backup := newLocal(t), newLocal(t)
+	meta := Metadata{SHA256: "aaaa", Version: "1.1.0"}
+	put(t, main, "u1/a", "backup", meta)
+
+	if err := Move(ctx, main, backup, "u1/a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := main.Stat(ctx, "u1/a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat in the source = %v, want ErrNotFound", err)
+	}
+	if content, obj := get(t, backup, "u1/a"); content != "backup" || obj.Metadata != meta {
+		t.Errorf("moved object = %q, %+v", content, obj)
+	}
+	if err := Move(ctx, main, backup, "u1/a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Move of a missing key = %v, want ErrNotFound", err)
+	}
+}
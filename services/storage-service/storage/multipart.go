@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// MinPartSize is the minimum size of the parts of a multipart upload, but
+// the last one, as required by S3.
+const MinPartSize = 5 << 20
+
+// ErrUploadNotFound is returned for a multipart upload that does not exist,
+// e.g. once completed or aborted.
+var ErrUploadNotFound = errors.New("storage: upload not found")
+
+// Multipart is implemented by the stores that assemble an object from parts
+// uploaded separately, possibly by different requests and instances. S3 maps
+// it onto S3 multipart uploads, Local onto files of a hidden directory.
+type Multipart interface {
+	// CreateMultipart starts an upload to key and returns its ID.
+	CreateMultipart(ctx context.Context, key string) (uploadID string, err error)
+	// UploadPart stores part number (from 1) of the upload, replacing a
+	// previous attempt.
+	UploadPart(ctx context.Context, key, uploadID string, number int, r io.Reader, size int64) error
+	// ListParts returns the parts uploaded so far, by number.
+	ListParts(ctx context.Context, key, uploadID string) ([]Part, error)
+	// CompleteMultipart stores the object made of parts, in order, and ends
+	// the upload.
+	CompleteMultipart(ctx context.Context, key, uploadID string, parts []Part) error
+	// AbortMultipart ends the upload, discarding its parts.
+	AbortMultipart(ctx context.Context, key, uploadID string) error
+	// ListMultipart returns the uploads in progress.
+	ListMultipart(ctx context.Context) ([]Upload, error)
+}
+
+// Part is an uploaded part of a multipart upload.
+type Part struct {
+	Number       int
+	Size         int64
+	ETag         string // identifies the part for S3
+	LastModified time.Time
+}
+
+// Upload is a multipart upload in progress.
+type Upload struct {
+	Key       string
+	ID        string
+	Initiated time.Time
+}
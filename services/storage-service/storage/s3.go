@@ -0,0 +1,338 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// S3Config configures an S3 store.
+type S3Config struct {
+	// Endpoint of the S3 API, e.g. https://<account>.r2.cloudflarestorage.com
+	// for R2. Empty for AWS.
+	Endpoint        string
+	Region          string // "auto" for R2
+	AccessKeyID     string
+	SecretAccessKey string
+	Bucket          string
+}
+
+// R2Endpoint returns the S3 API endpoint of a Cloudflare account.
+func R2Endpoint(accountID string) string {
+	return fmt.Sprintf("https://%s.r2.cloudflarestorage.com", accountID)
+}
+
+// S3 stores objects in a bucket of an S3-compatible API.
+type S3 struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3 returns a store for cfg.Bucket.
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage: bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "auto"
+	}
+
+	client := s3.New(s3.Options{
+		Region:      cfg.Region,
+		Credentials: credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		// R2 does not accept the checksums the SDK sends by default
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	}, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &S3{client: client, bucket: cfg.Bucket}, nil
+}
+
+// User metadata holding the Metadata of an object.
+const (
+	checksumMeta = "sha256"
+	uploadedMeta = "uploaded-sha256"
+	versionMeta  = "schema-version"
+)
+
+// Put spools readers that cannot seek (e.g. another object being moved) to a
+// temporary file: the SDK needs to rewind the body to sign and retry it.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, meta Metadata) error {
+	return s.PutIf(ctx, key, r, size, meta, Condition{})
+}
+
+// PutIf sends the Condition as the If-Match and If-None-Match headers of the
+// PUT, which R2 and S3 check atomically.
+func (s *S3) PutIf(ctx context.Context, key string, r io.Reader, size int64, meta Metadata, cond Condition) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	r, size, done, err := spool(r, size)
+	if err != nil {
+		return err
+	}
+	defer done()
+	in := &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        r,
+		ContentType: aws.String("application/octet-stream"),
+	}
+	if size >= 0 {
+		in.ContentLength = aws.Int64(size)
+	}
+	in.Metadata = map[string]string{}
+	if meta.SHA256 != "" {
+		in.Metadata[checksumMeta] = meta.SHA256
+	}
+	if meta.UploadedSHA256 != "" {
+		in.Metadata[uploadedMeta] = meta.UploadedSHA256
+	}
+	if meta.Version != "" {
+		in.Metadata[versionMeta] = meta.Version
+	}
+	if cond.IfMatch != "" {
+		in.IfMatch = aws.String(cond.IfMatch)
+	}
+	if cond.IfNoneMatch {
+		in.IfNoneMatch = aws.String("*")
+	}
+	_, err = s.client.PutObject(ctx, in)
+	return preconditionFailed(err)
+}
+
+// userMeta reads the Metadata from the user metadata of an object.
+func userMeta(m map[string]string) Metadata {
+	return Metadata{SHA256: m[checksumMeta], UploadedSHA256: m[uploadedMeta], Version: m[versionMeta]}
+}
+
+// spool copies r to a temporary file unless it can seek. done removes the
+// file.
+func spool(r io.Reader, size int64) (_ io.Reader, _ int64, done func(), err error) {
+	if _, ok := r.(io.ReadSeeker); ok {
+		return r, size, func() {}, nil
+	}
+	tmp, err := os.CreateTemp("", "leakr-spool-*")
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	done = func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+	if size, err = io.Copy(tmp, r); err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		done()
+		return nil, 0, nil, err
+	}
+	return tmp, size, done, nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if err := checkKey(key); err != nil {
+		return nil, nil, err
+	}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	return out.Body, &Object{Key: key, Size: aws.ToInt64(out.ContentLength), LastModified: aws.ToTime(out.LastModified), ETag: aws.ToString(out.ETag), Metadata: userMeta(out.Metadata)}, nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (*Object, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &Object{Key: key, Size: aws.ToInt64(out.ContentLength), LastModified: aws.ToTime(out.LastModified), ETag: aws.ToString(out.ETag), Metadata: userMeta(out.Metadata)}, nil
+}
+
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range page.Contents {
+			objects = append(objects, Object{Key: aws.ToString(o.Key), Size: aws.ToInt64(o.Size), LastModified: aws.ToTime(o.LastModified)})
+		}
+	}
+	return objects, nil
+}
+
+// Delete checks that the object exists first: S3 deletes are idempotent and
+// do not report missing keys.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if _, err := s.Stat(ctx, key); err != nil {
+		return err
+	}
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(key)})
+	return err
+}
+
+func (s *S3) CreateMultipart(ctx context.Context, key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String("application/octet-stream"),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+func (s *S3) UploadPart(ctx context.Context, key, uploadID string, number int, r io.Reader, size int64) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	r, size, done, err := spool(r, size)
+	if err != nil {
+		return err
+	}
+	defer done()
+	in := &s3.UploadPartInput{
+		Bucket:     aws.String(s.bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(int32(number)),
+		Body:       r,
+	}
+	if size >= 0 {
+		in.ContentLength = aws.Int64(size)
+	}
+	_, err = s.client.UploadPart(ctx, in)
+	return uploadNotFound(err)
+}
+
+func (s *S3) ListParts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	var parts []Part
+	pages := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, uploadNotFound(err)
+		}
+		for _, p := range page.Parts {
+			parts = append(parts, Part{
+				Number:       int(aws.ToInt32(p.PartNumber)),
+				Size:         aws.ToInt64(p.Size),
+				ETag:         aws.ToString(p.ETag),
+				LastModified: aws.ToTime(p.LastModified),
+			})
+		}
+	}
+	return parts, nil
+}
+
+func (s *S3) CompleteMultipart(ctx context.Context, key, uploadID string, parts []Part) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	completed := make([]types.CompletedPart, len(parts))
+	for i, p := range parts {
+		completed[i] = types.CompletedPart{PartNumber: aws.Int32(int32(p.Number)), ETag: aws.String(p.ETag)}
+	}
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	return uploadNotFound(err)
+}
+
+func (s *S3) AbortMultipart(ctx context.Context, key, uploadID string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	return uploadNotFound(err)
+}
+
+func (s *S3) ListMultipart(ctx context.Context) ([]Upload, error) {
+	var uploads []Upload
+	in := &s3.ListMultipartUploadsInput{Bucket: aws.String(s.bucket)}
+	for {
+		out, err := s.client.ListMultipartUploads(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range out.Uploads {
+			uploads = append(uploads, Upload{Key: aws.ToString(u.Key), ID: aws.ToString(u.UploadId), Initiated: aws.ToTime(u.Initiated)})
+		}
+		if !aws.ToBool(out.IsTruncated) {
+			return uploads, nil
+		}
+		in.KeyMarker, in.UploadIdMarker = out.NextKeyMarker, out.NextUploadIdMarker
+	}
+}
+
+// uploadNotFound maps the S3 "no such upload" errors to ErrUploadNotFound.
+func uploadNotFound(err error) error {
+	var noSuchUpload *types.NoSuchUpload
+	var apiErr smithy.APIError
+	if errors.As(err, &noSuchUpload) || (errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchUpload") {
+		return ErrUploadNotFound
+	}
+	return err
+}
+
+// preconditionFailed maps the S3 errors of a failed condition to
+// ErrPreconditionFailed: 412, or 409 when a concurrent conditional write won.
+func preconditionFailed(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "PreconditionFailed" || apiErr.ErrorCode() == "ConditionalRequestConflict") {
+		return ErrPreconditionFailed
+	}
+	return err
+}
+
+// notFound maps the S3 "no such key" errors to ErrNotFound. HEAD responses
+// have no body, hence no error code beyond the status.
+func notFound(err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	var apiErr smithy.APIError
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) ||
+		(errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NoSuchKey" || apiErr.ErrorCode() == "NotFound")) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 serves the objects of the bucket "main", path-style, checking the
+// conditional headers of PUTs as S3 does.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	writes  int
+}
+
+type fakeObject struct {
+	body string
+	etag string
+	meta http.Header
+}
+
+func newFakeS3(t *testing.T) *S3 {
+	t.Helper()
+	srv := httptest.NewServer(&fakeS3{objects: map[string]fakeObject{}})
+	t.Cleanup(srv.Close)
+	s, err := NewS3(S3Config{Endpoint: srv.URL, AccessKeyID: "key", SecretAccessKey: "secret", Bucket: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func s3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%[1]s</Message></Error>`, code)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key, ok := strings.CutPrefix(r.URL.Path, "/main/")
+	if !ok {
+		s3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	obj, exists := f.objects[key]
+
+	switch r.Method {
+	case http.MethodPut:
+		if m := r.Header.Get("If-Match"); m != "" && (!exists || m != obj.etag) {
+			s3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			s3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.writes++
+		obj = fakeObject{body: string(body), etag: fmt.Sprintf(`"etag-%d"`, f.writes), meta: http.Header{}}
+		for k, v := range r.Header {
+			if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+				obj.meta[k] = v
+			}
+		}
+		f.objects[key] = obj
+		w.Header().Set("ETag", obj.etag)
+	case http.MethodGet, http.MethodHead:
+		if !exists {
+			// HEAD responses have no body, hence no error code
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		for k, v := range obj.meta {
+			w.Header()[k] = v
+		}
+		w.Header().Set("ETag", obj.etag)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+		if r.Method == http.MethodGet {
+			io.WriteString(w, obj.body)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func TestS3(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeS3(t)
+
+	meta := Metadata{SHA256: "aaaa", UploadedSHA256: "bbbb", Version: "1.1.0"}
+	put(t, s, "u1/b.sqlite", "backup", meta)
+	content, obj := get(t, s, "u1/b.sqlite")
+	if content != "backup" || obj.Size != 6 || obj.Metadata != meta || obj.ETag == "" {
+		t.Errorf("Get = %q, %+v", content, obj)
+	}
+	if st, err := s.Stat(ctx, "u1/b.sqlite"); err != nil || st.Metadata != meta || st.ETag != obj.ETag {
+		t.Errorf("Stat = %+v, %v, want the object of Get", st, err)
+	}
+
+	// Not seekable: spooled
+	if err := s.Put(ctx, "u1/c.sqlite", io.MultiReader(strings.NewReader("spo"), strings.NewReader("oled")), -1, Metadata{}); err != nil {
+		t.Fatalf("Put of a reader that cannot seek: %v", err)
+	}
+	if content, _ := get(t, s, "u1/c.sqlite"); content != "spooled" {
+		t.Errorf("content = %q, want spooled", content)
+	}
+
+	if _, _, err := s.Get(ctx, "u1/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a missing key = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Stat(ctx, "u1/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat of a missing key = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "u1/c.sqlite"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, "u1/c.sqlite"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete = %v, want ErrNotFound", err)
+	}
+	if err := s.Put(ctx, "../escape", strings.NewReader("x"), 1, Metadata{}); err == nil {
+		t.Error("Put of an invalid key succeeded")
+	}
+}
+
+func TestS3PutIf(t *testing.T) {
+	ctx := context.Background()
+	s := newFakeS3(t)
+	putIf := func(content string, cond Condition) error {
+		return s.PutIf(ctx, "leases/u1", strings.NewReader(content), int64(len(content)), Metadata{}, cond)
+	}
+
+	if err := putIf("x", Condition{IfMatch: `"etag-0"`}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("IfMatch on a missing object = %v, want ErrPreconditionFailed", err)
+	}
+	if err := putIf("x", Condition{IfNoneMatch: true}); err != nil {
+		t.Fatalf("IfNoneMatch on a missing object: %v", err)
+	}
+	if err := putIf("x", Condition{IfNoneMatch: true}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("IfNoneMatch on an existing object = %v, want ErrPreconditionFailed", err)
+	}
+	first, err := s.Stat(ctx, "leases/u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := putIf("y", Condition{IfMatch: first.ETag}); err != nil {
+		t.Fatalf("IfMatch of the current ETag: %v", err)
+	}
+	if err := putIf("z", Condition{IfMatch: first.ETag}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("IfMatch of a stale ETag = %v, want ErrPreconditionFailed", err)
+	}
+	if content, _ := get(t, s, "leases/u1"); content != "y" {
+		t.Errorf("content = %q, want the last successful write", content)
+	}
+}
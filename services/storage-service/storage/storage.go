@@ -0,0 +1,99 @@
+// Package storage stores the backup files of storage-service behind the
+// BlobStore interface: S3 talks to Cloudflare R2 (or any S3-compatible
+// store) in production, Local keeps the files on disk for development and
+// tests.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// ErrNotFound is returned when a key does not exist.
+var ErrNotFound = errors.New("storage: object not found")
+
+// ErrPreconditionFailed is returned by PutIf when the Condition does not hold.
+var ErrPreconditionFailed = errors.New("storage: precondition failed")
+
+// BlobStore stores objects under slash-separated keys, e.g.
+// "<uuid>/leakr_db_<uuid>_<date>_it<n>.sqlite".
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any existing
+	// object. The object is only visible once completely written. meta is
+	// kept with the object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, meta Metadata) error
+	// PutIf is Put when cond holds, atomically with the other PutIf calls
+	// on key, whichever the instance; it returns ErrPreconditionFailed
+	// otherwise.
+	PutIf(ctx context.Context, key string, r io.Reader, size int64, meta Metadata, cond Condition) error
+	// Get opens the object stored under key. The caller closes the reader.
+	// Get and Stat return the Metadata given to Put, List does not.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// Stat returns the metadata of the object stored under key.
+	Stat(ctx context.Context, key string) (*Object, error)
+	// List returns the objects whose key starts with prefix, in key order.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes the object stored under key.
+	Delete(ctx context.Context, key string) error
+}
+
+// Object is the metadata of a stored object.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	// ETag identifies the content of the object for Condition.IfMatch; it
+	// changes whenever the object is written. Set by Get and Stat only.
+	ETag string
+	Metadata
+}
+
+// Condition is the precondition of a PutIf on the object stored under its
+// key.
+type Condition struct {
+	// IfMatch, when set, requires the object to exist with this ETag.
+	IfMatch string
+	// IfNoneMatch requires no object to exist.
+	IfNoneMatch bool
+}
+
+// Metadata is kept with an object by Put.
+type Metadata struct {
+	SHA256 string // hex SHA-256 of the content, empty when unknown
+	// UploadedSHA256 is the SHA-256 of the content as uploaded, when it was
+	// rewritten since (see package migrate); empty otherwise.
+	UploadedSHA256 string
+	// Version is the schema version of the stored backup (version_texte),
+	// empty when unknown.
+	Version string
+}
+
+// Move copies the object stored under key from one store to another, then
+// deletes the original: a failed move leaves the object in from, possibly
+// duplicated in to.
+func Move(ctx context.Context, from, to BlobStore, key string) error {
+	r, obj, err := from.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	err = to.Put(ctx, key, r, obj.Size, obj.Metadata)
+	if cerr := r.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return from.Delete(ctx, key)
+}
+
+// checkKey rejects the keys that could escape a prefix or a directory.
+func checkKey(key string) error {
+	if !fs.ValidPath(key) || key == "." {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
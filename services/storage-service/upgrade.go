@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"storage-service/backups"
+	"storage-service/migrate"
+	"storage-service/storage"
+)
+
+// runUpgrade implements the `upgrade` subcommand: upgrades every stored
+// backup older than the extension's schema (see migrate.Job). -dry-run
+// upgrades copies and only logs what it would store.
+func runUpgrade(args []string) {
+	fs := flag.NewFlagSet("upgrade", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "log the changes without applying them")
+	_ = fs.Parse(args)
+
+	stores, err := storesFromEnv()
+	if err != nil {
+		log.Fatalf("failed initializing storage: %v", err)
+	}
+
+	job := &migrate.Job{Stores: stores, DryRun: *dryRun}
+	res, err := job.RunOnce(context.Background())
+	if err != nil {
+		log.Fatalf("upgrading backups: %v", err)
+	}
+	if *dryRun {
+		log.Printf("Dry run: would upgrade %d of %d backup(s) to %s, %d cannot be", res.Upgraded, res.Backups, migrate.Target, res.Failed)
+		return
+	}
+	log.Printf("Upgraded %d of %d backup(s) to %s, %d cannot be", res.Upgraded, res.Backups, migrate.Target, res.Failed)
+}
+
+// upgradeBackup upgrades a stored backup of the caller to the extension's
+// schema, in place (see migrate.Job.Backup). It returns false when the
+// request was answered with an error.
+func (s *server) upgradeBackup(c *fiber.Ctx, name backups.Name) bool {
+	ctx := c.UserContext()
+	// Pas d'upload concurrent du même utilisateur pendant la réécriture
+	unlock, err := s.lockUser(ctx, name.UUID)
+	if err != nil {
+		log.Printf("Locking backups of %s failed: %v", name.UUID, err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to upgrade backup"})
+		return false
+	}
+	defer unlock()
+
+	location, _, err := s.stores.Find(ctx, name)
+	if err == nil {
+		job := &migrate.Job{Stores: s.stores}
+		_, _, err = job.Backup(ctx, location, name)
+	}
+	var failure *migrate.Failure
+	switch {
+	// Déplacée par la rétention entre-temps : envoyée telle quelle
+	case err == nil, errors.Is(err, migrate.ErrChanged):
+		return true
+	case errors.Is(err, storage.ErrNotFound):
+		_ = c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Backup not found"})
+	case errors.As(err, &failure):
+		_ = c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": "Backup cannot be upgraded", "reason": failure.Reason, "detail": failure.Detail})
+	default:
+		log.Printf("Upgrading backup %s failed: %v", name.Key(), err)
+		_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to upgrade backup"})
+	}
+	return false
+}
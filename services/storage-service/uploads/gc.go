@@ -0,0 +1,123 @@
+package uploads
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"storage-service/backups"
+	"storage-service/storage"
+)
+
+// DefaultTTL is how long an upload may stay incomplete.
+const DefaultTTL = 24 * time.Hour
+
+// DefaultInterval is the time between two runs of GC.
+const DefaultInterval = time.Hour
+
+// GC aborts the uploads started more than TTL ago, and deletes the incoming
+// backups left behind by a completion that failed midway. It only touches
+// keys under backups.IncomingPrefix and tolerates concurrent runs, so every
+// instance may run it.
+type GC struct {
+	// Store holds the uploads: the main store.
+	Store     storage.BlobStore
+	Multipart storage.Multipart
+	// TTL of an incomplete upload; DefaultTTL when zero.
+	TTL time.Duration
+	// Interval between two runs; DefaultInterval when zero.
+	Interval time.Duration
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+}
+
+// Result reports what a run did.
+type Result struct {
+	// Aborted is the number of uploads aborted.
+	Aborted int
+	// Deleted is the number of incoming backups deleted.
+	Deleted int
+}
+
+// Run runs GC immediately, then every Interval until ctx is done.
+func (g *GC) Run(ctx context.Context) {
+	interval := g.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := g.RunOnce(ctx)
+		switch {
+		case err != nil:
+			log.Printf("Upload GC failed: %v", err)
+		case res.Aborted > 0 || res.Deleted > 0:
+			log.Printf("Upload GC: %d stale upload(s) aborted, %d incoming backup(s) deleted", res.Aborted, res.Deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce aborts the stale uploads and deletes the stale incoming backups.
+func (g *GC) RunOnce(ctx context.Context) (Result, error) {
+	var res Result
+	ttl := g.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	cutoff := g.now().Add(-ttl)
+
+	pending, err := g.Multipart.ListMultipart(ctx)
+	if err != nil {
+		return res, err
+	}
+	for _, u := range pending {
+		if !strings.HasPrefix(u.Key, backups.IncomingPrefix) || u.Initiated.After(cutoff) {
+			continue
+		}
+		// Another instance may have aborted it, or its client completed it
+		err := g.Multipart.AbortMultipart(ctx, u.Key, u.ID)
+		if errors.Is(err, storage.ErrUploadNotFound) {
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		res.Aborted++
+	}
+
+	incoming, err := g.Store.List(ctx, backups.IncomingPrefix)
+	if err != nil {
+		return res, err
+	}
+	for _, o := range incoming {
+		if o.LastModified.After(cutoff) {
+			continue
+		}
+		err := g.Store.Delete(ctx, o.Key)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		res.Deleted++
+	}
+	return res, nil
+}
+
+func (g *GC) now() time.Time {
+	if g.Now != nil {
+		return g.Now()
+	}
+	return time.Now()
+}
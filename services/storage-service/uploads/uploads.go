@@ -0,0 +1,138 @@
+// Package uploads describes the resumable uploads of backups.
+//
+// A large database is uploaded in parts of Session.PartSize bytes (the last
+// one being shorter), each sent with its SHA-256 and retried on its own
+// until stored. The parts are kept by the store (see storage.Multipart):
+// S3 multipart uploads on R2, a hidden directory for storage.Local. Once
+// complete, they are assembled under backups.IncomingPrefix, validated like
+// a single-shot upload and only then stored as the backup.
+//
+// The service keeps no state: the upload token handed to the client carries
+// the session, signed with a key shared by the instances, so that any
+// instance can serve any part. GC aborts the uploads abandoned by their
+// client.
+package uploads
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"storage-service/backups"
+	"storage-service/storage"
+)
+
+// ErrInvalidToken is returned for an upload token not issued by the service.
+var ErrInvalidToken = errors.New("uploads: invalid upload token")
+
+// Session is a resumable upload of a backup.
+type Session struct {
+	// ID names the upload in the key it is assembled under (see
+	// backups.Name.IncomingKey). Empty in the tokens issued before it.
+	ID       string `json:"i,omitempty"`
+	Filename string `json:"f"`
+	Size     int64  `json:"s"`
+	PartSize int64  `json:"p"`
+	// UploadID is the ID of the multipart upload in the store.
+	UploadID string `json:"u"`
+	// Precondition is checked once the upload is complete.
+	Precondition backups.Precondition `json:"c"`
+}
+
+// NewID returns a random Session.ID.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// IncomingKey returns the key the backup of s is assembled under.
+func (s Session) IncomingKey(name backups.Name) string {
+	return name.IncomingKey(s.ID)
+}
+
+// Token encodes s for the client, signed with key (HMAC-SHA256) so that the
+// client cannot change the size of the upload nor of its parts. The handlers
+// still check that Filename belongs to the caller, and the store that
+// UploadID belongs to the key of Filename.
+func (s Session) Token(key []byte) string {
+	b, _ := json.Marshal(s)
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(sign(key, payload))
+}
+
+// ParseToken decodes an upload token signed with key.
+func ParseToken(token string, key []byte) (Session, error) {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return Session{}, ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, sign(key, payload)) {
+		return Session{}, ErrInvalidToken
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Session{}, ErrInvalidToken
+	}
+	var s Session
+	if err := json.Unmarshal(b, &s); err != nil || s.UploadID == "" || s.Size <= 0 || s.PartSize <= 0 {
+		return Session{}, ErrInvalidToken
+	}
+	return s, nil
+}
+
+func sign(key []byte, payload string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
+
+// Name returns the parsed filename of the backup.
+func (s Session) Name() (backups.Name, error) {
+	return backups.ParseName(s.Filename)
+}
+
+// Parts returns the number of parts of the upload.
+func (s Session) Parts() int {
+	return int((s.Size + s.PartSize - 1) / s.PartSize)
+}
+
+// PartSizeOf returns the size of part number, or false when the upload has
+// no such part.
+func (s Session) PartSizeOf(number int) (int64, bool) {
+	n := s.Parts()
+	switch {
+	case number < 1 || number > n:
+		return 0, false
+	case number < n:
+		return s.PartSize, true
+	default:
+		return s.Size - int64(n-1)*s.PartSize, true
+	}
+}
+
+// Missing returns the numbers of the parts not uploaded yet among parts (a
+// part of an unexpected size counts as missing).
+func (s Session) Missing(parts []storage.Part) []int {
+	uploaded := make(map[int]bool, len(parts))
+	for _, p := range parts {
+		if size, ok := s.PartSizeOf(p.Number); ok && size == p.Size {
+			uploaded[p.Number] = true
+		}
+	}
+	missing := []int{}
+	for number := 1; number <= s.Parts(); number++ {
+		if !uploaded[number] {
+			missing = append(missing, number)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,59 @@
+package uploads
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"storage-service/backups"
+)
+
+func TestToken(t *testing.T) {
+	key := []byte("secret")
+	s := Session{ID: "0123abcd", Filename: "f.sqlite", Size: 100, PartSize: 10, UploadID: "u1"}
+
+	got, err := ParseToken(s.Token(key), key)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if got != s {
+		t.Errorf("ParseToken = %+v, want %+v", got, s)
+	}
+
+	// A client raising the size keeps the signature of the original payload
+	payload, sig, _ := strings.Cut(s.Token(key), ".")
+	b, _ := base64.RawURLEncoding.DecodeString(payload)
+	var m map[string]any
+	_ = json.Unmarshal(b, &m)
+	m["s"] = 1 << 40
+	b, _ = json.Marshal(m)
+	tampered := base64.RawURLEncoding.EncodeToString(b) + "." + sig
+
+	for name, token := range map[string]string{
+		"tampered":  tampered,
+		"other key": s.Token([]byte("other")),
+		"unsigned":  payload,
+		"garbage":   "not a token",
+	} {
+		if _, err := ParseToken(token, key); err != ErrInvalidToken {
+			t.Errorf("%s: ParseToken error = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestIncomingKey(t *testing.T) {
+	name, err := backups.ParseName("leakr_db_0b0e3f0a-1111-4222-8333-444455556666_2025-05-17 10-21-03_it5.sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := Session{ID: "aaaa"}, Session{ID: "bbbb"}
+	if a.IncomingKey(name) == b.IncomingKey(name) {
+		t.Errorf("two uploads of %s share the key %s", name.Filename, a.IncomingKey(name))
+	}
+	for _, s := range []Session{a, {}} {
+		if key := s.IncomingKey(name); !strings.HasPrefix(key, backups.IncomingPrefix+backups.Prefix(name.UUID)) || !strings.HasSuffix(key, "/"+name.Filename) {
+			t.Errorf("IncomingKey = %s, want it under %s%s", key, backups.IncomingPrefix, backups.Prefix(name.UUID))
+		}
+	}
+}
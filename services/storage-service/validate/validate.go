@@ -0,0 +1,228 @@
+// Package validate checks an uploaded backup before it replaces the stored
+// ones, so that a buggy or malicious client cannot overwrite a good backup
+// with garbage.
+//
+// The file is opened read-only with a pure-Go SQLite driver
+// (modernc.org/sqlite) and must be an intact database with the extension's
+// schema (see createSchema in extension/src/lib/dbUtils.ts), belonging to
+// the uploading user. Every failed check returns a *Rejection with a stable
+// Reason the client can act on.
+package validate
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// Reasons of a Rejection.
+const (
+	// ReasonTooLarge: the file exceeds the maximum size.
+	ReasonTooLarge = "too_large"
+	// ReasonNotSQLite: the file is not an SQLite database.
+	ReasonNotSQLite = "not_sqlite"
+	// ReasonCorrupt: PRAGMA integrity_check reported errors.
+	ReasonCorrupt = "integrity_check_failed"
+	// ReasonInvalidVersion: the version table or its row is missing or invalid.
+	ReasonInvalidVersion = "invalid_version"
+	// ReasonMissingTables: some of the extension's tables are missing.
+	ReasonMissingTables = "missing_tables"
+	// ReasonUUIDMismatch: settings.uuid is not the uploading user's UUID.
+	ReasonUUIDMismatch = "uuid_mismatch"
+	// ReasonIterationMismatch: version.iterations differs from the filename.
+	ReasonIterationMismatch = "iteration_mismatch"
+)
+
+// Tables lists the tables every backup must contain.
+var Tables = []string{"version", "settings", "createurs", "contenus", "plateformes", "profils_plateforme"}
+
+// maxIntegrityErrors bounds the errors reported by integrity_check.
+const maxIntegrityErrors = 5
+
+var (
+	sqliteHeader = []byte("SQLite format 3\x00")
+	versionRe    = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+)
+
+// Rejection reports why a backup was rejected.
+type Rejection struct {
+	Reason string `json:"reason"`
+	Detail string `json:"detail"`
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("validate: backup rejected (%s): %s", r.Reason, r.Detail)
+}
+
+func reject(reason, format string, args ...any) *Rejection {
+	return &Rejection{Reason: reason, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Expect describes the backup the caller claims to upload.
+type Expect struct {
+	// UUID is the public UUID of the uploading user.
+	UUID string
+	// Iteration is the iteration in the filename; negative to skip the check.
+	Iteration int64
+	// MaxSize is the maximum file size in bytes; zero for no limit.
+	MaxSize int64
+}
+
+// Info describes a valid backup.
+type Info struct {
+	Version    string `json:"version"`
+	Iterations int64  `json:"iterations"`
+	DateMaj    string `json:"date_maj"`
+	UUID       string `json:"uuid"`
+}
+
+// Backup validates the SQLite database at path. It returns a *Rejection
+// when the file is not a valid backup of the expected user, and other errors
+// when the checks could not run.
+func Backup(ctx context.Context, path string, want Expect) (*Info, error) {
+	if err := checkFile(path, want.MaxSize); err != nil {
+		return nil, err
+	}
+
+	// immutable=1: no lock nor journal, the file is never written
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: path}).EscapedPath()+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if err := integrity(ctx, db); err != nil {
+		return nil, err
+	}
+	if err := tables(ctx, db); err != nil {
+		return nil, err
+	}
+
+	var info Info
+	var dateMaj sql.NullString
+	err = db.QueryRowContext(ctx, "SELECT version_texte, COALESCE(iterations, 0), date_maj FROM version WHERE id = 1").
+		Scan(&info.Version, &info.Iterations, &dateMaj)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reject(ReasonInvalidVersion, "version row is missing")
+	}
+	if err != nil {
+		return nil, reject(ReasonInvalidVersion, "reading version: %v", err)
+	}
+	info.DateMaj = dateMaj.String
+	if !versionRe.MatchString(info.Version) {
+		return nil, reject(ReasonInvalidVersion, "version %q is not a semantic version", info.Version)
+	}
+	if want.Iteration >= 0 && info.Iterations != want.Iteration {
+		return nil, reject(ReasonIterationMismatch, "database is at iteration %d, filename says %d", info.Iterations, want.Iteration)
+	}
+
+	err = db.QueryRowContext(ctx, "SELECT uuid FROM settings ORDER BY id LIMIT 1").Scan(&info.UUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reject(ReasonUUIDMismatch, "settings row is missing")
+	}
+	if err != nil {
+		return nil, reject(ReasonUUIDMismatch, "reading settings.uuid: %v", err)
+	}
+	if !strings.EqualFold(info.UUID, want.UUID) {
+		return nil, reject(ReasonUUIDMismatch, "settings.uuid %q is not the user's UUID", info.UUID)
+	}
+
+	return &info, nil
+}
+
+// checkFile checks the size and the SQLite header before opening the file.
+func checkFile(path string, maxSize int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if maxSize > 0 && st.Size() > maxSize {
+		return reject(ReasonTooLarge, "%d bytes, the maximum is %d", st.Size(), maxSize)
+	}
+
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(f, header); err != nil || !bytes.Equal(header, sqliteHeader) {
+		return reject(ReasonNotSQLite, "missing SQLite header")
+	}
+	return nil
+}
+
+// integrity runs PRAGMA integrity_check, which returns a single "ok" row for
+// a sound database.
+func integrity(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA integrity_check(%d)", maxIntegrityErrors))
+	if err != nil {
+		// The header was right but SQLite cannot read the file
+		return reject(ReasonCorrupt, "%v", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return reject(ReasonCorrupt, "%v", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return reject(ReasonCorrupt, "%v", err)
+	}
+	if len(problems) > 0 {
+		return reject(ReasonCorrupt, "%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// tables checks that the extension's tables exist.
+func tables(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table'")
+	if err != nil {
+		return reject(ReasonCorrupt, "%v", err)
+	}
+	defer rows.Close()
+
+	present := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return reject(ReasonCorrupt, "%v", err)
+		}
+		present[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return reject(ReasonCorrupt, "%v", err)
+	}
+
+	if !present["version"] {
+		return reject(ReasonInvalidVersion, "version table is missing")
+	}
+	var missing []string
+	for _, t := range Tables {
+		if !present[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		return reject(ReasonMissingTables, "missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"storage-service/snapshot"
+	"storage-service/snapshot/snapshottest"
+	"storage-service/validate"
+)
+
+// corrupt overwrites the pages after the first one of the database at path,
+// keeping its header and schema readable.
+func corrupt(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const pageSize = 4096
+	if len(b) <= pageSize {
+		t.Fatalf("%d bytes: no page to corrupt", len(b))
+	}
+	for i := pageSize; i < len(b); i++ {
+		b[i] = 0xa5
+	}
+	dst := filepath.Join(t.TempDir(), "corrupt.sqlite")
+	if err := os.WriteFile(dst, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dst
+}
+
+func TestBackup(t *testing.T) {
+	valid := snapshottest.New(t, snapshot.Version,
+		`INSERT INTO createurs (id, nom, aliases, date_ajout) VALUES (1, 'Alice', '["alice"]', '2026-01-01')`)
+	notSQLite := filepath.Join(t.TempDir(), "backup.sqlite")
+	if err := os.WriteFile(notSQLite, []byte(strings.Repeat("not a database ", 10)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(t.TempDir(), "empty.sqlite")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	st, err := os.Stat(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := validate.Expect{UUID: snapshottest.UUID, Iteration: 1}
+
+	tests := []struct {
+		name   string
+		path   string
+		want   validate.Expect
+		reason string
+	}{
+		{name: "valid", path: valid, want: want},
+		{name: "UUID case", path: valid, want: validate.Expect{UUID: strings.ToUpper(snapshottest.UUID), Iteration: 1}},
+		{name: "iteration not checked", path: valid, want: validate.Expect{UUID: snapshottest.UUID, Iteration: -1}},
+		{name: "at the maximum size", path: valid, want: validate.Expect{UUID: snapshottest.UUID, Iteration: 1, MaxSize: st.Size()}},
+		{name: "too large", path: valid, want: validate.Expect{UUID: snapshottest.UUID, Iteration: 1, MaxSize: st.Size() - 1}, reason: validate.ReasonTooLarge},
+		{name: "not SQLite", path: notSQLite, want: want, reason: validate.ReasonNotSQLite},
+		{name: "empty", path: empty, want: want, reason: validate.ReasonNotSQLite},
+		{name: "integrity check failed", path: corrupt(t, valid), want: want, reason: validate.ReasonCorrupt},
+		{name: "missing table", path: snapshottest.Copy(t, valid, `DROP TABLE profils_plateforme`, `DROP TABLE plateformes`), want: want, reason: validate.ReasonMissingTables},
+		{name: "no version table", path: snapshottest.Copy(t, valid, `DROP TABLE version`), want: want, reason: validate.ReasonInvalidVersion},
+		{name: "no version row", path: snapshottest.Copy(t, valid, `DELETE FROM version`), want: want, reason: validate.ReasonInvalidVersion},
+		{name: "bad version", path: snapshottest.Copy(t, valid, `UPDATE version SET version_texte = 'v2'`), want: want, reason: validate.ReasonInvalidVersion},
+		{name: "iteration mismatch", path: valid, want: validate.Expect{UUID: snapshottest.UUID, Iteration: 2}, reason: validate.ReasonIterationMismatch},
+		{name: "UUID mismatch", path: valid, want: validate.Expect{UUID: "00000000-0000-4000-8000-000000000000", Iteration: 1}, reason: validate.ReasonUUIDMismatch},
+		{name: "no settings row", path: snapshottest.Copy(t, valid, `DELETE FROM settings`), want: want, reason: validate.ReasonUUIDMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := validate.Backup(context.Background(), tt.path, tt.want)
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("Backup: %v", err)
+				}
+				if info.Version != snapshot.Version || info.Iterations != 1 || info.DateMaj != snapshottest.DateMaj || info.UUID != snapshottest.UUID {
+					t.Errorf("info = %+v", info)
+				}
+				return
+			}
+			var r *validate.Rejection
+			if !errors.As(err, &r) {
+				t.Fatalf("Backup = %v, want a rejection (%s)", err, tt.reason)
+			}
+			if r.Reason != tt.reason {
+				t.Errorf("reason = %s (%s), want %s", r.Reason, r.Detail, tt.reason)
+			}
+		})
+	}
+}
+
+func TestBackupMissingTables(t *testing.T) {
+	path := snapshottest.Copy(t, snapshottest.New(t, snapshot.Version), `DROP TABLE profils_plateforme`, `DROP TABLE contenus`)
+	_, err := validate.Backup(context.Background(), path, validate.Expect{UUID: snapshottest.UUID, Iteration: -1})
+	var r *validate.Rejection
+	if !errors.As(err, &r) || r.Detail != "missing contenus, profils_plateforme" {
+		t.Errorf("Backup = %v, want the missing tables in the order of validate.Tables", err)
+	}
+}
+
+func TestBackupMissingFile(t *testing.T) {
+	_, err := validate.Backup(context.Background(), filepath.Join(t.TempDir(), "missing.sqlite"), validate.Expect{})
+	var r *validate.Rejection
+	if err == nil || errors.As(err, &r) {
+		t.Errorf("Backup of a missing file = %v, want an error other than a rejection", err)
+	}
+}